@@ -0,0 +1,75 @@
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var metadataLabels map[string]string
+var annotations map[string]string
+var wait uint64
+
+func NewCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "claim POOL",
+		Short: "Claim a warm VM from a pool and print its name",
+		RunE:  runClaim,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	command.Flags().StringToStringVar(&metadataLabels, "metadata-labels", map[string]string{},
+		"informational labels to attach to the claimed VM (e.g. --metadata-labels=job=42)")
+	command.Flags().StringToStringVar(&annotations, "annotations", map[string]string{},
+		"annotations to attach to the claimed VM (e.g. --annotations=job-url=https://example.com/job/1)")
+	command.Flags().Uint64Var(&wait, "wait", 0,
+		"wait the specified amount of seconds for a VM to become ready if the pool has none")
+
+	return command
+}
+
+func runClaim(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	claim := &v1.VMPoolClaim{
+		Labels:      metadataLabels,
+		Annotations: annotations,
+	}
+
+	deadline := time.Now().Add(time.Duration(wait) * time.Second)
+
+	for {
+		vm, err := client.VMPools().Claim(cmd.Context(), name, claim)
+		if err == nil {
+			fmt.Println(vm.Name)
+
+			return nil
+		}
+
+		if !noReadyVMs(err) || time.Now().After(deadline) {
+			return err
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			return cmd.Context().Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func noReadyVMs(err error) bool {
+	var apiError *client.APIError
+
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusConflict
+}
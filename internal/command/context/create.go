@@ -23,6 +23,7 @@ var ErrCreateFailed = errors.New("failed to create context")
 var bootstrapTokenRaw string
 var serviceAccountName string
 var serviceAccountToken string
+var namespace string
 var force bool
 var noPKI bool
 
@@ -50,6 +51,8 @@ func newCreateCommand() *cobra.Command {
 		"service account name to use (alternative to --bootstrap-token)")
 	command.Flags().StringVar(&serviceAccountToken, "service-account-token", "",
 		"service account token to use (alternative to --bootstrap-token)")
+	command.Flags().StringVar(&namespace, "default-namespace", "",
+		"namespace of the VMs to operate on by default when using this context")
 	command.Flags().BoolVar(&force, "force", false,
 		"create the context even if a context with the same name already exists")
 	command.Flags().BoolVar(&noPKI, "no-pki", false,
@@ -141,6 +144,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		URL:                 controllerURL.String(),
 		ServiceAccountName:  serviceAccountName,
 		ServiceAccountToken: serviceAccountToken,
+		Namespace:           namespace,
 	}
 
 	if trustedCertificate != nil {
@@ -32,7 +32,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	table := uitable.New()
 	table.Wrap = true
 
-	table.AddRow("Name", "URL", "Default")
+	table.AddRow("Name", "URL", "Default namespace", "Default")
 
 	for name, context := range config.Contexts {
 		var defaultMark string
@@ -40,7 +40,7 @@ func runList(cmd *cobra.Command, args []string) error {
 			defaultMark = "*"
 		}
 
-		table.AddRow(name, context.URL, defaultMark)
+		table.AddRow(name, context.URL, context.Namespace, defaultMark)
 	}
 
 	fmt.Println(table)
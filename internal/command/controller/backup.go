@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var backupOutputPath string
+
+func newBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the running controller's database and PKI material",
+		Long: "Stream a consistent snapshot of the controller's database and PKI material " +
+			"from the controller in the current context without stopping it.\n\n" +
+			"The resulting archive can be restored with \"orchard controller restore\".",
+		RunE: runBackup,
+	}
+
+	cmd.Flags().StringVarP(&backupOutputPath, "output", "o", "-",
+		"path to the file to write the backup archive to (\"-\" for standard output)")
+
+	return cmd
+}
+
+func runBackup(cmd *cobra.Command, args []string) (err error) {
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	backup, err := client.Controller().Backup(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = backup.Close()
+	}()
+
+	var output io.Writer = os.Stdout
+
+	if backupOutputPath != "-" {
+		outputFile, err := os.Create(backupOutputPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := outputFile.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+
+			// Avoid leaving a truncated backup behind
+			if err != nil {
+				_ = os.Remove(backupOutputPath)
+			}
+		}()
+
+		output = outputFile
+	}
+
+	if _, err := io.Copy(output, backup); err != nil {
+		return fmt.Errorf("failed to receive the backup: %w", err)
+	}
+
+	return nil
+}
@@ -12,7 +12,12 @@ func NewCommand() *cobra.Command {
 		Short: "Run a controller on the local machine",
 	}
 
-	command.AddCommand(newRunCommand())
+	command.AddCommand(
+		newRunCommand(),
+		newBackupCommand(),
+		newRestoreCommand(),
+		newSimulateCommand(),
+	)
 
 	command.PersistentFlags().StringVar(&dataDirPath, "data-dir", "",
 		"path to the data controller's directory (defaults to $HOME/.orchard/controller)")
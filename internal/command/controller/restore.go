@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cirruslabs/orchard/internal/controller"
+	"github.com/cirruslabs/orchard/internal/orchardhome"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var restoreForce bool
+
+func newRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore BACKUP_FILE",
+		Short: "Restore the controller's data directory from a backup",
+		Long: "Restore the controller's database and PKI material from the archive " +
+			"created with \"orchard controller backup\" (\"-\" reads it from the standard input).\n\n" +
+			"The controller should not be running while restoring.",
+		Args: cobra.ExactArgs(1),
+		RunE: runRestore,
+	}
+
+	cmd.Flags().BoolVar(&restoreForce, "force", false,
+		"overwrite the existing database and PKI material in the data directory")
+
+	return cmd
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	if dataDirPath == "" {
+		orchardHome, err := orchardhome.Path()
+		if err != nil {
+			return err
+		}
+
+		dataDirPath = filepath.Join(orchardHome, "controller")
+	}
+
+	dataDir, err := controller.NewDataDir(dataDirPath)
+	if err != nil {
+		return err
+	}
+
+	initialized, err := dataDir.Initialized()
+	if err != nil {
+		return err
+	}
+
+	if initialized {
+		if !restoreForce {
+			return fmt.Errorf("%w: data directory %s is not empty, specify --force to overwrite it",
+				controller.ErrRestoreFailed, dataDirPath)
+		}
+
+		if err := os.RemoveAll(dataDir.DBPath()); err != nil {
+			return fmt.Errorf("%w: failed to remove the existing database: %v",
+				controller.ErrRestoreFailed, err)
+		}
+	}
+
+	var input io.Reader = os.Stdin
+
+	if args[0] != "-" {
+		inputFile, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = inputFile.Close()
+		}()
+
+		input = inputFile
+	}
+
+	logger, err := zap.NewDevelopment(zap.IncreaseLevel(zap.WarnLevel))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	if err := controller.Restore(dataDir, input, logger.Sugar()); err != nil {
+		return err
+	}
+
+	fmt.Printf("restored the controller's data directory at %s\n", dataDirPath)
+
+	return nil
+}
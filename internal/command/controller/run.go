@@ -14,10 +14,15 @@ import (
 
 	configpkg "github.com/cirruslabs/orchard/internal/config"
 	"github.com/cirruslabs/orchard/internal/controller"
+	"github.com/cirruslabs/orchard/internal/controller/leaderelection"
+	"github.com/cirruslabs/orchard/internal/controller/store/etcd"
 	"github.com/cirruslabs/orchard/internal/netconstants"
 	"github.com/cirruslabs/orchard/internal/orchardhome"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/namespace"
 	"go.uber.org/zap"
 )
 
@@ -38,6 +43,16 @@ var workerOfflineTimeout time.Duration
 var execSessionRetentionTTL time.Duration
 var execSSHConnectionKeepaliveInterval time.Duration
 var synthetic bool
+var storeKind string
+var etcdEndpoints []string
+var etcdPrefix string
+var leaderElectionIdentity string
+var leaderElectionLeaseDuration time.Duration
+
+const (
+	storeKindBadger = "badger"
+	storeKindEtcd   = "etcd"
+)
 
 func newRunCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -94,6 +109,22 @@ func newRunCommand() *cobra.Command {
 	cmd.Flags().DurationVar(&execSSHConnectionKeepaliveInterval, "exec-ssh-connection-keepalive-interval", 30*time.Second,
 		"interval between SSH keepalive requests sent by the controller for shared exec connections")
 
+	cmd.Flags().StringVar(&storeKind, "store", storeKindBadger,
+		fmt.Sprintf("store to keep the controller's resources in: %q to use an embedded database in the "+
+			"data directory or %q to use an external etcd cluster (see --etcd-endpoints), which allows running "+
+			"multiple controller replicas", storeKindBadger, storeKindEtcd))
+	cmd.Flags().StringSliceVar(&etcdEndpoints, "etcd-endpoints", []string{"127.0.0.1:2379"},
+		"comma-separated list of etcd endpoints to connect to when using --store=etcd")
+	cmd.Flags().StringVar(&etcdPrefix, "etcd-prefix", "/orchard",
+		"prefix for all keys stored in etcd when using --store=etcd, "+
+			"useful when sharing an etcd cluster between multiple Orchard clusters")
+	cmd.Flags().StringVar(&leaderElectionIdentity, "leader-election-identity", "",
+		"identity of this controller replica in the leader election when using --store=etcd "+
+			"(defaults to the hostname with a random suffix)")
+	cmd.Flags().DurationVar(&leaderElectionLeaseDuration, "leader-election-lease-duration", 15*time.Second,
+		"duration after which a leader that stopped renewing its lease is considered gone "+
+			"and another controller replica takes over the scheduling when using --store=etcd")
+
 	// Hidden flags
 	cmd.Flags().BoolVar(&synthetic, "synthetic", false, "")
 	cmd.Flags().MarkHidden("synthetic")
@@ -223,6 +254,42 @@ func runController(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("--exec-ssh-connection-keepalive-interval's value cannot be less than 5 seconds")
 	}
 
+	switch storeKind {
+	case storeKindBadger:
+		// Badger is the default store, nothing to do
+	case storeKindEtcd:
+		etcdClient, err := newEtcdClient(logger)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = etcdClient.Close()
+		}()
+
+		store, err := etcd.NewEtcdStore(etcdClient, logger.Sugar())
+		if err != nil {
+			return err
+		}
+
+		if leaderElectionLeaseDuration < 5*time.Second {
+			return fmt.Errorf("--leader-election-lease-duration's value cannot be less than 5 seconds")
+		}
+
+		identity, err := newLeaderElectionIdentity()
+		if err != nil {
+			return err
+		}
+
+		controllerOpts = append(controllerOpts,
+			controller.WithStore(store),
+			controller.WithLeaderElection(leaderelection.NewEtcd(etcdClient, identity,
+				leaderElectionLeaseDuration, logger.Sugar())),
+		)
+	default:
+		return fmt.Errorf("%w: unsupported store %q, expected %q or %q", ErrRunFailed, storeKind,
+			storeKindBadger, storeKindEtcd)
+	}
+
 	controllerInstance, err := controller.New(controllerOpts...)
 	if err != nil {
 		return err
@@ -246,6 +313,45 @@ func runController(cmd *cobra.Command, args []string) (err error) {
 	return controllerInstance.Run(cmd.Context())
 }
 
+func newEtcdClient(logger *zap.Logger) (*clientv3.Client, error) {
+	if len(etcdEndpoints) == 0 {
+		return nil, fmt.Errorf("%w: --etcd-endpoints cannot be empty when using --store=etcd", ErrRunFailed)
+	}
+
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   etcdEndpoints,
+		DialTimeout: 30 * time.Second,
+		Logger:      logger.With(zap.String("component", "etcd-client")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create etcd client: %v", ErrRunFailed, err)
+	}
+
+	// Isolate all keys under the specified prefix
+	if etcdPrefix != "" {
+		etcdClient.KV = namespace.NewKV(etcdClient.KV, etcdPrefix)
+		etcdClient.Watcher = namespace.NewWatcher(etcdClient.Watcher, etcdPrefix)
+		etcdClient.Lease = namespace.NewLease(etcdClient.Lease, etcdPrefix)
+	}
+
+	return etcdClient, nil
+}
+
+func newLeaderElectionIdentity() (string, error) {
+	if leaderElectionIdentity != "" {
+		return leaderElectionIdentity, nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	// Add a random suffix to disambiguate between
+	// multiple controller replicas on the same host
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]), nil
+}
+
 func createBootstrapContext(
 	controllerAddress string,
 	controllerCert tls.Certificate,
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var ErrSimulateFailed = errors.New("failed to simulate scheduling")
+
+var simulateWorkersPath string
+var simulateVMsPath string
+var simulateVMGroupsPath string
+var simulateQuotasPath string
+var simulateClusterSettingsPath string
+var simulateSchedulerProfile string
+var simulateKeepAssignments bool
+
+func newSimulateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the scheduling of the recorded VMs on the recorded workers",
+		Long: "Replay the recorded VMs through the scheduler offline and show where they would be placed " +
+			"and how utilized the workers would be.\n\n" +
+			"The recordings are the JSON responses of the corresponding controller's API endpoints, " +
+			"e.g. \"GET /v1/workers\" and \"GET /v1/vms\". The recorded workers are considered to be online.",
+		RunE: runSimulate,
+	}
+
+	cmd.Flags().StringVar(&simulateWorkersPath, "workers", "",
+		"path to the recorded list of workers (required)")
+	cmd.Flags().StringVar(&simulateVMsPath, "vms", "",
+		"path to the recorded list of VMs (required)")
+	cmd.Flags().StringVar(&simulateVMGroupsPath, "vm-groups", "",
+		"path to the recorded list of VM groups")
+	cmd.Flags().StringVar(&simulateQuotasPath, "quotas", "",
+		"path to the recorded list of quotas")
+	cmd.Flags().StringVar(&simulateClusterSettingsPath, "cluster-settings", "",
+		"path to the recorded cluster settings")
+	cmd.Flags().StringVar(&simulateSchedulerProfile, "scheduler-profile", "",
+		"scheduler profile to use instead of the recorded one")
+	cmd.Flags().BoolVar(&simulateKeepAssignments, "keep-assignments", false,
+		"keep the recorded VMs assigned to their workers and only place the pending VMs, "+
+			"by default, all VMs are placed from scratch")
+
+	_ = cmd.MarkFlagRequired("workers")
+	_ = cmd.MarkFlagRequired("vms")
+
+	return cmd
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	var simulation scheduler.Simulation
+
+	if err := readRecording(simulateWorkersPath, &simulation.Workers); err != nil {
+		return err
+	}
+	if err := readRecording(simulateVMsPath, &simulation.VMs); err != nil {
+		return err
+	}
+	if err := readRecording(simulateVMGroupsPath, &simulation.VMGroups); err != nil {
+		return err
+	}
+	if err := readRecording(simulateQuotasPath, &simulation.Quotas); err != nil {
+		return err
+	}
+	if err := readRecording(simulateClusterSettingsPath, &simulation.ClusterSettings); err != nil {
+		return err
+	}
+
+	if simulateSchedulerProfile != "" {
+		schedulerProfile, err := v1.NewSchedulerProfile(simulateSchedulerProfile)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrSimulateFailed, err)
+		}
+
+		simulation.ClusterSettings.SchedulerProfile = schedulerProfile
+		simulation.ClusterSettings.SchedulerScoreWeights = nil
+	}
+
+	simulation.WorkerOfflineTimeout = time.Hour
+
+	for i := range simulation.Workers {
+		simulation.Workers[i].LastSeen = time.Now()
+	}
+
+	if !simulateKeepAssignments {
+		simulation.VMs = lo.FilterMap(simulation.VMs, func(vm v1.VM, _ int) (v1.VM, bool) {
+			if vm.TerminalState() || vm.PowerState.TerminalState() {
+				return v1.VM{}, false
+			}
+
+			vm.Worker = ""
+			vm.AssignedCPU = 0
+			vm.AssignedMemory = 0
+			v1.ConditionsSet(&vm.Conditions, v1.Condition{
+				Type:  v1.ConditionTypeScheduled,
+				State: v1.ConditionStateFalse,
+			})
+
+			return vm, true
+		})
+	}
+
+	result := scheduler.Simulate(simulation)
+
+	placementTable := uitable.New()
+	placementTable.Wrap = true
+	placementTable.AddRow("Namespace", "VM", "Worker")
+
+	for _, vm := range result.VMs {
+		if explanation, ok := result.Explanations[v1.NamespacedName(vm.Namespace, vm.Name)]; ok {
+			placementTable.AddRow(vm.Namespace, vm.Name, fmt.Sprintf("pending (%s)", explanation.Message))
+		} else if vm.Worker != "" {
+			placementTable.AddRow(vm.Namespace, vm.Name, vm.Worker)
+		}
+	}
+
+	fmt.Println(placementTable)
+	fmt.Println()
+
+	resourceNames := lo.Uniq(lo.FlatMap(simulation.Workers, func(worker v1.Worker, _ int) []string {
+		return lo.Keys(worker.Resources)
+	}))
+	slices.Sort(resourceNames)
+
+	utilizationTable := uitable.New()
+	utilizationTable.AddRow(append([]any{"Worker", "VMs"}, lo.ToAnySlice(resourceNames)...)...)
+
+	for _, worker := range simulation.Workers {
+		workerInfo := result.WorkerInfos.Get(worker.Name)
+
+		row := []any{worker.Name, workerInfo.NumRunningVMs}
+
+		for _, resourceName := range resourceNames {
+			row = append(row, utilization(workerInfo.ResourcesUsed[resourceName], worker.Resources[resourceName]))
+		}
+
+		utilizationTable.AddRow(row...)
+	}
+
+	fmt.Println(utilizationTable)
+	fmt.Println()
+
+	numPending := len(lo.Filter(result.VMs, func(vm v1.VM, _ int) bool {
+		_, ok := result.Explanations[v1.NamespacedName(vm.Namespace, vm.Name)]
+
+		return ok
+	}))
+
+	fmt.Printf("%d VM(s) placed, %d VM(s) would stay pending\n", len(result.Placed), numPending)
+
+	return nil
+}
+
+func utilization(used uint64, total uint64) string {
+	if total == 0 {
+		return fmt.Sprintf("%d/0", used)
+	}
+
+	return fmt.Sprintf("%d/%d (%d%%)", used, total, used*100/total)
+}
+
+func readRecording(path string, target any) error {
+	if path == "" {
+		return nil
+	}
+
+	recording, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read the recording: %v", ErrSimulateFailed, err)
+	}
+
+	if err := json.Unmarshal(recording, target); err != nil {
+		return fmt.Errorf("%w: failed to parse the recording %s: %v", ErrSimulateFailed, path, err)
+	}
+
+	return nil
+}
@@ -4,13 +4,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var metadataLabels map[string]string
+var annotations map[string]string
+
 func NewCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "create",
 		Short: "Create resources on the controller",
 	}
 
-	command.AddCommand(newCreateVMCommand(), newCreateServiceAccount())
+	command.PersistentFlags().StringToStringVar(&metadataLabels, "metadata-labels", map[string]string{},
+		"informational labels to attach to the resource (e.g. --metadata-labels=team=ci), "+
+			"unlike VM's --labels these are not taken into account when scheduling")
+	command.PersistentFlags().StringToStringVar(&annotations, "annotations", map[string]string{},
+		"annotations to attach to the resource (e.g. --annotations=job-url=https://example.com/job/1)")
+
+	command.AddCommand(newCreateVMCommand(), newCreateVMGroupCommand(), newCreatePoolCommand(),
+		newCreateVMSetCommand(), newCreateVMTemplateCommand(), newCreateQuotaCommand(), newCreateServiceAccount())
 
 	return command
 }
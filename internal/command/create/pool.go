@@ -0,0 +1,54 @@
+package create
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var replicas uint64
+
+func newCreatePoolCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pool NAME",
+		Short: "Create a pool of warm VMs that can be claimed with \"orchard claim\"",
+		RunE:  runCreatePool,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	addVMFlags(command)
+
+	command.Flags().Uint64Var(&replicas, "replicas", 1,
+		"number of warm VMs that the pool maintains")
+
+	return command
+}
+
+func runCreatePool(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// The template's name is ignored by the controller,
+	// so use the pool's name for the client-side validation
+	template, err := newVMFromFlags(cmd, name)
+	if err != nil {
+		return err
+	}
+
+	// Metadata labels and annotations are attached to the pool itself
+	template.Meta = v1.Meta{}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMPools().Create(cmd.Context(), &v1.VMPool{
+		Meta: v1.Meta{
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
+		},
+		Replicas: replicas,
+		Template: *template,
+	})
+}
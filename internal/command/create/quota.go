@@ -0,0 +1,63 @@
+package create
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var quotaNamespace string
+var quotaServiceAccount string
+var quotaLimits map[string]string
+
+func newCreateQuotaCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "quota NAME",
+		Short: "Create a quota",
+		RunE:  runCreateQuota,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	command.Flags().StringVar(&quotaNamespace, "for-namespace", "",
+		"limit the VMs in the specified namespace")
+	command.Flags().StringVar(&quotaServiceAccount, "for-service-account", "",
+		"limit the VMs created by the specified service account in all namespaces")
+	command.Flags().StringToStringVar(&quotaLimits, "limit", map[string]string{},
+		fmt.Sprintf("limit to enforce, specified as KEY=VALUE, where KEY is either %q (number of VMs), "+
+			"%q (number of CPUs), %q (amount of memory in megabytes) or a custom resource name "+
+			"(e.g. --limit %s=4), can be specified multiple times", v1.QuotaResourceVMs,
+			v1.QuotaResourceCPU, v1.QuotaResourceMemory, v1.ResourceTartVMs))
+	command.MarkFlagsMutuallyExclusive("for-namespace", "for-service-account")
+	command.MarkFlagsOneRequired("for-namespace", "for-service-account")
+
+	return command
+}
+
+func runCreateQuota(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	hard, err := v1.NewResourcesFromStringToString(quotaLimits)
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.Quotas().Create(cmd.Context(), &v1.Quota{
+		Meta: v1.Meta{
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
+		},
+		Scope: v1.QuotaScope{
+			Namespace:      quotaNamespace,
+			ServiceAccount: quotaServiceAccount,
+		},
+		Hard: hard,
+	})
+}
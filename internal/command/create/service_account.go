@@ -1,15 +1,20 @@
 package create
 
 import (
+	"errors"
 	"fmt"
 	"github.com/cirruslabs/orchard/pkg/client"
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/spf13/cobra"
+	"slices"
 	"strings"
 )
 
+var ErrInvalidNamespaceRole = errors.New("invalid namespace role")
+
 var token string
 var roles []string
+var namespaceRoles []string
 
 func newCreateServiceAccount() *cobra.Command {
 	command := &cobra.Command{
@@ -29,6 +34,10 @@ func newCreateServiceAccount() *cobra.Command {
 	command.Flags().StringArrayVar(&roles, "roles", []string{},
 		fmt.Sprintf("roles to grant to this service account (supported roles: %s)",
 			strings.Join(serviceAccountRoleList, ", ")))
+	command.Flags().StringArrayVar(&namespaceRoles, "namespace-role", []string{},
+		"role to grant to this service account only in a specific namespace, "+
+			"specified as NAMESPACE=ROLE (e.g. --namespace-role team-a=compute:write), "+
+			"can be specified multiple times")
 
 	return command
 }
@@ -49,11 +58,43 @@ func runCreateServiceAccount(cmd *cobra.Command, args []string) error {
 		serviceAccountRoles = append(serviceAccountRoles, v1.ServiceAccountRole(role))
 	}
 
+	roleBindings, err := parseNamespaceRoles(namespaceRoles)
+	if err != nil {
+		return err
+	}
+
 	return client.ServiceAccounts().Create(cmd.Context(), &v1.ServiceAccount{
 		Meta: v1.Meta{
-			Name: name,
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
 		},
-		Token: token,
-		Roles: serviceAccountRoles,
+		Token:        token,
+		Roles:        serviceAccountRoles,
+		RoleBindings: roleBindings,
 	})
 }
+
+// parseNamespaceRoles groups the NAMESPACE=ROLE pairs into the role bindings.
+func parseNamespaceRoles(namespaceRoles []string) ([]v1.RoleBinding, error) {
+	var roleBindings []v1.RoleBinding
+
+	for _, namespaceRole := range namespaceRoles {
+		namespace, role, ok := strings.Cut(namespaceRole, "=")
+		if !ok || namespace == "" || role == "" {
+			return nil, fmt.Errorf("%w: %q, expected NAMESPACE=ROLE", ErrInvalidNamespaceRole, namespaceRole)
+		}
+
+		idx := slices.IndexFunc(roleBindings, func(roleBinding v1.RoleBinding) bool {
+			return roleBinding.Namespace == namespace
+		})
+		if idx == -1 {
+			roleBindings = append(roleBindings, v1.RoleBinding{Namespace: namespace})
+			idx = len(roleBindings) - 1
+		}
+
+		roleBindings[idx].Roles = append(roleBindings[idx].Roles, v1.ServiceAccountRole(role))
+	}
+
+	return roleBindings, nil
+}
@@ -3,8 +3,11 @@ package create
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/cirruslabs/orchard/internal/imageconstant"
 	"github.com/cirruslabs/orchard/internal/simplename"
@@ -35,9 +38,22 @@ var resources map[string]string
 var labels map[string]string
 var randomSerial bool
 var restartPolicy string
+var maxRestarts uint64
+var priority int64
+var preemptionPolicy string
+var priorityClass string
+var workerAffinity string
+var vmAntiAffinity string
+var spreadBy string
+var tolerationsRaw []string
+var group string
 var startupScript string
 var hostDirsRaw []string
 var imagePullPolicy string
+var dryRun bool
+var ttlAfterCreation time.Duration
+var ttlAfterFinished time.Duration
+var template string
 
 func newCreateVMCommand() *cobra.Command {
 	command := &cobra.Command{
@@ -47,6 +63,20 @@ func newCreateVMCommand() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 	}
 
+	addVMFlags(command)
+
+	command.Flags().BoolVar(&dryRun, "dry-run", false,
+		"only validate the VM and show the worker it would be scheduled on, without creating it")
+	command.Flags().StringVar(&template, "template", "",
+		"create the VM from the specified VM template, only the explicitly specified "+
+			"flags override the template's fields")
+
+	return command
+}
+
+// addVMFlags registers the flags that describe the VM's specification,
+// they are shared between the commands that create VMs and VM pools.
+func addVMFlags(command *cobra.Command) {
 	command.Flags().StringVar(&image, "image", imageconstant.DefaultMacosImage, "image to use")
 	command.Flags().StringVar(&vmOSRaw, "os", string(v1.OSDarwin), fmt.Sprintf("operating system of this "+
 		"VM: %q or %q; set to \"linux\" to work around the Apple's limitation of 2 macOS VMs per host when using "+
@@ -84,8 +114,37 @@ func newCreateVMCommand() *cobra.Command {
 	command.Flags().BoolVar(&randomSerial, "random-serial", false,
 		"generate a new random serial number if this is a macOS VM (no-op for Linux VMs)")
 	command.Flags().StringVar(&restartPolicy, "restart-policy", string(v1.RestartPolicyNever),
-		fmt.Sprintf("restart policy for this VM: specify %q to never restart or %q "+
-			"to only restart when the VM fails", v1.RestartPolicyNever, v1.RestartPolicyOnFailure))
+		fmt.Sprintf("restart policy for this VM: specify %q to never restart or %q to restart when the VM fails "+
+			"or exits on its own until --max-restarts is reached, the restarts are delayed with an exponential "+
+			"backoff configured in the cluster settings", v1.RestartPolicyNever, v1.RestartPolicyOnFailure))
+	command.Flags().Uint64Var(&maxRestarts, "max-restarts", 0,
+		fmt.Sprintf("maximum number of restarts for the %q restart policy, after which the VM stays failed "+
+			"(no limit by default)", v1.RestartPolicyOnFailure))
+	command.Flags().Int64Var(&priority, "priority", 0,
+		"priority of this VM, VMs with a higher priority are scheduled first")
+	command.Flags().StringVar(&preemptionPolicy, "preemption-policy", string(v1.PreemptionPolicyNever),
+		fmt.Sprintf("preemption policy for this VM: specify %q to never preempt other VMs or %q "+
+			"to evict the lower-priority VMs when no worker has enough resources to run this VM",
+			v1.PreemptionPolicyNever, v1.PreemptionPolicyPreemptLowerPriority))
+	command.Flags().StringVar(&priorityClass, "priority-class", "",
+		"priority class defined in the cluster settings whose priority and preemption policy "+
+			"are assigned to this VM, only the administrators can specify a positive --priority "+
+			"or --preemption-policy "+string(v1.PreemptionPolicyPreemptLowerPriority)+" directly")
+	command.Flags().StringVar(&group, "group", "",
+		"VM group to add this VM to, the VMs in a group are only scheduled "+
+			"once the group's minimum number of members can be scheduled at once")
+	command.Flags().StringArrayVar(&tolerationsRaw, "toleration", []string{},
+		"tolerate the worker taint with the specified key and value (\"key=value:Effect\") or with the "+
+			"specified key and any value (\"key:Effect\"), omit the \":Effect\" to tolerate any effect, "+
+			"can be specified multiple times")
+	command.Flags().StringVar(&workerAffinity, "worker-affinity", "",
+		"only schedule this VM on the workers matching the selector (e.g. \"labels.rack in (a,b)\")")
+	command.Flags().StringVar(&vmAntiAffinity, "vm-anti-affinity", "",
+		"do not schedule this VM on the workers already running the VMs from the same namespace "+
+			"that match the selector (e.g. \"metadataLabels.shard=1\")")
+	command.Flags().StringVar(&spreadBy, "spread-by", "",
+		"spread the VMs having the same metadata labels as this VM evenly across "+
+			"the workers' values of the specified label (e.g. \"rack\")")
 	command.Flags().StringVar(&startupScript, "startup-script", "",
 		"startup script (e.g. --startup-script=\"sync\") or a path to a script file prefixed with \"@\" "+
 			"(e.g. \"--startup-script=@script.sh\")")
@@ -96,8 +155,12 @@ func newCreateVMCommand() *cobra.Command {
 		fmt.Sprintf("image pull policy for this VM, by default the image is only pulled if it doesn't "+
 			"exist in the cache (%q), specify %q to always try to pull the image",
 			v1.ImagePullPolicyIfNotPresent, v1.ImagePullPolicyAlways))
-
-	return command
+	command.Flags().DurationVar(&ttlAfterCreation, "ttl-after-creation", 0,
+		"automatically delete the VM once the specified duration has passed since its creation "+
+			"(e.g. \"--ttl-after-creation=24h\")")
+	command.Flags().DurationVar(&ttlAfterFinished, "ttl-after-finished", 0,
+		"automatically delete the VM once the specified duration has passed since it has failed "+
+			"without being restarted or was stopped (e.g. \"--ttl-after-finished=10m\")")
 }
 
 func runCreateVM(cmd *cobra.Command, args []string) error {
@@ -108,28 +171,66 @@ func runCreateVM(cmd *cobra.Command, args []string) error {
 		_, _ = fmt.Fprintf(os.Stderr, "WARNING: %v\n", err)
 	}
 
+	vm, err := newVMFromFlags(cmd, name)
+	if err != nil {
+		return err
+	}
+
+	if template != "" {
+		return runCreateVMFromTemplate(cmd, vm)
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		simulatedVM, err := client.VMs().CreateDryRun(cmd.Context(), vm)
+		if err != nil {
+			return err
+		}
+
+		printSimulatedVM(simulatedVM)
+
+		return nil
+	}
+
+	return client.VMs().Create(cmd.Context(), vm)
+}
+
+func printSimulatedVM(simulatedVM *v1.VM) {
+	if simulatedVM.Worker != "" {
+		fmt.Printf("VM %s would be scheduled on worker %s\n", simulatedVM.Name, simulatedVM.Worker)
+	} else {
+		fmt.Printf("VM %s would stay pending: %s\n", simulatedVM.Name, simulatedVM.StatusMessage)
+	}
+}
+
+// newVMFromFlags creates a VM specification from the flags registered by addVMFlags().
+func newVMFromFlags(cmd *cobra.Command, name string) (*v1.VM, error) {
 	// Convert arguments
 	var hostDirs []v1.HostDir
 
 	vmOS, err := v1.NewOSFromString(vmOSRaw)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	vmArch, err := v1.NewArchitectureFromString(vmArchRaw)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	vmRuntime, err := v1.NewRuntimeFromString(vmRuntimeRaw)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	for _, hostDirRaw := range hostDirsRaw {
 		hostDir, err := v1.NewHostDirFromString(hostDirRaw)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		hostDirs = append(hostDirs, hostDir)
@@ -137,7 +238,9 @@ func runCreateVM(cmd *cobra.Command, args []string) error {
 
 	vm := &v1.VM{
 		Meta: v1.Meta{
-			Name: name,
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
 		},
 		Image:    image,
 		CPU:      cpu,
@@ -161,28 +264,68 @@ func runCreateVM(cmd *cobra.Command, args []string) error {
 		RandomSerial: randomSerial,
 		Labels:       labels,
 		HostDirs:     hostDirs,
+		Group:        group,
 	}
 
 	if err := vm.Validate(); err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	// Convert resources
 	vm.Resources, err = v1.NewResourcesFromStringToString(resources)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	// Convert image pull policy
 	vm.ImagePullPolicy, err = v1.NewImagePullPolicyFromString(imagePullPolicy)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
 	}
 
 	// Convert restart policy
 	vm.RestartPolicy, err = v1.NewRestartPolicyFromString(restartPolicy)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrVMFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
+	}
+	vm.MaxRestarts = maxRestarts
+
+	// Convert preemption policy
+	vm.Priority = priority
+	vm.PriorityClassName = priorityClass
+	vm.PreemptionPolicy, err = v1.NewPreemptionPolicyFromString(preemptionPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
+	}
+
+	// Convert tolerations
+	for _, tolerationRaw := range tolerationsRaw {
+		toleration, err := v1.NewTolerationFromString(tolerationRaw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
+		}
+
+		vm.Tolerations = append(vm.Tolerations, toleration)
+	}
+
+	// Convert affinity
+	vm.Affinity = newAffinity()
+	if err := vm.Affinity.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVMFailed, err)
+	}
+
+	// Convert time-to-live durations
+	if cmd.Flags().Changed("ttl-after-creation") {
+		vm.TTLSecondsAfterCreation, err = ttlSeconds("ttl-after-creation", ttlAfterCreation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Flags().Changed("ttl-after-finished") {
+		vm.TTLSecondsAfterFinished, err = ttlSeconds("ttl-after-finished", ttlAfterFinished)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Convert startup script, optionally reading it from the file system
@@ -191,7 +334,7 @@ func runCreateVM(cmd *cobra.Command, args []string) error {
 	if strings.HasPrefix(startupScript, scriptFilePrefix) {
 		startupScriptBytes, err := os.ReadFile(strings.TrimPrefix(startupScript, scriptFilePrefix))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		vm.StartupScript = &v1.VMScript{
@@ -203,10 +346,56 @@ func runCreateVM(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	client, err := client.New()
-	if err != nil {
-		return err
+	return vm, nil
+}
+
+func ttlSeconds(flag string, ttl time.Duration) (*uint64, error) {
+	if ttl < 0 {
+		return nil, fmt.Errorf("%w: --%s cannot be negative", ErrVMFailed, flag)
 	}
 
-	return client.VMs().Create(cmd.Context(), vm)
+	seconds := uint64(ttl.Round(time.Second) / time.Second)
+
+	return &seconds, nil
+}
+
+func newAffinity() *v1.Affinity {
+	if workerAffinity == "" && vmAntiAffinity == "" && spreadBy == "" {
+		return nil
+	}
+
+	affinity := &v1.Affinity{}
+
+	if workerAffinity != "" {
+		affinity.WorkerAffinity = &v1.WorkerAffinity{
+			Required: workerAffinity,
+		}
+	}
+
+	if vmAntiAffinity != "" {
+		affinity.VMAntiAffinity = &v1.VMAntiAffinity{
+			Required: vmAntiAffinity,
+		}
+	}
+
+	if spreadBy != "" {
+		var selector v1.Selector
+
+		for _, key := range slices.Sorted(maps.Keys(metadataLabels)) {
+			selector = append(selector, v1.Requirement{
+				Key:      "metadataLabels." + key,
+				Operator: v1.SelectorOperatorEquals,
+				Values:   []string{metadataLabels[key]},
+			})
+		}
+
+		affinity.TopologySpread = []v1.TopologySpreadConstraint{
+			{
+				TopologyKey: spreadBy,
+				Selector:    selector.String(),
+			},
+		}
+	}
+
+	return affinity
 }
@@ -0,0 +1,42 @@
+package create
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var minMember uint64
+
+func newCreateVMGroupCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmgroup NAME",
+		Short: "Create a VM group",
+		RunE:  runCreateVMGroup,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	command.Flags().Uint64Var(&minMember, "min-member", 1,
+		"minimum number of the group's VMs that need to be scheduled at once, "+
+			"the group's VMs stay pending until that many of them fit on the workers")
+
+	return command
+}
+
+func runCreateVMGroup(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMGroups().Create(cmd.Context(), &v1.VMGroup{
+		Meta: v1.Meta{
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
+		},
+		MinMember: minMember,
+	})
+}
@@ -0,0 +1,58 @@
+package create
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var vmSetReplicas uint64
+var maxUnavailable uint64
+
+func newCreateVMSetCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmset NAME",
+		Short: "Create a set of identical VMs that are re-created when they fail or are deleted",
+		RunE:  runCreateVMSet,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	addVMFlags(command)
+
+	command.Flags().Uint64Var(&vmSetReplicas, "replicas", 1,
+		"number of VMs that the set maintains")
+	command.Flags().Uint64Var(&maxUnavailable, "max-unavailable", 1,
+		"maximum number of set's VMs that can be unavailable while rolling out the template changes")
+
+	return command
+}
+
+func runCreateVMSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// The template's name is ignored by the controller,
+	// so use the set's name for the client-side validation
+	template, err := newVMFromFlags(cmd, name)
+	if err != nil {
+		return err
+	}
+
+	// Metadata labels and annotations are attached to the set itself
+	template.Meta = v1.Meta{}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMSets().Create(cmd.Context(), &v1.VMSet{
+		Meta: v1.Meta{
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
+		},
+		Replicas:       vmSetReplicas,
+		MaxUnavailable: maxUnavailable,
+		Template:       *template,
+	})
+}
@@ -0,0 +1,179 @@
+package create
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// vmFlagFields maps the flags registered by addVMFlags() and the metadata flags
+// to the VM's JSON fields that they affect, nested fields are separated by dots.
+var vmFlagFields = map[string][]string{
+	"metadata-labels":    {"metadataLabels"},
+	"annotations":        {"annotations"},
+	"image":              {"image"},
+	"os":                 {"os"},
+	"arch":               {"arch"},
+	"runtime":            {"runtime"},
+	"cpu":                {"cpu"},
+	"memory":             {"memory"},
+	"disk-size":          {"diskSize"},
+	"net-softnet":        {"net-softnet", "netSoftnet"},
+	"net-softnet-allow":  {"netSoftnetAllow"},
+	"net-softnet-block":  {"netSoftnetBlock"},
+	"net-bridged":        {"net-bridged"},
+	"headless":           {"headless"},
+	"nested":             {"nested"},
+	"suspendable":        {"suspendable"},
+	"username":           {"username"},
+	"password":           {"password"},
+	"resources":          {"resources"},
+	"labels":             {"labels"},
+	"random-serial":      {"randomSerial"},
+	"restart-policy":     {"restart_policy"},
+	"max-restarts":       {"maxRestarts"},
+	"priority":           {"priority"},
+	"preemption-policy":  {"preemptionPolicy"},
+	"priority-class":     {"priorityClassName"},
+	"group":              {"group"},
+	"toleration":         {"tolerations"},
+	"worker-affinity":    {"affinity.workerAffinity"},
+	"vm-anti-affinity":   {"affinity.vmAntiAffinity"},
+	"spread-by":          {"affinity.topologySpread"},
+	"startup-script":     {"startup_script"},
+	"host-dirs":          {"hostDirs"},
+	"image-pull-policy":  {"imagePullPolicy"},
+	"ttl-after-creation": {"ttlSecondsAfterCreation"},
+	"ttl-after-finished": {"ttlSecondsAfterFinished"},
+}
+
+func newCreateVMTemplateCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmtemplate NAME",
+		Short: "Create a VM template that can be used with \"orchard create vm --template\"",
+		RunE:  runCreateVMTemplate,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	addVMFlags(command)
+
+	return command
+}
+
+func runCreateVMTemplate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// The specification's name is ignored by the controller,
+	// so use the template's name for the client-side validation
+	spec, err := newVMFromFlags(cmd, name)
+	if err != nil {
+		return err
+	}
+
+	// Metadata labels and annotations are attached to the template itself
+	spec.Meta = v1.Meta{}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMTemplates().Create(cmd.Context(), &v1.VMTemplate{
+		Meta: v1.Meta{
+			Name:        name,
+			Labels:      metadataLabels,
+			Annotations: annotations,
+		},
+		Spec: *spec,
+	})
+}
+
+// vmOverridesFromFlags returns the fields of the VM created from the flags that
+// were explicitly specified by the user, and thus should override the template's
+// fields. The fields whose flags were set to a zero value are returned as nil
+// to reset the template's fields.
+func vmOverridesFromFlags(cmd *cobra.Command, vm *v1.VM) (map[string]any, error) {
+	vmJSON, err := json.Marshal(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+
+	if err := json.Unmarshal(vmJSON, &fields); err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]any{}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		for _, field := range vmFlagFields[flag.Name] {
+			path := strings.Split(field, ".")
+
+			setNestedField(overrides, path, getNestedField(fields, path))
+		}
+	})
+
+	return overrides, nil
+}
+
+func getNestedField(fields map[string]any, path []string) any {
+	value, ok := fields[path[0]]
+	if !ok {
+		return nil
+	}
+
+	if len(path) == 1 {
+		return value
+	}
+
+	nestedFields, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	return getNestedField(nestedFields, path[1:])
+}
+
+func setNestedField(fields map[string]any, path []string, value any) {
+	if len(path) == 1 {
+		fields[path[0]] = value
+
+		return
+	}
+
+	nestedFields, ok := fields[path[0]].(map[string]any)
+	if !ok {
+		nestedFields = map[string]any{}
+		fields[path[0]] = nestedFields
+	}
+
+	setNestedField(nestedFields, path[1:], value)
+}
+
+func runCreateVMFromTemplate(cmd *cobra.Command, vm *v1.VM) error {
+	overrides, err := vmOverridesFromFlags(cmd, vm)
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	createdVM, err := client.VMs().CreateFromTemplate(cmd.Context(), vm.Name, template, overrides, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		printSimulatedVM(createdVM)
+	}
+
+	return nil
+}
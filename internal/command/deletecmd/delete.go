@@ -10,7 +10,9 @@ func NewCommand() *cobra.Command {
 		Short: "Delete resources from the controller",
 	}
 
-	command.AddCommand(newDeleteVMCommand(), newDeleteServiceComandCommand(), newDeleteWorkerCommand())
+	command.AddCommand(newDeleteVMCommand(), newDeleteVMGroupCommand(), newDeletePoolCommand(),
+		newDeleteVMSetCommand(), newDeleteVMTemplateCommand(), newDeleteQuotaCommand(),
+		newDeleteServiceComandCommand(), newDeleteWorkerCommand(), newDeleteWorkerTaintCommand())
 
 	return command
 }
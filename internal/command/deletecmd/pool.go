@@ -0,0 +1,26 @@
+package deletecmd
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newDeletePoolCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool NAME",
+		Short: "Delete a VM pool and its unclaimed VMs",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeletePoolCommand,
+	}
+}
+
+func runDeletePoolCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMPools().Delete(cmd.Context(), name)
+}
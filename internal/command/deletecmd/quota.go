@@ -0,0 +1,26 @@
+package deletecmd
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteQuotaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "quota NAME",
+		Short: "Delete a quota",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteQuotaCommand,
+	}
+}
+
+func runDeleteQuotaCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.Quotas().Delete(cmd.Context(), name)
+}
@@ -0,0 +1,26 @@
+package deletecmd
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteVMGroupCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vmgroup NAME",
+		Short: "Delete a VM group",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteVMGroupCommand,
+	}
+}
+
+func runDeleteVMGroupCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMGroups().Delete(cmd.Context(), name)
+}
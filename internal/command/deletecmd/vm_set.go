@@ -0,0 +1,26 @@
+package deletecmd
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteVMSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vmset NAME",
+		Short: "Delete a VM set and its VMs",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteVMSetCommand,
+	}
+}
+
+func runDeleteVMSetCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMSets().Delete(cmd.Context(), name)
+}
@@ -0,0 +1,26 @@
+package deletecmd
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteVMTemplateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vmtemplate NAME",
+		Short: "Delete a VM template, the VMs created from it are not affected",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteVMTemplateCommand,
+	}
+}
+
+func runDeleteVMTemplateCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	return client.VMTemplates().Delete(cmd.Context(), name)
+}
@@ -0,0 +1,73 @@
+package deletecmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+var ErrTaintNotFound = errors.New("taint not found")
+
+func newDeleteWorkerTaintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "worker-taint NAME KEY[:EFFECT]",
+		Short: "Remove taints with the specified key (and optionally, effect) from a worker",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runDeleteWorkerTaint,
+	}
+}
+
+func runDeleteWorkerTaint(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	key, effectRaw, hasEffect := strings.Cut(args[1], ":")
+
+	var effect v1.TaintEffect
+
+	if hasEffect {
+		var err error
+
+		effect, err = v1.NewTaintEffectFromString(effectRaw)
+		if err != nil {
+			return err
+		}
+	}
+
+	apiClient, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	worker, err := apiClient.Workers().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	var taints []v1.Taint
+
+	for _, taint := range worker.Taints {
+		if taint.Key == key && (!hasEffect || taint.Effect == effect) {
+			continue
+		}
+
+		taints = append(taints, taint)
+	}
+
+	if len(taints) == len(worker.Taints) {
+		return fmt.Errorf("%w: worker %q has no taint %q", ErrTaintNotFound, name, args[1])
+	}
+
+	worker.Taints = taints
+
+	// Use a patch to be able to remove the last taint, which is
+	// otherwise indistinguishable from not specifying the taints
+	_, err = apiClient.Workers().Patch(cmd.Context(), name, map[string]any{
+		"taints": worker.Taints,
+	}, client.WithResourceVersion(worker.Version))
+
+	return err
+}
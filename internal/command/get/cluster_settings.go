@@ -2,6 +2,7 @@ package get
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/cirruslabs/orchard/pkg/client"
@@ -45,6 +46,43 @@ func runGetClusterSettings(cmd *cobra.Command, args []string) error {
 
 	table.AddRow("Scheduler profile", clusterSettings.SchedulerProfile)
 
+	scoreWeightsDescription := clusterSettings.EffectiveSchedulerScoreWeights().String()
+	if len(clusterSettings.SchedulerScoreWeights) == 0 {
+		scoreWeightsDescription += " (scheduler profile's preset)"
+	}
+	table.AddRow("Scheduler score weights", scoreWeightsDescription)
+
+	fairShareDescription := "disabled"
+	if fairShare := clusterSettings.FairShare; fairShare != nil {
+		if fairShare.Label != "" {
+			fairShareDescription = fmt.Sprintf("queue by the %q metadata label", fairShare.Label)
+		} else {
+			fairShareDescription = "queue by the service account"
+		}
+
+		weights := lo.MapToSlice(fairShare.Weights, func(queue string, weight uint64) string {
+			return fmt.Sprintf("%s=%d", queue, weight)
+		})
+		slices.Sort(weights)
+
+		if len(weights) != 0 {
+			fairShareDescription += fmt.Sprintf(", weights: %s", strings.Join(weights, ","))
+		}
+
+		if usageHalfLife := fairShare.UsageHalfLife(); usageHalfLife != 0 {
+			fairShareDescription += fmt.Sprintf(", usage half-life: %s", usageHalfLife)
+		}
+	}
+	table.AddRow("Fair-share queueing", fairShareDescription)
+
+	restartBackoffDescription := fmt.Sprintf("%s initially, up to %s, reset after running for %s",
+		clusterSettings.RestartBackoff.InitialDelay(), clusterSettings.RestartBackoff.MaxDelay(),
+		clusterSettings.RestartBackoff.ResetAfter())
+	if clusterSettings.RestartBackoff == nil {
+		restartBackoffDescription += " (default)"
+	}
+	table.AddRow("Restart backoff", restartBackoffDescription)
+
 	fmt.Println(table)
 
 	return nil
@@ -16,8 +16,13 @@ func NewCommand() *cobra.Command {
 	command.AddCommand(
 		newGetBootstrapTokenCommand(),
 		newGetClusterSettingsCommand(),
+		newGetPoolCommand(),
+		newGetQuotaCommand(),
 		newGetServiceAccountCommand(),
 		newGetVMCommand(),
+		newGetVMGroupCommand(),
+		newGetVMSetCommand(),
+		newGetVMTemplateCommand(),
 		newGetWorkerCommand(),
 	)
 
@@ -0,0 +1,73 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cirruslabs/orchard/internal/structpath"
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newGetPoolCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pool NAME",
+		Short: "Retrieve a VM pool and it's fields",
+		RunE:  runGetPool,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return command
+}
+
+func runGetPool(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get pool ci/template/image")
+	splits := strings.Split(name, "/")
+	var path []string
+	if len(splits) > 1 {
+		name = splits[0]
+		path = splits[1:]
+	}
+
+	vmPool, err := client.VMPools().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get pool ci/template/image")
+	if len(path) != 0 {
+		result, ok := structpath.Lookup(*vmPool, path)
+		if !ok {
+			return fmt.Errorf("%w: failed to find the specified field \"%s\" or the field is not a string",
+				ErrGetFailed, strings.Join(path, "/"))
+		}
+
+		fmt.Println(result)
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", vmPool.Name)
+	table.AddRow("Namespace", nonEmptyOrNone(vmPool.Namespace))
+	table.AddRow("Image", vmPool.Template.Image)
+	table.AddRow("Replicas", vmPool.Replicas)
+	table.AddRow("Ready replicas", vmPool.ReadyReplicas)
+	table.AddRow("Status message", nonEmptyOrNone(vmPool.StatusMessage))
+	table.AddRow("Claims", fmt.Sprintf("%d hits, %d misses", vmPool.Hits, vmPool.Misses))
+	table.AddRow("Hit rate", fmt.Sprintf("%.1f%%", vmPool.HitRate()*100))
+
+	fmt.Println(table)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package get
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func newGetQuotaCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "quota NAME",
+		Short: "Retrieve a quota and its usage",
+		RunE:  runGetQuota,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return command
+}
+
+func runGetQuota(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	quota, err := client.Quotas().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", quota.Name)
+	table.AddRow("Scope", quota.Scope)
+
+	fmt.Println(table)
+	fmt.Println()
+
+	usageTable := uitable.New()
+
+	usageTable.AddRow("Resource", "Used", "Limit")
+
+	keys := lo.Keys(quota.Hard)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		usageTable.AddRow(key, quota.Used[key], quota.Hard[key])
+	}
+
+	fmt.Println(usageTable)
+
+	return nil
+}
@@ -66,6 +66,12 @@ func runGetServiceAccount(cmd *cobra.Command, args []string) error {
 	}
 	table.AddRow("roles", strings.Join(scopeList, ", "))
 
+	var roleBindingList []string
+	for _, roleBinding := range serviceAccount.RoleBindings {
+		roleBindingList = append(roleBindingList, roleBinding.String())
+	}
+	table.AddRow("namespace roles", strings.Join(roleBindingList, "; "))
+
 	fmt.Println(table)
 
 	return nil
@@ -14,6 +14,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var explain bool
+
 func newGetVMCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "vm NAME",
@@ -22,6 +24,9 @@ func newGetVMCommand() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 	}
 
+	command.Flags().BoolVar(&explain, "explain", false,
+		"explain why the VM is not scheduled yet instead of showing its fields")
+
 	return command
 }
 
@@ -41,6 +46,10 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 		path = splits[1:]
 	}
 
+	if explain {
+		return runExplainVM(cmd, client, name)
+	}
+
 	vm, err := client.VMs().Get(cmd.Context(), name)
 	if err != nil {
 		return err
@@ -63,6 +72,7 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 	table.Wrap = true
 
 	table.AddRow("Name", vm.Name)
+	table.AddRow("Namespace", vm.Namespace)
 	createdAtInfo := humanize.RelTime(vm.CreatedAt, time.Now(), "ago", "in the future")
 	table.AddRow("Created", createdAtInfo)
 	table.AddRow("Image", vm.Image)
@@ -101,6 +111,14 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 	table.AddRow("Status", vm.Status)
 	table.AddRow("Status message", vm.StatusMessage)
 	table.AddRow("Assigned worker", nonEmptyOrNone(vm.Worker))
+	table.AddRow("Created by", nonEmptyOrNone(vm.CreatedBy))
+
+	table.AddRow("Priority", vm.Priority)
+	table.AddRow("Preemption policy", nonEmptyOrNone(string(vm.PreemptionPolicy)))
+	table.AddRow("Group", nonEmptyOrNone(vm.Group))
+	table.AddRow("Pool", nonEmptyOrNone(vm.Pool))
+	table.AddRow("VM set", nonEmptyOrNone(vm.VMSet))
+	table.AddRow("Template", nonEmptyOrNone(vm.Template))
 
 	table.AddRow("Restart policy", vm.RestartPolicy)
 	restartedAtInfo := "never"
@@ -109,6 +127,30 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 	}
 	table.AddRow("Restarted", restartedAtInfo)
 	table.AddRow("Restart count", vm.RestartCount)
+	table.AddRow("Consecutive failures", vm.ConsecutiveFailures)
+	maxRestartsInfo := "unlimited"
+	if vm.MaxRestarts != 0 {
+		maxRestartsInfo = fmt.Sprintf("%d", vm.MaxRestarts)
+	}
+	table.AddRow("Max restarts", maxRestartsInfo)
+	nextRestartAtInfo := "never"
+	if !vm.NextRestartAt.IsZero() {
+		nextRestartAtInfo = humanize.RelTime(vm.NextRestartAt, time.Now(), "ago", "from now")
+	}
+	table.AddRow("Next restart", nextRestartAtInfo)
+
+	finishedAtInfo := "never"
+	if !vm.FinishedAt.IsZero() {
+		finishedAtInfo = humanize.RelTime(vm.FinishedAt, time.Now(), "ago", "in the future")
+	}
+	table.AddRow("Finished", finishedAtInfo)
+	table.AddRow("TTL after creation", ttlInfo(vm.TTLSecondsAfterCreation))
+	table.AddRow("TTL after finished", ttlInfo(vm.TTLSecondsAfterFinished))
+	expiresAtInfo := "never"
+	if expiresAt, ok := vm.ExpiresAt(); ok {
+		expiresAtInfo = humanize.RelTime(expiresAt, time.Now(), "ago", "from now")
+	}
+	table.AddRow("Expires", expiresAtInfo)
 
 	resourcesInfo := strings.Join(lo.MapToSlice(vm.Resources, func(key string, value uint64) string {
 		return fmt.Sprintf("%s: %d", key, value)
@@ -120,6 +162,21 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 	}), "\n")
 	table.AddRow("Labels", nonEmptyOrNone(labelsInfo))
 
+	tolerationsInfo := strings.Join(lo.Map(vm.Tolerations, func(toleration v1.Toleration, _ int) string {
+		return toleration.String()
+	}), "\n")
+	table.AddRow("Tolerations", nonEmptyOrNone(tolerationsInfo))
+
+	metadataLabelsInfo := strings.Join(lo.MapToSlice(vm.Meta.Labels, func(key string, value string) string {
+		return fmt.Sprintf("%s: %s", key, value)
+	}), "\n")
+	table.AddRow("Metadata labels", nonEmptyOrNone(metadataLabelsInfo))
+
+	annotationsInfo := strings.Join(lo.MapToSlice(vm.Annotations, func(key string, value string) string {
+		return fmt.Sprintf("%s: %s", key, value)
+	}), "\n")
+	table.AddRow("Annotations", nonEmptyOrNone(annotationsInfo))
+
 	table.AddRow("Random serial", vm.RandomSerial)
 
 	var hostDirsInfo string
@@ -135,3 +192,38 @@ func runGetVM(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runExplainVM(cmd *cobra.Command, client *client.Client, name string) error {
+	explanation, err := client.VMs().Scheduling(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(explanation.Message)
+
+	if len(explanation.Workers) == 0 {
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Worker", "Reason")
+
+	for _, rejection := range explanation.Workers {
+		table.AddRow(rejection.Worker, rejection.Reason)
+	}
+
+	fmt.Println()
+	fmt.Println(table)
+
+	return nil
+}
+
+func ttlInfo(ttlSeconds *uint64) string {
+	if ttlSeconds == nil {
+		return "none"
+	}
+
+	return (time.Duration(*ttlSeconds) * time.Second).String()
+}
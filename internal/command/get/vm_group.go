@@ -0,0 +1,78 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/structpath"
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/dustin/go-humanize"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newGetVMGroupCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmgroup NAME",
+		Short: "Retrieve a VM group and it's fields",
+		RunE:  runGetVMGroup,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return command
+}
+
+func runGetVMGroup(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmgroup builders/status")
+	splits := strings.Split(name, "/")
+	var path []string
+	if len(splits) > 1 {
+		name = splits[0]
+		path = splits[1:]
+	}
+
+	vmGroup, err := client.VMGroups().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmgroup builders/status")
+	if len(path) != 0 {
+		result, ok := structpath.Lookup(*vmGroup, path)
+		if !ok {
+			return fmt.Errorf("%w: failed to find the specified field \"%s\" or the field is not a string",
+				ErrGetFailed, strings.Join(path, "/"))
+		}
+
+		fmt.Println(result)
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", vmGroup.Name)
+	table.AddRow("Namespace", nonEmptyOrNone(vmGroup.Namespace))
+	table.AddRow("Minimum members", vmGroup.MinMember)
+	table.AddRow("Status", vmGroup.Status)
+	table.AddRow("Status message", nonEmptyOrNone(vmGroup.StatusMessage))
+
+	scheduledAtInfo := "never"
+	if !vmGroup.ScheduledAt.IsZero() {
+		scheduledAtInfo = humanize.RelTime(vmGroup.ScheduledAt, time.Now(), "ago", "in the future")
+	}
+	table.AddRow("Scheduled", scheduledAtInfo)
+
+	fmt.Println(table)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cirruslabs/orchard/internal/structpath"
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newGetVMSetCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmset NAME",
+		Short: "Retrieve a VM set and it's fields",
+		RunE:  runGetVMSet,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return command
+}
+
+func runGetVMSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmset agents/template/image")
+	splits := strings.Split(name, "/")
+	var path []string
+	if len(splits) > 1 {
+		name = splits[0]
+		path = splits[1:]
+	}
+
+	vmSet, err := client.VMSets().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmset agents/template/image")
+	if len(path) != 0 {
+		result, ok := structpath.Lookup(*vmSet, path)
+		if !ok {
+			return fmt.Errorf("%w: failed to find the specified field \"%s\" or the field is not a string",
+				ErrGetFailed, strings.Join(path, "/"))
+		}
+
+		fmt.Println(result)
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", vmSet.Name)
+	table.AddRow("Namespace", nonEmptyOrNone(vmSet.Namespace))
+	table.AddRow("Image", vmSet.Template.Image)
+	table.AddRow("Generation", vmSet.Generation)
+	table.AddRow("Replicas", vmSet.Replicas)
+	table.AddRow("Ready replicas", vmSet.ReadyReplicas)
+	table.AddRow("Updated replicas", vmSet.UpdatedReplicas)
+	table.AddRow("Max unavailable", vmSet.EffectiveMaxUnavailable())
+	table.AddRow("Rolled out", vmSet.RolledOut())
+	table.AddRow("Status message", nonEmptyOrNone(vmSet.StatusMessage))
+
+	fmt.Println(table)
+
+	return nil
+}
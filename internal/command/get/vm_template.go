@@ -0,0 +1,91 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/structpath"
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/dustin/go-humanize"
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+func newGetVMTemplateCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmtemplate NAME",
+		Short: "Retrieve a VM template and it's fields",
+		RunE:  runGetVMTemplate,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return command
+}
+
+func runGetVMTemplate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmtemplate macos/spec/image")
+	splits := strings.Split(name, "/")
+	var path []string
+	if len(splits) > 1 {
+		name = splits[0]
+		path = splits[1:]
+	}
+
+	vmTemplate, err := client.VMTemplates().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Ability to retrieve resource fields (e.g. "orchard get vmtemplate macos/spec/image")
+	if len(path) != 0 {
+		result, ok := structpath.Lookup(*vmTemplate, path)
+		if !ok {
+			return fmt.Errorf("%w: failed to find the specified field \"%s\" or the field is not a string",
+				ErrGetFailed, strings.Join(path, "/"))
+		}
+
+		fmt.Println(result)
+
+		return nil
+	}
+
+	spec := vmTemplate.Spec
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", vmTemplate.Name)
+	table.AddRow("Created", humanize.RelTime(vmTemplate.CreatedAt, time.Now(), "ago", "in the future"))
+	table.AddRow("Image", nonEmptyOrNone(spec.Image))
+	table.AddRow("Image pull policy", nonEmptyOrNone(string(spec.ImagePullPolicy)))
+	table.AddRow("OS", nonEmptyOrNone(string(spec.OS)))
+	table.AddRow("Architecture", nonEmptyOrNone(string(spec.Arch)))
+	table.AddRow("Runtime", nonEmptyOrNone(string(spec.Runtime)))
+	table.AddRow("CPU", spec.CPU)
+	table.AddRow("Memory", spec.Memory)
+
+	resourcesInfo := strings.Join(lo.MapToSlice(spec.Resources, func(key string, value uint64) string {
+		return fmt.Sprintf("%s: %d", key, value)
+	}), "\n")
+	table.AddRow("Resources", nonEmptyOrNone(resourcesInfo))
+
+	labelsInfo := strings.Join(lo.MapToSlice(spec.Labels, func(key string, value string) string {
+		return fmt.Sprintf("%s: %s", key, value)
+	}), "\n")
+	table.AddRow("Labels", nonEmptyOrNone(labelsInfo))
+
+	table.AddRow("Restart policy", nonEmptyOrNone(string(spec.RestartPolicy)))
+
+	fmt.Println(table)
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cirruslabs/orchard/internal/structpath"
 	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/dustin/go-humanize"
 	"github.com/gosuri/uitable"
 	"github.com/samber/lo"
@@ -84,6 +85,22 @@ func runGetWorker(cmd *cobra.Command, args []string) error {
 	}), "\n")
 	table.AddRow("Labels", nonEmptyOrNone(labelsInfo))
 
+	taintsInfo := strings.Join(lo.Map(worker.Taints, func(taint v1.Taint, _ int) string {
+		return taint.String()
+	}), "\n")
+	table.AddRow("Taints", nonEmptyOrNone(taintsInfo))
+	table.AddRow("Cached images", nonEmptyOrNone(strings.Join(worker.CachedImages, "\n")))
+
+	metadataLabelsInfo := strings.Join(lo.MapToSlice(worker.Meta.Labels, func(key string, value string) string {
+		return fmt.Sprintf("%s: %s", key, value)
+	}), "\n")
+	table.AddRow("Metadata labels", nonEmptyOrNone(metadataLabelsInfo))
+
+	annotationsInfo := strings.Join(lo.MapToSlice(worker.Annotations, func(key string, value string) string {
+		return fmt.Sprintf("%s: %s", key, value)
+	}), "\n")
+	table.AddRow("Annotations", nonEmptyOrNone(annotationsInfo))
+
 	fmt.Println(table)
 
 	return nil
@@ -1,10 +1,13 @@
 package list
 
 import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/spf13/cobra"
 )
 
 var quiet bool
+var selectorRaw string
 
 func NewCommand() *cobra.Command {
 	command := &cobra.Command{
@@ -12,9 +15,26 @@ func NewCommand() *cobra.Command {
 		Short: "List resources on the controller",
 	}
 
-	command.AddCommand(newListWorkersCommand(), newListVMsCommand(), newListServiceAccountsCommand())
+	command.AddCommand(newListWorkersCommand(), newListVMsCommand(), newListVMGroupsCommand(),
+		newListPoolsCommand(), newListVMSetsCommand(), newListVMTemplatesCommand(), newListQuotasCommand(),
+		newListServiceAccountsCommand())
 
 	command.Flags().BoolVarP(&quiet, "", "q", false, "only show resource names")
+	command.PersistentFlags().StringVarP(&selectorRaw, "selector", "l", "",
+		"only show resources matching the selector, e.g. \"status=running,metadataLabels.team in (ci,qa),!worker\"; "+
+			"supported operators are =, ==, !=, in, notin, existence (key) and non-existence (!key), "+
+			"keys are dot-separated JSON field names of the resource")
 
 	return command
 }
+
+func listOptions() ([]client.ListOption, error) {
+	selector, err := v1.ParseSelector(selectorRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	return []client.ListOption{
+		client.WithListSelector(selector),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newListPoolsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pools",
+		Short: "List VM pools",
+		RunE:  runListPools,
+	}
+
+	return command
+}
+
+func runListPools(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vmPools, err := client.VMPools().List(cmd.Context(), listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, vmPool := range vmPools {
+			fmt.Println(vmPool.Name)
+		}
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", "Image", "Ready", "Hit rate", "Status message")
+
+	for _, vmPool := range vmPools {
+		table.AddRow(vmPool.Name, vmPool.Template.Image,
+			fmt.Sprintf("%d/%d", vmPool.ReadyReplicas, vmPool.Replicas),
+			fmt.Sprintf("%.1f%%", vmPool.HitRate()*100), vmPool.StatusMessage)
+	}
+
+	fmt.Println(table)
+
+	return nil
+}
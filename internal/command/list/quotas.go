@@ -0,0 +1,57 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newListQuotasCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "quotas",
+		Short: "List quotas",
+		RunE:  runListQuotas,
+	}
+
+	return command
+}
+
+func runListQuotas(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	quotas, err := client.Quotas().List(cmd.Context(), listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, quota := range quotas {
+			fmt.Println(quota.Name)
+		}
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", "Scope")
+
+	for _, quota := range quotas {
+		table.AddRow(quota.Name, quota.Scope)
+	}
+
+	fmt.Println(table)
+
+	return nil
+}
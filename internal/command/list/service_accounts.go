@@ -20,12 +20,17 @@ func newListServiceAccountsCommand() *cobra.Command {
 }
 
 func runListServiceAccounts(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
 	client, err := client.New()
 	if err != nil {
 		return err
 	}
 
-	serviceAccounts, err := client.ServiceAccounts().List(cmd.Context())
+	serviceAccounts, err := client.ServiceAccounts().List(cmd.Context(), listOpts...)
 	if err != nil {
 		return err
 	}
@@ -41,7 +46,7 @@ func runListServiceAccounts(cmd *cobra.Command, args []string) error {
 	table := uitable.New()
 	table.Wrap = true
 
-	table.AddRow("Name", "Roles")
+	table.AddRow("Name", "Roles", "Namespace roles")
 
 	for _, serviceAccount := range serviceAccounts {
 		var scopeList []string
@@ -50,7 +55,13 @@ func runListServiceAccounts(cmd *cobra.Command, args []string) error {
 			scopeList = append(scopeList, string(scope))
 		}
 
-		table.AddRow(serviceAccount.Name, strings.Join(scopeList, ", "))
+		var roleBindingList []string
+
+		for _, roleBinding := range serviceAccount.RoleBindings {
+			roleBindingList = append(roleBindingList, roleBinding.String())
+		}
+
+		table.AddRow(serviceAccount.Name, strings.Join(scopeList, ", "), strings.Join(roleBindingList, "; "))
 	}
 
 	fmt.Println(table)
@@ -0,0 +1,57 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newListVMGroupsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmgroups",
+		Short: "List VM groups",
+		RunE:  runListVMGroups,
+	}
+
+	return command
+}
+
+func runListVMGroups(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vmGroups, err := client.VMGroups().List(cmd.Context(), listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, vmGroup := range vmGroups {
+			fmt.Println(vmGroup.Name)
+		}
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", "Min. members", "Status", "Status message")
+
+	for _, vmGroup := range vmGroups {
+		table.AddRow(vmGroup.Name, vmGroup.MinMember, vmGroup.Status, vmGroup.StatusMessage)
+	}
+
+	fmt.Println(table)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newListVMSetsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmsets",
+		Short: "List VM sets",
+		RunE:  runListVMSets,
+	}
+
+	return command
+}
+
+func runListVMSets(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vmSets, err := client.VMSets().List(cmd.Context(), listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, vmSet := range vmSets {
+			fmt.Println(vmSet.Name)
+		}
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", "Image", "Ready", "Updated", "Status message")
+
+	for _, vmSet := range vmSets {
+		table.AddRow(vmSet.Name, vmSet.Template.Image,
+			fmt.Sprintf("%d/%d", vmSet.ReadyReplicas, vmSet.Replicas),
+			fmt.Sprintf("%d/%d", vmSet.UpdatedReplicas, vmSet.Replicas), vmSet.StatusMessage)
+	}
+
+	fmt.Println(table)
+
+	return nil
+}
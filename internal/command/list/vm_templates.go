@@ -0,0 +1,57 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+func newListVMTemplatesCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmtemplates",
+		Short: "List VM templates",
+		RunE:  runListVMTemplates,
+	}
+
+	return command
+}
+
+func runListVMTemplates(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vmTemplates, err := client.VMTemplates().List(cmd.Context(), listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		for _, vmTemplate := range vmTemplates {
+			fmt.Println(vmTemplate.Name)
+		}
+
+		return nil
+	}
+
+	table := uitable.New()
+	table.Wrap = true
+
+	table.AddRow("Name", "Image", "CPU", "Memory")
+
+	for _, vmTemplate := range vmTemplates {
+		table.AddRow(vmTemplate.Name, vmTemplate.Spec.Image, vmTemplate.Spec.CPU, vmTemplate.Spec.Memory)
+	}
+
+	fmt.Println(table)
+
+	return nil
+}
@@ -2,14 +2,19 @@ package list
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cirruslabs/orchard/pkg/client"
 	"github.com/dustin/go-humanize"
 	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
+var allNamespaces bool
+
 func newListVMsCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "vms",
@@ -17,16 +22,28 @@ func newListVMsCommand() *cobra.Command {
 		RunE:  runListVMs,
 	}
 
+	command.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false,
+		"list VMs in all namespaces that the service account has access to")
+
 	return command
 }
 
 func runListVMs(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
+	if allNamespaces {
+		listOpts = append(listOpts, client.WithListAllNamespaces())
+	}
+
 	client, err := client.New()
 	if err != nil {
 		return err
 	}
 
-	vms, err := client.VMs().List(cmd.Context())
+	vms, err := client.VMs().List(cmd.Context(), listOpts...)
 	if err != nil {
 		return err
 	}
@@ -42,13 +59,21 @@ func runListVMs(cmd *cobra.Command, args []string) error {
 	table := uitable.New()
 	table.Wrap = true
 
-	table.AddRow("Name", "Created", "Image", "Status", "Restart policy", "Assigned worker")
+	table.AddRow("Name", "Namespace", "Created", "Image", "Status", "Restart policy", "Assigned worker",
+		"Metadata labels")
 
 	for _, vm := range vms {
 		restartPolicyInfo := fmt.Sprintf("%s (%d restarts)", vm.RestartPolicy, vm.RestartCount)
 		createdAtInfo := humanize.RelTime(vm.CreatedAt, time.Now(), "ago", "in the future")
 
-		table.AddRow(vm.Name, createdAtInfo, vm.Image, vm.Status, restartPolicyInfo, vm.Worker)
+		metadataLabels := lo.MapToSlice(vm.Meta.Labels, func(key string, value string) string {
+			return fmt.Sprintf("%s=%s", key, value)
+		})
+		slices.Sort(metadataLabels)
+		metadataLabelsInfo := strings.Join(metadataLabels, ",")
+
+		table.AddRow(vm.Name, vm.Namespace, createdAtInfo, vm.Image, vm.Status, restartPolicyInfo, vm.Worker,
+			metadataLabelsInfo)
 	}
 
 	fmt.Println(table)
@@ -21,12 +21,17 @@ func newListWorkersCommand() *cobra.Command {
 }
 
 func runListWorkers(cmd *cobra.Command, args []string) error {
+	listOpts, err := listOptions()
+	if err != nil {
+		return err
+	}
+
 	client, err := client.New()
 	if err != nil {
 		return err
 	}
 
-	workers, err := client.Workers().List(cmd.Context())
+	workers, err := client.Workers().List(cmd.Context(), listOpts...)
 	if err != nil {
 		return err
 	}
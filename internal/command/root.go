@@ -1,6 +1,9 @@
 package command
 
 import (
+	"os"
+
+	"github.com/cirruslabs/orchard/internal/command/claim"
 	"github.com/cirruslabs/orchard/internal/command/context"
 	"github.com/cirruslabs/orchard/internal/command/controller"
 	"github.com/cirruslabs/orchard/internal/command/create"
@@ -13,16 +16,20 @@ import (
 	"github.com/cirruslabs/orchard/internal/command/pause"
 	"github.com/cirruslabs/orchard/internal/command/portforward"
 	"github.com/cirruslabs/orchard/internal/command/resume"
+	"github.com/cirruslabs/orchard/internal/command/scale"
 	"github.com/cirruslabs/orchard/internal/command/set"
 	"github.com/cirruslabs/orchard/internal/command/ssh"
 	"github.com/cirruslabs/orchard/internal/command/vnc"
 	"github.com/cirruslabs/orchard/internal/command/worker"
+	"github.com/cirruslabs/orchard/internal/config"
 	"github.com/cirruslabs/orchard/internal/opentelemetry"
 	"github.com/cirruslabs/orchard/internal/version"
 	"github.com/spf13/cobra"
 )
 
 func NewRootCmd() *cobra.Command {
+	var namespace string
+
 	command := &cobra.Command{
 		Use:           "orchard",
 		SilenceUsage:  true,
@@ -34,15 +41,27 @@ func NewRootCmd() *cobra.Command {
 				return err
 			}
 
+			// Propagate the namespace to the API clients the same way
+			// as it would've been specified via an environment variable
+			if namespace != "" {
+				if err := os.Setenv(config.OrchardNamespace, namespace); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
 
+	command.PersistentFlags().StringVarP(&namespace, "namespace", "n", "",
+		"namespace of the VMs to operate on (overrides the namespace of the current context)")
+
 	if localNetworkHelperCommand := localnetworkhelper.NewCommand(); localNetworkHelperCommand != nil {
 		command.AddCommand(localNetworkHelperCommand)
 	}
 
 	addGroupedCommands(command, "Working With Resources:",
+		claim.NewCommand(),
 		create.NewCommand(),
 		deletepkg.NewCommand(),
 		get.NewCommand(),
@@ -51,6 +70,7 @@ func NewRootCmd() *cobra.Command {
 		pause.NewCommand(),
 		portforward.NewCommand(),
 		resume.NewCommand(),
+		scale.NewCommand(),
 		set.NewCommand(),
 		ssh.NewCommand(),
 		vnc.NewCommand(),
@@ -0,0 +1,33 @@
+package scale
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newScalePoolCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pool NAME",
+		Short: "Change the number of warm VMs that a VM pool maintains",
+		RunE:  runScalePool,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	command.Flags().Uint64Var(&replicas, "replicas", 0, "number of warm VMs that the VM pool maintains")
+	_ = command.MarkFlagRequired("replicas")
+
+	return command
+}
+
+func runScalePool(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.VMPools().Scale(cmd.Context(), name, replicas)
+
+	return err
+}
@@ -0,0 +1,14 @@
+package scale
+
+import "github.com/spf13/cobra"
+
+func NewCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "scale",
+		Short: "Scale a resource",
+	}
+
+	command.AddCommand(newScaleVMSetCommand(), newScalePoolCommand())
+
+	return command
+}
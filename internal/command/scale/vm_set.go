@@ -0,0 +1,35 @@
+package scale
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var replicas uint64
+
+func newScaleVMSetCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmset NAME",
+		Short: "Change the number of VMs that a VM set maintains",
+		RunE:  runScaleVMSet,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	command.Flags().Uint64Var(&replicas, "replicas", 0, "number of VMs that the VM set maintains")
+	_ = command.MarkFlagRequired("replicas")
+
+	return command
+}
+
+func runScaleVMSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	client, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.VMSets().Scale(cmd.Context(), name, replicas)
+
+	return err
+}
@@ -6,16 +6,35 @@ import (
 	"github.com/cirruslabs/orchard/pkg/client"
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/spf13/cobra"
+	"strconv"
+	"time"
 )
 
 var ErrClusterSettingsFailed = errors.New("failed to set cluster settings")
 
 var hostDirPoliciesRaw []string
 var schedulerProfileRaw string
+var fairShareEnabled bool
+var fairShareLabel string
+var fairShareWeightsRaw map[string]string
+var fairShareUsageHalfLife time.Duration
+var schedulerScoreWeightsRaw map[string]string
+var restartBackoffInitialDelay time.Duration
+var restartBackoffMaxDelay time.Duration
+var restartBackoffResetAfter time.Duration
 
 const (
-	hostDirPoliciesFlag  = "host-dir-policies"
-	schedulerProfileFlag = "scheduler-profile"
+	hostDirPoliciesFlag   = "host-dir-policies"
+	schedulerProfileFlag  = "scheduler-profile"
+	fairShareFlag         = "fair-share"
+	fairShareLabelFlag    = "fair-share-label"
+	fairShareWeightsFlag  = "fair-share-weights"
+	fairShareHalfLifeFlag = "fair-share-usage-half-life"
+	scoreWeightsFlag      = "scheduler-score-weights"
+
+	restartBackoffInitialDelayFlag = "restart-backoff-initial-delay"
+	restartBackoffMaxDelayFlag     = "restart-backoff-max-delay"
+	restartBackoffResetAfterFlag   = "restart-backoff-reset-after"
 )
 
 func newSetClusterSettingsCommand() *cobra.Command {
@@ -40,6 +59,40 @@ falling back to more busier workers
 
 `, schedulerProfileFlag, v1.SchedulerProfileOptimizeUtilization, schedulerProfileFlag,
 		v1.SchedulerProfileDistributeLoad))
+	cmd.Flags().BoolVar(&fairShareEnabled, fairShareFlag, false,
+		"enable or disable (--fair-share=false) the weighted fair-share queueing, which interleaves "+
+			"the pending VMs of different owners instead of scheduling them in the order of creation")
+	cmd.Flags().StringVar(&fairShareLabel, fairShareLabelFlag, "",
+		fmt.Sprintf("VM metadata label whose value determines the VM's fair-share queue, when empty, "+
+			"the VMs are queued by the service account that created them (implies --%s)", fairShareFlag))
+	cmd.Flags().StringToStringVar(&fairShareWeightsRaw, fairShareWeightsFlag, map[string]string{},
+		fmt.Sprintf("weights of the fair-share queues specified as QUEUE=WEIGHT, the queues that are not "+
+			"listed have a weight of 1 (for example, --%s=ci=3,nightly=1, implies --%s)",
+			fairShareWeightsFlag, fairShareFlag))
+	cmd.Flags().DurationVar(&fairShareUsageHalfLife, fairShareHalfLifeFlag, 0,
+		fmt.Sprintf("average the resource usage of the fair-share queues over time, with the past usage "+
+			"counting half as much after each specified period, so that the queues that have recently "+
+			"used more than their share are deprioritized for a while, specify zero to only take the "+
+			"currently scheduled VMs into account (for example, --%s=1h, implies --%s)",
+			fairShareHalfLifeFlag, fairShareFlag))
+	cmd.Flags().StringToStringVar(&schedulerScoreWeightsRaw, scoreWeightsFlag, map[string]string{},
+		fmt.Sprintf("weights of the scheduler's score plugins specified as PLUGIN=WEIGHT, which take precedence "+
+			"over the --%s, supported plugins are %s, %s and %s (for example, --%s=%s=100,%s=1), "+
+			"specify an empty value (--%s=) to go back to using the scheduler profile",
+			schedulerProfileFlag, v1.SchedulerScorePluginAffinity, v1.SchedulerScorePluginBinPacking,
+			v1.SchedulerScorePluginSpread, scoreWeightsFlag, v1.SchedulerScorePluginAffinity,
+			v1.SchedulerScorePluginSpread, scoreWeightsFlag))
+	cmd.Flags().DurationVar(&restartBackoffInitialDelay, restartBackoffInitialDelayFlag, 0,
+		fmt.Sprintf("delay before restarting a failed VM for the first time, which doubles with each "+
+			"consecutive failure of the VM, specify zero to use the default of %s (for example, --%s=30s)",
+			v1.DefaultRestartBackoffInitialDelay, restartBackoffInitialDelayFlag))
+	cmd.Flags().DurationVar(&restartBackoffMaxDelay, restartBackoffMaxDelayFlag, 0,
+		fmt.Sprintf("maximum delay before restarting a failed VM, specify zero to use the default of %s "+
+			"(for example, --%s=10m)", v1.DefaultRestartBackoffMaxDelay, restartBackoffMaxDelayFlag))
+	cmd.Flags().DurationVar(&restartBackoffResetAfter, restartBackoffResetAfterFlag, 0,
+		fmt.Sprintf("time a restarted VM needs to run for its next failure to be delayed by the initial "+
+			"delay again, specify zero to use the default of %s (for example, --%s=1h)",
+			v1.DefaultRestartBackoffResetAfter, restartBackoffResetAfterFlag))
 
 	return cmd
 }
@@ -80,6 +133,106 @@ func runSetClusterSettings(cmd *cobra.Command, args []string) error {
 		needUpdate = true
 	}
 
+	if cmd.Flag(fairShareFlag).Changed && !fairShareEnabled {
+		clusterSettings.FairShare = nil
+
+		needUpdate = true
+	} else if cmd.Flag(fairShareFlag).Changed || cmd.Flag(fairShareLabelFlag).Changed ||
+		cmd.Flag(fairShareWeightsFlag).Changed || cmd.Flag(fairShareHalfLifeFlag).Changed {
+		if clusterSettings.FairShare == nil {
+			clusterSettings.FairShare = &v1.FairShare{}
+		}
+
+		if cmd.Flag(fairShareLabelFlag).Changed {
+			clusterSettings.FairShare.Label = fairShareLabel
+		}
+
+		if cmd.Flag(fairShareWeightsFlag).Changed {
+			clusterSettings.FairShare.Weights = map[string]uint64{}
+
+			for queue, weightRaw := range fairShareWeightsRaw {
+				weight, err := strconv.ParseUint(weightRaw, 10, 64)
+				if err != nil {
+					return fmt.Errorf("%w: invalid weight of the fair-share queue %q: %v",
+						ErrClusterSettingsFailed, queue, err)
+				}
+
+				clusterSettings.FairShare.Weights[queue] = weight
+			}
+		}
+
+		if cmd.Flag(fairShareHalfLifeFlag).Changed {
+			clusterSettings.FairShare.UsageHalfLifeSeconds = uint64(fairShareUsageHalfLife.Seconds())
+		}
+
+		needUpdate = true
+	}
+
+	if cmd.Flag(scoreWeightsFlag).Changed {
+		clusterSettings.SchedulerScoreWeights = nil
+
+		for plugin, weightRaw := range schedulerScoreWeightsRaw {
+			weight, err := strconv.ParseUint(weightRaw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: invalid weight of the score plugin %q: %v",
+					ErrClusterSettingsFailed, plugin, err)
+			}
+
+			if clusterSettings.SchedulerScoreWeights == nil {
+				clusterSettings.SchedulerScoreWeights = v1.SchedulerScoreWeights{}
+			}
+
+			clusterSettings.SchedulerScoreWeights[v1.SchedulerScorePlugin(plugin)] = weight
+		}
+
+		if err := clusterSettings.SchedulerScoreWeights.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrClusterSettingsFailed, err)
+		}
+
+		needUpdate = true
+	}
+
+	if cmd.Flag(restartBackoffInitialDelayFlag).Changed || cmd.Flag(restartBackoffMaxDelayFlag).Changed ||
+		cmd.Flag(restartBackoffResetAfterFlag).Changed {
+		if clusterSettings.RestartBackoff == nil {
+			clusterSettings.RestartBackoff = &v1.RestartBackoff{}
+		}
+
+		if cmd.Flag(restartBackoffInitialDelayFlag).Changed {
+			clusterSettings.RestartBackoff.InitialDelaySeconds, err = restartBackoffSeconds(
+				restartBackoffInitialDelayFlag, restartBackoffInitialDelay)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cmd.Flag(restartBackoffMaxDelayFlag).Changed {
+			clusterSettings.RestartBackoff.MaxDelaySeconds, err = restartBackoffSeconds(
+				restartBackoffMaxDelayFlag, restartBackoffMaxDelay)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cmd.Flag(restartBackoffResetAfterFlag).Changed {
+			clusterSettings.RestartBackoff.ResetAfterSeconds, err = restartBackoffSeconds(
+				restartBackoffResetAfterFlag, restartBackoffResetAfter)
+			if err != nil {
+				return err
+			}
+		}
+
+		if *clusterSettings.RestartBackoff == (v1.RestartBackoff{}) {
+			clusterSettings.RestartBackoff = nil
+		}
+
+		if err := clusterSettings.RestartBackoff.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrClusterSettingsFailed, err)
+		}
+
+		needUpdate = true
+	}
+
 	// Check if we need to update anything in the cluster settings
 	if !needUpdate {
 		return fmt.Errorf("%w: you need to specify at least one setting to update", ErrClusterSettingsFailed)
@@ -87,3 +240,12 @@ func runSetClusterSettings(cmd *cobra.Command, args []string) error {
 
 	return client.ClusterSettings().Set(cmd.Context(), clusterSettings)
 }
+
+func restartBackoffSeconds(flag string, delay time.Duration) (uint64, error) {
+	if delay < 0 || delay%time.Second != 0 {
+		return 0, fmt.Errorf("%w: --%s should be a non-negative whole number of seconds",
+			ErrClusterSettingsFailed, flag)
+	}
+
+	return uint64(delay / time.Second), nil
+}
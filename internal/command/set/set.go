@@ -10,7 +10,7 @@ func NewCommand() *cobra.Command {
 		Short: "Set resource properties on the controller",
 	}
 
-	command.AddCommand(newSetClusterSettingsCommand())
+	command.AddCommand(newSetClusterSettingsCommand(), newSetWorkerCommand(), newSetVMSetCommand(), newSetVMTemplateCommand())
 
 	return command
 }
@@ -0,0 +1,59 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var ErrSetVMSetFailed = errors.New("failed to set VM set properties")
+
+var vmSetImage string
+var vmSetMaxUnavailable uint64
+
+func newSetVMSetCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmset NAME",
+		Short: "Set VM set properties",
+		Long: "Set VM set properties, the template changes (e.g. --image) are rolled out by gradually " +
+			"replacing the set's VMs, with at most --max-unavailable VMs being unavailable at any time",
+		Args: cobra.ExactArgs(1),
+		RunE: runSetVMSet,
+	}
+
+	command.Flags().StringVar(&vmSetImage, "image", "", "image to use")
+	command.Flags().Uint64Var(&vmSetMaxUnavailable, "max-unavailable", 1,
+		"maximum number of set's VMs that can be unavailable while rolling out the template changes")
+
+	return command
+}
+
+func runSetVMSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	patch := map[string]any{}
+
+	if cmd.Flags().Changed("image") {
+		patch["template"] = map[string]any{
+			"image": vmSetImage,
+		}
+	}
+	if cmd.Flags().Changed("max-unavailable") {
+		patch["maxUnavailable"] = vmSetMaxUnavailable
+	}
+
+	if len(patch) == 0 {
+		return fmt.Errorf("%w: you need to specify at least one property to update", ErrSetVMSetFailed)
+	}
+
+	apiClient, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.VMSets().Patch(cmd.Context(), name, patch)
+
+	return err
+}
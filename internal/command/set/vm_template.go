@@ -0,0 +1,53 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cirruslabs/orchard/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var ErrSetVMTemplateFailed = errors.New("failed to set VM template properties")
+
+var vmTemplateImage string
+
+func newSetVMTemplateCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vmtemplate NAME",
+		Short: "Set VM template properties",
+		Long: "Set VM template properties, the VMs that will be created from the template " +
+			"are affected, while the VMs that were already created from it are not",
+		Args: cobra.ExactArgs(1),
+		RunE: runSetVMTemplate,
+	}
+
+	command.Flags().StringVar(&vmTemplateImage, "image", "", "image to use")
+
+	return command
+}
+
+func runSetVMTemplate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if !cmd.Flags().Changed("image") {
+		return fmt.Errorf("%w: you need to specify at least one property to update", ErrSetVMTemplateFailed)
+	}
+
+	apiClient, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vmTemplate, err := apiClient.VMTemplates().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	vmTemplate.Spec.Image = vmTemplateImage
+
+	_, err = apiClient.VMTemplates().Update(cmd.Context(), vmTemplate,
+		client.WithResourceVersion(vmTemplate.Version))
+
+	return err
+}
@@ -0,0 +1,71 @@
+package set
+
+import (
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/spf13/cobra"
+)
+
+func newSetWorkerCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "worker",
+		Short: "Set worker properties",
+	}
+
+	command.AddCommand(newSetWorkerTaintCommand())
+
+	return command
+}
+
+func newSetWorkerTaintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "taint NAME KEY[=VALUE]:EFFECT",
+		Short: "Add a taint to a worker or update the value of an existing taint",
+		Long: "Add a taint to a worker or update the value of an existing taint with the same key and effect.\n\n" +
+			"The effect can be one of:\n\n" +
+			"* NoSchedule — only schedule the VMs that tolerate this taint on the worker\n" +
+			"* PreferNoSchedule — avoid scheduling the VMs that don't tolerate this taint on the worker\n" +
+			"* NoExecute — same as NoSchedule, but also evict the VMs that don't tolerate this taint " +
+			"and are already running on the worker",
+		Args: cobra.ExactArgs(2),
+		RunE: runSetWorkerTaint,
+	}
+}
+
+func runSetWorkerTaint(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	taint, err := v1.NewTaintFromString(args[1])
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	worker, err := apiClient.Workers().Get(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	var replaced bool
+
+	for i, existingTaint := range worker.Taints {
+		if existingTaint.Key == taint.Key && existingTaint.Effect == taint.Effect {
+			worker.Taints[i] = taint
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		worker.Taints = append(worker.Taints, taint)
+	}
+
+	_, err = apiClient.Workers().Patch(cmd.Context(), name, map[string]any{
+		"taints": worker.Taints,
+	}, client.WithResourceVersion(worker.Version))
+
+	return err
+}
@@ -11,6 +11,7 @@ type Context struct {
 	Certificate         Base64 `yaml:"certificate,omitempty"`
 	ServiceAccountName  string `yaml:"serviceAccountName,omitempty"`
 	ServiceAccountToken string `yaml:"serviceAccountToken,omitempty"`
+	Namespace           string `yaml:"namespace,omitempty"`
 }
 
 func (context *Context) TrustedCertificate() (*x509.Certificate, error) {
@@ -4,4 +4,5 @@ const (
 	OrchardURL                 = "ORCHARD_URL"
 	OrchardServiceAccountName  = "ORCHARD_SERVICE_ACCOUNT_NAME"
 	OrchardServiceAccountToken = "ORCHARD_SERVICE_ACCOUNT_TOKEN"
+	OrchardNamespace           = "ORCHARD_NAMESPACE"
 )
@@ -121,6 +121,9 @@ func (handle *Handle) DefaultContext() (Context, error) {
 	if serviceAccountToken, ok := os.LookupEnv(OrchardServiceAccountToken); ok {
 		defaultContext.ServiceAccountToken = serviceAccountToken
 	}
+	if namespace, ok := os.LookupEnv(OrchardNamespace); ok {
+		defaultContext.Namespace = namespace
+	}
 
 	return defaultContext, nil
 }
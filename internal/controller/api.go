@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/subtle"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -82,6 +83,9 @@ func (controller *Controller) initAPI() *gin.Engine {
 	v1.GET("/controller/info", func(c *gin.Context) {
 		controller.controllerInfo(c).Respond(c)
 	})
+	v1.GET("/controller/backup", func(c *gin.Context) {
+		controller.controllerBackup(c).Respond(c)
+	})
 
 	// Cluster settings
 	v1.GET("/cluster-settings", func(c *gin.Context) {
@@ -91,6 +95,97 @@ func (controller *Controller) initAPI() *gin.Engine {
 		controller.updateClusterSettings(c).Respond(c)
 	})
 
+	// VM groups
+	v1.POST("/vm-groups", func(c *gin.Context) {
+		controller.createVMGroup(c).Respond(c)
+	})
+	v1.GET("/vm-groups/:name", func(c *gin.Context) {
+		controller.getVMGroup(c).Respond(c)
+	})
+	v1.GET("/vm-groups", func(c *gin.Context) {
+		controller.listVMGroups(c).Respond(c)
+	})
+	v1.DELETE("/vm-groups/:name", func(c *gin.Context) {
+		controller.deleteVMGroup(c).Respond(c)
+	})
+
+	// VM pools
+	v1.POST("/pools", func(c *gin.Context) {
+		controller.createVMPool(c).Respond(c)
+	})
+	v1.PUT("/pools/:name", func(c *gin.Context) {
+		controller.updateVMPool(c).Respond(c)
+	})
+	v1.PATCH("/pools/:name", func(c *gin.Context) {
+		controller.patchVMPool(c).Respond(c)
+	})
+	v1.GET("/pools/:name", func(c *gin.Context) {
+		controller.getVMPool(c).Respond(c)
+	})
+	v1.GET("/pools", func(c *gin.Context) {
+		controller.listVMPools(c).Respond(c)
+	})
+	v1.DELETE("/pools/:name", func(c *gin.Context) {
+		controller.deleteVMPool(c).Respond(c)
+	})
+	v1.POST("/pools/:name/claim", func(c *gin.Context) {
+		controller.claimVMPool(c).Respond(c)
+	})
+
+	// VM sets
+	v1.POST("/vm-sets", func(c *gin.Context) {
+		controller.createVMSet(c).Respond(c)
+	})
+	v1.PUT("/vm-sets/:name", func(c *gin.Context) {
+		controller.updateVMSet(c).Respond(c)
+	})
+	v1.PATCH("/vm-sets/:name", func(c *gin.Context) {
+		controller.patchVMSet(c).Respond(c)
+	})
+	v1.GET("/vm-sets/:name", func(c *gin.Context) {
+		controller.getVMSet(c).Respond(c)
+	})
+	v1.GET("/vm-sets", func(c *gin.Context) {
+		controller.listVMSets(c).Respond(c)
+	})
+	v1.DELETE("/vm-sets/:name", func(c *gin.Context) {
+		controller.deleteVMSet(c).Respond(c)
+	})
+
+	// VM templates
+	v1.POST("/vm-templates", func(c *gin.Context) {
+		controller.createVMTemplate(c).Respond(c)
+	})
+	v1.PUT("/vm-templates/:name", func(c *gin.Context) {
+		controller.updateVMTemplate(c).Respond(c)
+	})
+	v1.GET("/vm-templates/:name", func(c *gin.Context) {
+		controller.getVMTemplate(c).Respond(c)
+	})
+	v1.GET("/vm-templates", func(c *gin.Context) {
+		controller.listVMTemplates(c).Respond(c)
+	})
+	v1.DELETE("/vm-templates/:name", func(c *gin.Context) {
+		controller.deleteVMTemplate(c).Respond(c)
+	})
+
+	// Quotas
+	v1.POST("/quotas", func(c *gin.Context) {
+		controller.createQuota(c).Respond(c)
+	})
+	v1.PUT("/quotas/:name", func(c *gin.Context) {
+		controller.updateQuota(c).Respond(c)
+	})
+	v1.GET("/quotas/:name", func(c *gin.Context) {
+		controller.getQuota(c).Respond(c)
+	})
+	v1.GET("/quotas", func(c *gin.Context) {
+		controller.listQuotas(c).Respond(c)
+	})
+	v1.DELETE("/quotas/:name", func(c *gin.Context) {
+		controller.deleteQuota(c).Respond(c)
+	})
+
 	// Service accounts
 	v1.POST("/service-accounts", func(c *gin.Context) {
 		controller.createServiceAccount(c).Respond(c)
@@ -98,6 +193,9 @@ func (controller *Controller) initAPI() *gin.Engine {
 	v1.PUT("/service-accounts/:name", func(c *gin.Context) {
 		controller.updateServiceAccount(c).Respond(c)
 	})
+	v1.PATCH("/service-accounts/:name", func(c *gin.Context) {
+		controller.patchServiceAccount(c).Respond(c)
+	})
 	v1.GET("/service-accounts/:name", func(c *gin.Context) {
 		controller.getServiceAccount(c).Respond(c)
 	})
@@ -115,6 +213,9 @@ func (controller *Controller) initAPI() *gin.Engine {
 	v1.PUT("/workers/:name", func(c *gin.Context) {
 		controller.updateWorker(c).Respond(c)
 	})
+	v1.PATCH("/workers/:name", func(c *gin.Context) {
+		controller.patchWorker(c).Respond(c)
+	})
 	v1.GET("/workers/:name", func(c *gin.Context) {
 		controller.getWorker(c).Respond(c)
 	})
@@ -160,6 +261,9 @@ func (controller *Controller) initAPI() *gin.Engine {
 			controller.updateVMSpec(c).Respond(c)
 		}
 	})
+	v1.PATCH("/vms/:name", func(c *gin.Context) {
+		controller.patchVM(c).Respond(c)
+	})
 	v1.PUT("/vms/:name/state", func(c *gin.Context) {
 		controller.updateVMState(c).Respond(c)
 	})
@@ -175,6 +279,9 @@ func (controller *Controller) initAPI() *gin.Engine {
 	v1.GET("/vms/:name/exec", func(c *gin.Context) {
 		controller.execVM(c).Respond(c)
 	})
+	v1.GET("/vms/:name/scheduling", func(c *gin.Context) {
+		controller.getVMScheduling(c).Respond(c)
+	})
 	v1.GET("/vms/:name/ip", func(c *gin.Context) {
 		controller.ip(c).Respond(c)
 	})
@@ -255,42 +362,58 @@ func (controller *Controller) authorize(
 	ctx *gin.Context,
 	requiredRoles ...v1pkg.ServiceAccountRole,
 ) responder.Responder {
-	return controller.authorizeBase(ctx, AuthorizeModeAll, requiredRoles...)
+	return controller.authorizeBase(ctx, AuthorizeModeAll, "", requiredRoles...)
 }
 
 func (controller *Controller) authorizeAny(
 	ctx *gin.Context,
 	requiredRoles ...v1pkg.ServiceAccountRole,
 ) responder.Responder {
-	return controller.authorizeBase(ctx, AuthorizeModeAny, requiredRoles...)
+	return controller.authorizeBase(ctx, AuthorizeModeAny, "", requiredRoles...)
+}
+
+// authorizeNamespace is similar to authorize() and authorizeAny(), but also
+// takes into account the roles granted by the service account's role bindings
+// in the namespace.
+//
+// Passing anyNamespace as a namespace authorizes the service accounts that have
+// the required roles in at least one namespace, which is useful to reject the
+// unprivileged requests before looking up the namespaced resource.
+func (controller *Controller) authorizeNamespace(
+	ctx *gin.Context,
+	mode AuthorizeMode,
+	namespace string,
+	requiredRoles ...v1pkg.ServiceAccountRole,
+) responder.Responder {
+	return controller.authorizeBase(ctx, mode, namespace, requiredRoles...)
 }
 
 func (controller *Controller) authorizeBase(
 	ctx *gin.Context,
 	mode AuthorizeMode,
+	namespace string,
 	requiredRoles ...v1pkg.ServiceAccountRole,
 ) responder.Responder {
 	if controller.insecureAuthDisabled {
 		return nil
 	}
 
-	serviceAccountUntyped, ok := ctx.Get(ctxServiceAccountKey)
+	serviceAccount, ok := serviceAccountFromContext(ctx)
 	if !ok {
 		return responder.Code(http.StatusUnauthorized)
 	}
-	serviceAccount := serviceAccountUntyped.(*v1pkg.ServiceAccount)
-	serviceAccountRolesSet := mapset.NewSet[v1pkg.ServiceAccountRole](serviceAccount.Roles...)
 
-	var authorized bool
+	candidateRoles := [][]v1pkg.ServiceAccountRole{serviceAccount.RolesIn(namespace)}
 
-	switch mode {
-	case AuthorizeModeAll:
-		authorized = serviceAccountRolesSet.Contains(requiredRoles...)
-	case AuthorizeModeAny:
-		authorized = serviceAccountRolesSet.ContainsAny(requiredRoles...)
+	if namespace == anyNamespace {
+		for _, roleBinding := range serviceAccount.RoleBindings {
+			candidateRoles = append(candidateRoles, serviceAccount.RolesIn(roleBinding.Namespace))
+		}
 	}
 
-	if authorized {
+	if lo.SomeBy(candidateRoles, func(roles []v1pkg.ServiceAccountRole) bool {
+		return hasRoles(mode, roles, requiredRoles)
+	}) {
 		return nil
 	}
 
@@ -303,6 +426,10 @@ func (controller *Controller) authorizeBase(
 		hint = "any of the following roles must be present"
 	}
 
+	if namespace != "" && namespace != anyNamespace {
+		hint += fmt.Sprintf(" in namespace %q", namespace)
+	}
+
 	humanizedRoles := lo.Map(requiredRoles, func(role v1pkg.ServiceAccountRole, _ int) string {
 		return string(role)
 	})
@@ -311,6 +438,28 @@ func (controller *Controller) authorizeBase(
 		NewErrorResponse("%s: %s", hint, strings.Join(humanizedRoles, ", ")))
 }
 
+func serviceAccountFromContext(ctx *gin.Context) (*v1pkg.ServiceAccount, bool) {
+	serviceAccountUntyped, ok := ctx.Get(ctxServiceAccountKey)
+	if !ok {
+		return nil, false
+	}
+
+	return serviceAccountUntyped.(*v1pkg.ServiceAccount), true
+}
+
+func hasRoles(mode AuthorizeMode, roles []v1pkg.ServiceAccountRole, requiredRoles []v1pkg.ServiceAccountRole) bool {
+	rolesSet := mapset.NewSet[v1pkg.ServiceAccountRole](roles...)
+
+	switch mode {
+	case AuthorizeModeAll:
+		return rolesSet.Contains(requiredRoles...)
+	case AuthorizeModeAny:
+		return rolesSet.ContainsAny(requiredRoles...)
+	default:
+		return false
+	}
+}
+
 func (controller *Controller) authorizeGRPC(ctx context.Context, scopes ...v1pkg.ServiceAccountRole) bool {
 	if controller.insecureAuthDisabled {
 		return true
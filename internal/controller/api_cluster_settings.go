@@ -52,6 +52,22 @@ func (controller *Controller) updateClusterSettings(ctx *gin.Context) responder.
 		}
 	}
 
+	if err := clusterSettings.FairShare.Validate(); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("%v", err))
+	}
+
+	if err := clusterSettings.SchedulerScoreWeights.Validate(); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("%v", err))
+	}
+
+	if err := clusterSettings.RestartBackoff.Validate(); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("%v", err))
+	}
+
+	if err := clusterSettings.PriorityClasses.Validate(); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("%v", err))
+	}
+
 	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
 		if err := txn.SetClusterSettings(clusterSettings); err != nil {
 			controller.logger.Errorf("failed to set cluster settings in the DB: %v", err)
@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/cirruslabs/orchard/internal/responder"
 	"github.com/cirruslabs/orchard/internal/version"
@@ -25,9 +28,51 @@ func (controller *Controller) controllerInfo(ctx *gin.Context) responder.Respond
 		capabilities = append(capabilities, v1pkg.ControllerCapabilityRPCV2)
 	}
 
+	leaderElectionStatus := controller.leaderElection.Status()
+
 	return responder.JSON(http.StatusOK, &v1pkg.ControllerInfo{
 		Version:      version.Version,
 		Commit:       version.Commit,
 		Capabilities: capabilities,
+		Identity:     leaderElectionStatus.Identity,
+		Leader:       leaderElectionStatus.Leader,
+		Term:         leaderElectionStatus.Term,
 	})
 }
+
+func (controller *Controller) controllerBackup(ctx *gin.Context) responder.Responder {
+	// Backup contains all the secrets, including
+	// service account tokens and private keys
+	if responder := controller.authorize(ctx, v1pkg.ServiceAccountRoleAdminRead,
+		v1pkg.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	ctx.Header("Content-Type", "application/x-tar")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"orchard-backup-%s.tar\"",
+		time.Now().UTC().Format("20060102T150405Z")))
+
+	if err := controller.Backup(ctx.Writer); err != nil {
+		// We can't change the status code anymore
+		// if the archive is already being streamed
+		if ctx.Writer.Written() {
+			controller.logger.Errorf("failed to stream the backup: %v", err)
+
+			return responder.Empty()
+		}
+
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+
+		if errors.Is(err, ErrBackupUnsupported) {
+			return responder.JSON(http.StatusNotImplemented, NewErrorResponse("%v", err))
+		}
+
+		controller.logger.Errorf("failed to back up the controller: %v", err)
+
+		return responder.JSON(http.StatusInternalServerError,
+			NewErrorResponse("failed to back up the controller: %v", err))
+	}
+
+	return responder.Empty()
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// listPage retrieves a page of resources matching the selector.
+//
+// Since the selector is applied after retrieving the resources from the store,
+// listPage keeps retrieving the pages until either options.Limit matching
+// resources are found or there are no more resources left.
+func listPage[T any](
+	options storepkg.ListOptions,
+	selector v1.Selector,
+	listFunc func(options storepkg.ListOptions) (storepkg.Page[T], error),
+) (storepkg.Page[T], error) {
+	// Declare an empty, non-nil slice to
+	// return [] when no objects are found
+	result := storepkg.Page[T]{
+		Items: []T{},
+	}
+
+	limit := options.Limit
+
+	for {
+		if limit > 0 {
+			options.Limit = limit - len(result.Items)
+		}
+
+		page, err := listFunc(options)
+		if err != nil {
+			return result, err
+		}
+
+		// Use index-based loop to avoid per-iteration copies
+		for i := range page.Items {
+			if selector.Matches(&page.Items[i]) {
+				result.Items = append(result.Items, page.Items[i])
+			}
+		}
+
+		if len(page.NextCursor) == 0 {
+			return result, nil
+		}
+
+		if len(result.Items) >= limit {
+			result.NextCursor = page.NextCursor
+
+			return result, nil
+		}
+
+		options.Cursor = page.NextCursor
+	}
+}
+
+func parseListOptions(ctx *gin.Context) (storepkg.ListOptions, responder.Responder) {
+	var options storepkg.ListOptions
+
+	limitRaw := ctx.Query("limit")
+	orderRaw := ctx.Query("order")
+	cursorRaw := ctx.Query("cursor")
+
+	if limitRaw != "" {
+		limit, ok := parsePositiveInt(limitRaw)
+		if !ok {
+			return options, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid limit %q: expected positive integer", limitRaw))
+		}
+		options.Limit = limit
+	}
+
+	if orderRaw != "" {
+		order, err := client.ParseLogsOrder(orderRaw)
+		if err != nil {
+			return options, responder.JSON(http.StatusBadRequest, NewErrorResponse("%s", err))
+		}
+		options.Order = storepkg.ListOrder(order)
+	}
+
+	if cursorRaw != "" {
+		cursor, err := decodeCursor(cursorRaw)
+		if err != nil {
+			return options, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid cursor %q", cursorRaw))
+		}
+		options.Cursor = cursor
+	}
+
+	return options, nil
+}
+
+func parsePositiveInt(raw string) (int, bool) {
+	value, err := strconv.ParseInt(raw, 10, 0)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+func encodeCursor(cursor []byte) string {
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+func decodeCursor(cursorRaw string) ([]byte, error) {
+	cursor, err := base64.RawURLEncoding.DecodeString(cursorRaw)
+	if err == nil {
+		return cursor, nil
+	}
+
+	return base64.URLEncoding.DecodeString(cursorRaw)
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/cirruslabs/orchard/internal/responder"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+func validateMetadata(meta *v1.Meta) responder.Responder {
+	if err := meta.ValidateMetadata(); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+	}
+
+	return nil
+}
+
+// updateMetadata replaces the metadata labels and annotations in dbMeta with the ones from
+// userMeta and returns true if anything was changed. The nil labels and annotations are
+// left intact to avoid wiping them on updates from the clients that are not aware of them.
+func updateMetadata(dbMeta *v1.Meta, userMeta *v1.Meta) bool {
+	var changed bool
+
+	if userMeta.Labels != nil && !maps.Equal(dbMeta.Labels, userMeta.Labels) {
+		dbMeta.Labels = userMeta.Labels
+		changed = true
+	}
+
+	if userMeta.Annotations != nil && !maps.Equal(dbMeta.Annotations, userMeta.Annotations) {
+		dbMeta.Annotations = userMeta.Annotations
+		changed = true
+	}
+
+	return changed
+}
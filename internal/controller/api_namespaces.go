@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"net/http"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// anyNamespace is a special namespace value for authorizeNamespace() that
+// can never clash with the real namespace names due to the name validation.
+const anyNamespace = "*"
+
+func validateNamespace(namespace string) responder.Responder {
+	if namespace == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("namespace is empty"))
+	}
+
+	if err := simplename.Validate(namespace); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("namespace %v", err))
+	}
+
+	return nil
+}
+
+// validateClusterScoped ensures that no namespace was specified
+// for a resource that is not namespaced, such as a worker.
+func validateClusterScoped(meta *v1.Meta, kind string) responder.Responder {
+	if meta.Namespace != "" {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("%s is a cluster-scoped resource and cannot have a namespace", kind))
+	}
+
+	return nil
+}
+
+// resolveNamespace determines the namespace of a namespaced resource that is being created
+// by taking into account the namespace specified in the resource itself and the "namespace"
+// query parameter, falling back to the default namespace when none are specified.
+func resolveNamespace(ctx *gin.Context, meta *v1.Meta) responder.Responder {
+	if queryNamespace := ctx.Query("namespace"); queryNamespace != "" {
+		if meta.Namespace != "" && meta.Namespace != queryNamespace {
+			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("namespace %q "+
+				"specified in the resource does not match the namespace %q specified in the request",
+				meta.Namespace, queryNamespace))
+		}
+
+		meta.Namespace = queryNamespace
+	}
+
+	if meta.Namespace == "" {
+		meta.Namespace = v1.DefaultNamespace
+	}
+
+	return validateNamespace(meta.Namespace)
+}
+
+// requestNamespace returns the namespace in which the namespaced resource referenced
+// by the request's ":name" path parameter resides, which is the namespace specified
+// in the "namespace" query parameter or the default namespace when none is specified.
+func requestNamespace(ctx *gin.Context) string {
+	if queryNamespace := ctx.Query("namespace"); queryNamespace != "" {
+		return queryNamespace
+	}
+
+	return v1.DefaultNamespace
+}
+
+// authorizeNamespaced authorizes the access to an already retrieved namespaced resource.
+//
+// The resource is reported as not found to the service accounts that have no roles
+// in the resource's namespace, so that they can't find out which names are taken in
+// the namespaces of other tenants.
+func (controller *Controller) authorizeNamespaced(
+	ctx *gin.Context,
+	meta *v1.Meta,
+	mode AuthorizeMode,
+	requiredRoles ...v1.ServiceAccountRole,
+) responder.Responder {
+	if controller.authorizeNamespace(ctx, AuthorizeModeAny, meta.Namespace,
+		v1.ServiceAccountRoleComputeRead, v1.ServiceAccountRoleComputeWrite,
+		v1.ServiceAccountRoleComputeConnect) != nil {
+		return responder.Error(storepkg.ErrNotFound)
+	}
+
+	return controller.authorizeNamespace(ctx, mode, meta.Namespace, requiredRoles...)
+}
+
+// namespaceSelector returns a selector that only matches the namespaced resources
+// in the namespace specified in the "namespace" query parameter (if any) and in
+// the namespaces in which the service account has all of the required roles.
+func (controller *Controller) namespaceSelector(
+	ctx *gin.Context,
+	requiredRoles ...v1.ServiceAccountRole,
+) (v1.Selector, responder.Responder) {
+	var selector v1.Selector
+
+	if queryNamespace := ctx.Query("namespace"); queryNamespace != "" {
+		if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, queryNamespace,
+			requiredRoles...); responder != nil {
+			return nil, responder
+		}
+
+		selector = append(selector, v1.Requirement{
+			Key:      "namespace",
+			Operator: v1.SelectorOperatorEquals,
+			Values:   []string{queryNamespace},
+		})
+	}
+
+	if controller.insecureAuthDisabled {
+		return selector, nil
+	}
+
+	serviceAccount, ok := serviceAccountFromContext(ctx)
+	if !ok {
+		return nil, responder.Code(http.StatusUnauthorized)
+	}
+
+	if hasRoles(AuthorizeModeAll, serviceAccount.Roles, requiredRoles) {
+		return selector, nil
+	}
+
+	var namespaces []string
+
+	for _, roleBinding := range serviceAccount.RoleBindings {
+		if hasRoles(AuthorizeModeAll, serviceAccount.RolesIn(roleBinding.Namespace), requiredRoles) {
+			namespaces = append(namespaces, roleBinding.Namespace)
+		}
+	}
+
+	return append(selector, v1.Requirement{
+		Key:      "namespace",
+		Operator: v1.SelectorOperatorIn,
+		Values:   namespaces,
+	}), nil
+}
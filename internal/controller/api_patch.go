@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/mergepatch"
+	"github.com/cirruslabs/orchard/internal/responder"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// precondition is an optional requirement for the resource's
+// state that needs to be satisfied for the update to succeed.
+type precondition struct {
+	// resourceVersion is the expected resource's version
+	// (Meta.Version), zero means that any version is accepted
+	resourceVersion uint64
+
+	// generation is the expected VM's specification
+	// generation, nil means that any generation is accepted
+	generation *uint64
+}
+
+// parsePrecondition parses the expected resource version from the "If-Match" header
+// or the "resourceVersion" query parameter, and the expected VM's specification
+// generation from the "generation" query parameter.
+func parsePrecondition(ctx *gin.Context) (precondition, responder.Responder) {
+	var result precondition
+
+	if ifMatchRaw := ctx.GetHeader("If-Match"); ifMatchRaw != "" && ifMatchRaw != "*" {
+		// Accept both the plain and the entity tag-like versions, e.g. 5, "5" and W/"5"
+		versionRaw := strings.Trim(strings.TrimPrefix(strings.TrimSpace(ifMatchRaw), "W/"), "\"")
+
+		version, err := strconv.ParseUint(versionRaw, 10, 64)
+		if err != nil {
+			return result, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid If-Match header %q: expected a resource version", ifMatchRaw))
+		}
+
+		result.resourceVersion = version
+	}
+
+	if resourceVersionRaw := ctx.Query("resourceVersion"); resourceVersionRaw != "" {
+		version, err := strconv.ParseUint(resourceVersionRaw, 10, 64)
+		if err != nil {
+			return result, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid resourceVersion %q: expected an unsigned integer", resourceVersionRaw))
+		}
+
+		if result.resourceVersion != 0 && result.resourceVersion != version {
+			return result, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("If-Match header and resourceVersion specify different resource versions"))
+		}
+
+		result.resourceVersion = version
+	}
+
+	if generationRaw := ctx.Query("generation"); generationRaw != "" {
+		generation, err := strconv.ParseUint(generationRaw, 10, 64)
+		if err != nil {
+			return result, responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid generation %q: expected an unsigned integer", generationRaw))
+		}
+
+		result.generation = &generation
+	}
+
+	return result, nil
+}
+
+// check ensures that the resource with the given version and generation satisfies the
+// precondition, generation should be nil for the resources that are not versioned by it.
+func (precondition precondition) check(kind string, version uint64, generation *uint64) responder.Responder {
+	if precondition.generation != nil && generation == nil {
+		return responder.JSON(http.StatusBadRequest,
+			NewErrorResponse("generation precondition is not supported for %s", kind))
+	}
+
+	if precondition.resourceVersion != 0 && precondition.resourceVersion != version {
+		return responder.JSON(http.StatusConflict, NewErrorResponse("%s was modified concurrently: "+
+			"expected resource version %d, got %d", kind, precondition.resourceVersion, version))
+	}
+
+	if precondition.generation != nil && *precondition.generation != *generation {
+		return responder.JSON(http.StatusConflict, NewErrorResponse("%s specification was modified "+
+			"concurrently: expected generation %d, got %d", kind, *precondition.generation, *generation))
+	}
+
+	return nil
+}
+
+// readMergePatch reads the JSON Merge Patch (RFC 7386) from the request's body.
+func readMergePatch(ctx *gin.Context) ([]byte, responder.Responder) {
+	if contentTypeRaw := ctx.GetHeader("Content-Type"); contentTypeRaw != "" {
+		contentType, _, err := mime.ParseMediaType(contentTypeRaw)
+		if err != nil || (contentType != mergePatchContentType && contentType != "application/json") {
+			return nil, responder.JSON(http.StatusUnsupportedMediaType,
+				NewErrorResponse("unsupported content type %q, only JSON Merge Patch (%s) is supported",
+					contentTypeRaw, mergePatchContentType))
+		}
+	}
+
+	patch, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, responder.JSON(http.StatusBadRequest, NewErrorResponse("failed to read the patch"))
+	}
+
+	var patchObject map[string]json.RawMessage
+
+	if err := json.Unmarshal(patch, &patchObject); err != nil || patchObject == nil {
+		return nil, responder.JSON(http.StatusBadRequest,
+			NewErrorResponse("invalid JSON Merge Patch was provided: expected a JSON object"))
+	}
+
+	return patch, nil
+}
+
+// applyMergePatch applies the JSON Merge Patch to the resource
+// and returns the patched copy of the resource.
+func applyMergePatch[T any](resource *T, patch []byte) (*T, responder.Responder) {
+	resourceJSON, err := json.Marshal(resource)
+	if err != nil {
+		return nil, responder.Error(err)
+	}
+
+	patchedJSON, err := mergepatch.Apply(resourceJSON, patch)
+	if err != nil {
+		return nil, responder.JSON(http.StatusBadRequest, NewErrorResponse("%v", err))
+	}
+
+	var patched T
+
+	if err := json.Unmarshal(patchedJSON, &patched); err != nil {
+		return nil, responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("patched resource is invalid: %v", err))
+	}
+
+	return &patched, nil
+}
+
+// normalizePatchedMetadata ensures that the metadata labels and annotations
+// removed by a patch are not mistaken for the omitted ones by updateMetadata().
+func normalizePatchedMetadata(meta *v1.Meta) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+}
+
+// respondWithUpdated retrieves the resource again once the update has been committed
+// to respond with its new version, which is not known until the commit.
+func respondWithUpdated[T any](
+	controller *Controller,
+	result responder.Responder,
+	updated bool,
+	get func(txn storepkg.Transaction) (*T, error),
+) responder.Responder {
+	if !updated {
+		return result
+	}
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		resource, err := get(txn)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.JSON(http.StatusOK, resource)
+	})
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+func (controller *Controller) createQuota(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	var quota v1.Quota
+
+	if err := ctx.ShouldBindJSON(&quota); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	// Validate quota name
+	if quota.Name == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("quota name is empty"))
+	} else if err := simplename.Validate(quota.Name); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("quota %v", err))
+	}
+
+	if err := quota.Validate(); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+	}
+
+	if responder := validateMetadata(&quota.Meta); responder != nil {
+		return responder
+	}
+	if responder := validateClusterScoped(&quota.Meta, "quota"); responder != nil {
+		return responder
+	}
+
+	quota.Used = nil
+	quota.CreatedAt = time.Now()
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		// Does the quota resource with this name already exists?
+		_, err := txn.GetQuota(quota.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the quota exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("quota with this name already exists"))
+		}
+
+		if err := txn.SetQuota(quota); err != nil {
+			controller.logger.Errorf("failed to create the quota in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &quota)
+	})
+}
+
+func (controller *Controller) updateQuota(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	var userQuota v1.Quota
+
+	if err := ctx.ShouldBindJSON(&userQuota); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if err := userQuota.Validate(); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+	}
+
+	if responder := validateMetadata(&userQuota.Meta); responder != nil {
+		return responder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbQuota, err := txn.GetQuota(name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := precondition.check("quota", dbQuota.Version, nil); responder != nil {
+			return responder
+		}
+
+		dbQuota.Scope = userQuota.Scope
+		dbQuota.Hard = userQuota.Hard
+		updateMetadata(&dbQuota.Meta, &userQuota.Meta)
+
+		if err := txn.SetQuota(*dbQuota); err != nil {
+			controller.logger.Errorf("failed to update quota in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		updated = true
+
+		return responder.JSON(http.StatusOK, dbQuota)
+	})
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.Quota, error) {
+		return txn.GetQuota(name)
+	})
+}
+
+func (controller *Controller) getQuota(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleAdminRead, v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		quota, err := txn.GetQuota(name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if !controller.quotaVisible(ctx, *quota) {
+			return controller.authorize(ctx, v1.ServiceAccountRoleAdminRead)
+		}
+
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		quota.Used = quota.Usage(vms)
+
+		return responder.JSON(http.StatusOK, quota)
+	})
+}
+
+func (controller *Controller) listQuotas(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleAdminRead, v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		quotas, err := txn.ListQuotas()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		quotas = lo.Filter(quotas, func(quota v1.Quota, _ int) bool {
+			return controller.quotaVisible(ctx, quota) && selector.Matches(&quota)
+		})
+
+		for i := range quotas {
+			quotas[i].Used = quotas[i].Usage(vms)
+		}
+
+		return responder.JSON(http.StatusOK, &quotas)
+	})
+}
+
+func (controller *Controller) deleteQuota(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		if err := txn.DeleteQuota(name); err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.Code(http.StatusOK)
+	})
+}
+
+// quotaVisible returns true if the quota can be retrieved by the service account.
+//
+// Besides the service accounts with the admin:read role, the quotas are also
+// visible to the service accounts that can read the VMs in the quota's namespace
+// and to the service account that the quota applies to.
+func (controller *Controller) quotaVisible(ctx *gin.Context, quota v1.Quota) bool {
+	if controller.authorize(ctx, v1.ServiceAccountRoleAdminRead) == nil {
+		return true
+	}
+
+	if quota.Scope.ServiceAccount != "" {
+		serviceAccount, ok := serviceAccountFromContext(ctx)
+
+		return ok && serviceAccount.Name == quota.Scope.ServiceAccount
+	}
+
+	return controller.authorizeNamespace(ctx, AuthorizeModeAll, quota.Scope.Namespace,
+		v1.ServiceAccountRoleComputeRead) == nil
+}
+
+// admitQuotasTxn ensures that creating the VM won't exceed any of the quotas that apply to it.
+func (controller *Controller) admitQuotasTxn(txn storepkg.Transaction, vm v1.VM) responder.Responder {
+	quotas, err := txn.ListQuotas()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	quotas = lo.Filter(quotas, func(quota v1.Quota, _ int) bool {
+		return quota.AppliesTo(vm)
+	})
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	vms, err := txn.ListVMs()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	for _, quota := range quotas {
+		if err := quota.Admit(quota.Usage(vms), vm.QuotaUsage()); err != nil {
+			return responder.JSON(http.StatusForbidden, NewErrorResponse("%v", err))
+		}
+	}
+
+	return nil
+}
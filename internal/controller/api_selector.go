@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cirruslabs/orchard/internal/responder"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// parseListSelector combines the "selector" query parameter and
+// the legacy "filter" query parameter into a single selector.
+func parseListSelector(ctx *gin.Context) (v1.Selector, responder.Responder) {
+	var selector v1.Selector
+
+	if filterRaw := ctx.Query("filter"); filterRaw != "" {
+		for _, filterRaw := range strings.Split(filterRaw, ",") {
+			filter, err := v1.NewFilter(filterRaw)
+			if err != nil {
+				return nil, responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+			}
+
+			selector = append(selector, filter.Selector()...)
+		}
+	}
+
+	if selectorRaw := ctx.Query("selector"); selectorRaw != "" {
+		parsedSelector, err := v1.ParseSelector(selectorRaw)
+		if err != nil {
+			return nil, responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+		}
+
+		selector = append(selector, parsedSelector...)
+	}
+
+	return selector, nil
+}
@@ -11,6 +11,7 @@ import (
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 func (controller *Controller) createServiceAccount(ctx *gin.Context) responder.Responder {
@@ -33,13 +34,15 @@ func (controller *Controller) createServiceAccount(ctx *gin.Context) responder.R
 			NewErrorResponse("service account %v", err))
 	}
 
-	// Validate roles
-	for _, role := range serviceAccount.Roles {
-		_, err := v1.NewServiceAccountRole(string(role))
-		if err != nil {
-			return responder.JSON(http.StatusPreconditionFailed,
-				NewErrorResponse("unsupported role \"%s\"", role))
-		}
+	if responder := validateRoles(&serviceAccount); responder != nil {
+		return responder
+	}
+
+	if responder := validateMetadata(&serviceAccount.Meta); responder != nil {
+		return responder
+	}
+	if responder := validateClusterScoped(&serviceAccount.Meta, "service account"); responder != nil {
+		return responder
 	}
 
 	if serviceAccount.Token == "" {
@@ -91,36 +94,154 @@ func (controller *Controller) updateServiceAccount(ctx *gin.Context) responder.R
 			NewErrorResponse("service account %v", err))
 	}
 
-	// Validate roles
-	for _, role := range userServiceAccount.Roles {
-		_, err := v1.NewServiceAccountRole(string(role))
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbServiceAccount, err := txn.GetServiceAccount(userServiceAccount.Name)
 		if err != nil {
-			return responder.JSON(http.StatusPreconditionFailed,
-				NewErrorResponse("unsupported role \"%s\"", role))
+			return responder.Error(err)
 		}
+
+		if responder := precondition.check("service account", dbServiceAccount.Version, nil); responder != nil {
+			return responder
+		}
+
+		return controller.updateServiceAccountTxn(txn, dbServiceAccount, &userServiceAccount, &updated)
+	})
+
+	return respondWithUpdated(controller, result, updated,
+		func(txn storepkg.Transaction) (*v1.ServiceAccount, error) {
+			return txn.GetServiceAccount(userServiceAccount.Name)
+		})
+}
+
+func (controller *Controller) patchServiceAccount(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
 	}
 
-	if userServiceAccount.Token == "" {
-		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("service account token is empty"))
+	patch, parseResponder := readMergePatch(ctx)
+	if parseResponder != nil {
+		return parseResponder
 	}
 
-	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		dbServiceAccount, err := txn.GetServiceAccount(userServiceAccount.Name)
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbServiceAccount, err := txn.GetServiceAccount(name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
-		dbServiceAccount.Token = userServiceAccount.Token
-		dbServiceAccount.Roles = userServiceAccount.Roles
+		if responder := precondition.check("service account", dbServiceAccount.Version, nil); responder != nil {
+			return responder
+		}
 
-		if err := txn.SetServiceAccount(dbServiceAccount); err != nil {
-			controller.logger.Errorf("failed to update service account in the DB: %v", err)
+		userServiceAccount, patchResponder := applyMergePatch(dbServiceAccount, patch)
+		if patchResponder != nil {
+			return patchResponder
+		}
 
-			return responder.Code(http.StatusInternalServerError)
+		if userServiceAccount.Name != dbServiceAccount.Name {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("\"name\" field cannot be modified"))
 		}
 
-		return responder.JSON(http.StatusOK, &dbServiceAccount)
+		normalizePatchedMetadata(&userServiceAccount.Meta)
+
+		return controller.updateServiceAccountTxn(txn, dbServiceAccount, userServiceAccount, &updated)
 	})
+
+	return respondWithUpdated(controller, result, updated,
+		func(txn storepkg.Transaction) (*v1.ServiceAccount, error) {
+			return txn.GetServiceAccount(name)
+		})
+}
+
+// updateServiceAccountTxn validates and applies the user-modifiable fields
+// of the service account to the service account stored in the DB.
+func (controller *Controller) updateServiceAccountTxn(
+	txn storepkg.Transaction,
+	dbServiceAccount *v1.ServiceAccount,
+	userServiceAccount *v1.ServiceAccount,
+	updated *bool,
+) responder.Responder {
+	if responder := validateRoles(userServiceAccount); responder != nil {
+		return responder
+	}
+
+	if userServiceAccount.Token == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("service account token is empty"))
+	}
+
+	if responder := validateMetadata(&userServiceAccount.Meta); responder != nil {
+		return responder
+	}
+
+	dbServiceAccount.Token = userServiceAccount.Token
+	dbServiceAccount.Roles = userServiceAccount.Roles
+	dbServiceAccount.RoleBindings = userServiceAccount.RoleBindings
+	updateMetadata(&dbServiceAccount.Meta, &userServiceAccount.Meta)
+
+	if err := txn.SetServiceAccount(dbServiceAccount); err != nil {
+		controller.logger.Errorf("failed to update service account in the DB: %v", err)
+
+		return responder.Code(http.StatusInternalServerError)
+	}
+
+	*updated = true
+
+	return responder.JSON(http.StatusOK, &dbServiceAccount)
+}
+
+// validateRoles ensures that the service account's roles are supported and
+// that its role bindings only grant the namespaced roles in valid namespaces.
+func validateRoles(serviceAccount *v1.ServiceAccount) responder.Responder {
+	for _, role := range serviceAccount.Roles {
+		_, err := v1.NewServiceAccountRole(string(role))
+		if err != nil {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("unsupported role \"%s\"", role))
+		}
+	}
+
+	for _, roleBinding := range serviceAccount.RoleBindings {
+		if responder := validateNamespace(roleBinding.Namespace); responder != nil {
+			return responder
+		}
+
+		for _, role := range roleBinding.Roles {
+			_, err := v1.NewServiceAccountRole(string(role))
+			if err != nil {
+				return responder.JSON(http.StatusPreconditionFailed,
+					NewErrorResponse("unsupported role \"%s\"", role))
+			}
+
+			if !role.Namespaced() {
+				return responder.JSON(http.StatusPreconditionFailed,
+					NewErrorResponse("role \"%s\" can only be granted cluster-wide, "+
+						"not in namespace %q", role, roleBinding.Namespace))
+			}
+		}
+	}
+
+	return nil
 }
 
 func (controller *Controller) getServiceAccount(ctx *gin.Context) responder.Responder {
@@ -145,12 +266,28 @@ func (controller *Controller) listServiceAccounts(ctx *gin.Context) responder.Re
 		return responder
 	}
 
+	if ctx.Query("watch") == "true" {
+		return watchCollection(controller, ctx, "service accounts", nil, func(resourceVersion uint64) (
+			chan storepkg.WatchMessage[v1.ServiceAccount], chan error, error) {
+			return controller.store.WatchServiceAccounts(ctx, resourceVersion)
+		})
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
 	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
 		serviceAccounts, err := txn.ListServiceAccounts()
 		if err != nil {
 			return responder.Error(err)
 		}
 
+		serviceAccounts = lo.Filter(serviceAccounts, func(serviceAccount v1.ServiceAccount, _ int) bool {
+			return selector.Matches(&serviceAccount)
+		})
+
 		return responder.JSON(http.StatusOK, &serviceAccounts)
 	})
 }
@@ -3,12 +3,16 @@ package controller
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/controller/store/badger"
 	"github.com/cirruslabs/orchard/internal/responder"
 	v1pkg "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestAuthorizeInsecureAuthDisabled(t *testing.T) {
@@ -44,3 +48,152 @@ func TestAuthorizeAuthenticatedHasRoles(t *testing.T) {
 
 	require.Nil(t, controller.authorize(ctx, requiredRole))
 }
+
+func TestAuthorizeNamespace(t *testing.T) {
+	ctx := &gin.Context{}
+	ctx.Set(ctxServiceAccountKey, &v1pkg.ServiceAccount{
+		Roles: []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeRead},
+		RoleBindings: []v1pkg.RoleBinding{
+			{
+				Namespace: "team-a",
+				Roles:     []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeWrite},
+			},
+		},
+	})
+	controller := Controller{}
+
+	// Cluster-wide roles apply to all namespaces
+	require.Nil(t, controller.authorizeNamespace(ctx, AuthorizeModeAll, "team-b",
+		v1pkg.ServiceAccountRoleComputeRead))
+
+	// Role bindings only apply to their namespace
+	require.Nil(t, controller.authorizeNamespace(ctx, AuthorizeModeAll, "team-a",
+		v1pkg.ServiceAccountRoleComputeRead, v1pkg.ServiceAccountRoleComputeWrite))
+	require.Equal(t, responder.JSON(http.StatusUnauthorized, NewErrorResponse("all of the following roles "+
+		"must be present in namespace \"team-b\": %s", v1pkg.ServiceAccountRoleComputeWrite)),
+		controller.authorizeNamespace(ctx, AuthorizeModeAll, "team-b", v1pkg.ServiceAccountRoleComputeWrite))
+	require.Equal(t, responder.JSON(http.StatusUnauthorized, NewErrorResponse("all of the following roles "+
+		"must be present: %s", v1pkg.ServiceAccountRoleComputeWrite)),
+		controller.authorize(ctx, v1pkg.ServiceAccountRoleComputeWrite))
+
+	// Roles in any of the namespaces are sufficient for anyNamespace
+	require.Nil(t, controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1pkg.ServiceAccountRoleComputeWrite))
+	require.NotNil(t, controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1pkg.ServiceAccountRoleComputeConnect))
+}
+
+func TestNamespaceSelector(t *testing.T) {
+	ctx := &gin.Context{Request: httptest.NewRequest(http.MethodGet, "/v1/vms", nil)}
+	ctx.Set(ctxServiceAccountKey, &v1pkg.ServiceAccount{
+		RoleBindings: []v1pkg.RoleBinding{
+			{
+				Namespace: "team-a",
+				Roles:     []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeRead},
+			},
+			{
+				Namespace: "team-b",
+				Roles:     []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeConnect},
+			},
+		},
+	})
+	controller := Controller{}
+
+	selector, authorizeResponder := controller.namespaceSelector(ctx, v1pkg.ServiceAccountRoleComputeRead)
+	require.Nil(t, authorizeResponder)
+	require.Equal(t, "namespace in (team-a)", selector.String())
+
+	require.True(t, selector.Matches(&v1pkg.VM{Meta: v1pkg.Meta{Namespace: "team-a"}}))
+	require.False(t, selector.Matches(&v1pkg.VM{Meta: v1pkg.Meta{Namespace: "team-b"}}))
+}
+
+func TestAuthorizeNamespaced(t *testing.T) {
+	ctx := &gin.Context{}
+	ctx.Set(ctxServiceAccountKey, &v1pkg.ServiceAccount{
+		RoleBindings: []v1pkg.RoleBinding{
+			{
+				Namespace: "team-a",
+				Roles:     []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeRead},
+			},
+		},
+	})
+	controller := Controller{}
+
+	require.Nil(t, controller.authorizeNamespaced(ctx, &v1pkg.Meta{Namespace: "team-a"}, AuthorizeModeAll,
+		v1pkg.ServiceAccountRoleComputeRead))
+
+	// Missing roles are reported for the resources in the readable namespaces
+	require.Equal(t, responder.JSON(http.StatusUnauthorized, NewErrorResponse("all of the following roles "+
+		"must be present in namespace \"team-a\": %s", v1pkg.ServiceAccountRoleComputeWrite)),
+		controller.authorizeNamespaced(ctx, &v1pkg.Meta{Namespace: "team-a"}, AuthorizeModeAll,
+			v1pkg.ServiceAccountRoleComputeWrite))
+
+	// Resources in the other namespaces are not disclosed
+	require.Equal(t, responder.Error(storepkg.ErrNotFound),
+		controller.authorizeNamespaced(ctx, &v1pkg.Meta{Namespace: "team-b"}, AuthorizeModeAll,
+			v1pkg.ServiceAccountRoleComputeRead))
+}
+
+func TestResolvePriority(t *testing.T) {
+	store, err := badger.NewBadgerStore(t.TempDir(), false, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+		return txn.SetClusterSettings(v1pkg.ClusterSettings{
+			PriorityClasses: v1pkg.PriorityClasses{
+				{Name: "batch", Value: 10},
+				{Name: "urgent", Value: 100, PreemptionPolicy: v1pkg.PreemptionPolicyPreemptLowerPriority},
+			},
+		})
+	}))
+
+	ctx := &gin.Context{}
+	ctx.Set(ctxServiceAccountKey, &v1pkg.ServiceAccount{
+		Roles: []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleComputeWrite},
+	})
+	controller := Controller{store: store}
+
+	resolvePriority := func(vm *v1pkg.VM) responder.Responder {
+		var result responder.Responder
+
+		require.NoError(t, store.View(func(txn storepkg.Transaction) error {
+			result = controller.resolvePriorityTxn(ctx, txn, vm, nil)
+
+			return nil
+		}))
+
+		return result
+	}
+
+	// Non-administrators can only lower the priority
+	require.Nil(t, resolvePriority(&v1pkg.VM{Priority: -1}))
+	require.NotNil(t, resolvePriority(&v1pkg.VM{Priority: 1}))
+	require.NotNil(t, resolvePriority(&v1pkg.VM{
+		PreemptionPolicy: v1pkg.PreemptionPolicyPreemptLowerPriority,
+	}))
+
+	// ...or use the priority classes
+	vm := &v1pkg.VM{PriorityClassName: "urgent", PreemptionPolicy: v1pkg.PreemptionPolicyNever}
+	require.Nil(t, resolvePriority(vm))
+	require.EqualValues(t, 100, vm.Priority)
+	require.Equal(t, v1pkg.PreemptionPolicyPreemptLowerPriority, vm.PreemptionPolicy)
+
+	require.NotNil(t, resolvePriority(&v1pkg.VM{PriorityClassName: "batch", Priority: 100}))
+	require.NotNil(t, resolvePriority(&v1pkg.VM{
+		PriorityClassName: "batch",
+		PreemptionPolicy:  v1pkg.PreemptionPolicyPreemptLowerPriority,
+	}))
+	require.NotNil(t, resolvePriority(&v1pkg.VM{PriorityClassName: "missing"}))
+
+	// Unchanged priority settings are kept as is
+	require.Nil(t, controller.resolvePriorityTxn(ctx, nil, &v1pkg.VM{Priority: 1}, &v1pkg.VM{Priority: 1}))
+
+	// Administrators can set any priority
+	ctx.Set(ctxServiceAccountKey, &v1pkg.ServiceAccount{
+		Roles: []v1pkg.ServiceAccountRole{v1pkg.ServiceAccountRoleAdminWrite},
+	})
+	require.Nil(t, resolvePriority(&v1pkg.VM{
+		Priority:         1000,
+		PreemptionPolicy: v1pkg.PreemptionPolicyPreemptLowerPriority,
+	}))
+}
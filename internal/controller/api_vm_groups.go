@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+func (controller *Controller) createVMGroup(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	var vmGroup v1.VMGroup
+
+	if err := ctx.ShouldBindJSON(&vmGroup); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if responder := resolveNamespace(ctx, &vmGroup.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, vmGroup.Namespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	if vmGroup.Name == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM group name is empty"))
+	} else if err := simplename.Validate(vmGroup.Name); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("VM group name %v", err))
+	}
+	if vmGroup.MinMember == 0 {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("VM group's \"minMember\" should be at least 1"))
+	}
+	if responder := validateMetadata(&vmGroup.Meta); responder != nil {
+		return responder
+	}
+
+	// Provide defaults
+	vmGroup.Status = v1.VMGroupStatusPending
+	vmGroup.StatusMessage = ""
+	vmGroup.ScheduledAt = time.Time{}
+	vmGroup.CreatedAt = time.Now()
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		_, err := txn.GetVMGroup(vmGroup.Namespace, vmGroup.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the VM group exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM group with this name already exists"))
+		}
+
+		if err := txn.SetVMGroup(vmGroup); err != nil {
+			controller.logger.Errorf("failed to create VM group in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &vmGroup)
+	})
+}
+
+func (controller *Controller) getVMGroup(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmGroup, err := txn.GetVMGroup(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmGroup.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
+		return responder.JSON(http.StatusOK, vmGroup)
+	})
+}
+
+func (controller *Controller) listVMGroups(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	namespaceSelector, authorizeResponder := controller.namespaceSelector(ctx, v1.ServiceAccountRoleComputeRead)
+	if authorizeResponder != nil {
+		return authorizeResponder
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	selector = append(selector, namespaceSelector...)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmGroups, err := txn.ListVMGroups()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		vmGroups = lo.Filter(vmGroups, func(vmGroup v1.VMGroup, _ int) bool {
+			return selector.Matches(&vmGroup)
+		})
+
+		return responder.JSON(http.StatusOK, &vmGroups)
+	})
+}
+
+func (controller *Controller) deleteVMGroup(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		vmGroup, err := txn.GetVMGroup(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmGroup.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		// Refuse to delete the groups that still have VMs,
+		// as these VMs would never be scheduled otherwise
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		numMembers := lo.CountBy(vms, func(vm v1.VM) bool {
+			return vm.Namespace == vmGroup.Namespace && vm.Group == vmGroup.Name
+		})
+		if numMembers != 0 {
+			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM group still has %d VM(s), "+
+				"delete them first", numMembers))
+		}
+
+		if err := txn.DeleteVMGroup(namespace, name); err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.Code(http.StatusOK)
+	})
+}
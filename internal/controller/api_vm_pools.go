@@ -0,0 +1,484 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+)
+
+func (controller *Controller) createVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	var vmPool v1.VMPool
+
+	if err := ctx.ShouldBindJSON(&vmPool); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if responder := resolveNamespace(ctx, &vmPool.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, vmPool.Namespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	if vmPool.Name == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM pool name is empty"))
+	} else if err := simplename.Validate(vmPool.Name); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("VM pool name %v", err))
+	}
+	if responder := validateMetadata(&vmPool.Meta); responder != nil {
+		return responder
+	}
+
+	if responder := controller.validateVMPoolTemplate(&vmPool.Template); responder != nil {
+		return responder
+	}
+
+	// Provide defaults
+	vmPool.ReadyReplicas = 0
+	vmPool.StatusMessage = ""
+	vmPool.Hits = 0
+	vmPool.Misses = 0
+	vmPool.CreatedAt = time.Now()
+	vmPool.Template.CreatedBy = vmSetTemplateCreatedBy(ctx)
+
+	response := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		_, err := txn.GetVMPool(vmPool.Namespace, vmPool.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the VM pool exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM pool with this name already exists"))
+		}
+
+		if responder := controller.resolvePriorityTxn(ctx, txn, &vmPool.Template, nil); responder != nil {
+			return responder
+		}
+
+		if err := txn.SetVMPool(vmPool); err != nil {
+			controller.logger.Errorf("failed to create VM pool in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &vmPool)
+	})
+
+	// Request immediate scheduling to fill the pool
+	controller.scheduler.RequestScheduling()
+
+	return response
+}
+
+func (controller *Controller) updateVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	var userVMPool v1.VMPool
+
+	if err := ctx.ShouldBindJSON(&userVMPool); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVMPool, err := txn.GetVMPool(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &dbVMPool.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		if responder := precondition.check("VM pool", dbVMPool.Version, nil); responder != nil {
+			return responder
+		}
+
+		return controller.updateVMPoolTxn(ctx, txn, dbVMPool, &userVMPool, &updated)
+	})
+
+	if updated {
+		// Request immediate scheduling to scale the pool
+		controller.scheduler.RequestScheduling()
+	}
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VMPool, error) {
+		return txn.GetVMPool(namespace, name)
+	})
+}
+
+func (controller *Controller) patchVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	patch, parseResponder := readMergePatch(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVMPool, err := txn.GetVMPool(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &dbVMPool.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		if responder := precondition.check("VM pool", dbVMPool.Version, nil); responder != nil {
+			return responder
+		}
+
+		userVMPool, patchResponder := applyMergePatch(dbVMPool, patch)
+		if patchResponder != nil {
+			return patchResponder
+		}
+
+		if userVMPool.Name != dbVMPool.Name {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("\"name\" field cannot be modified"))
+		}
+
+		normalizePatchedMetadata(&userVMPool.Meta)
+
+		return controller.updateVMPoolTxn(ctx, txn, dbVMPool, userVMPool, &updated)
+	})
+
+	if updated {
+		// Request immediate scheduling to scale the pool
+		controller.scheduler.RequestScheduling()
+	}
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VMPool, error) {
+		return txn.GetVMPool(namespace, name)
+	})
+}
+
+// updateVMPoolTxn applies the user-modifiable fields of the VM pool to the VM pool
+// stored in the DB. Template changes only affect the VMs created afterward, the
+// pool's existing VMs are retained.
+func (controller *Controller) updateVMPoolTxn(
+	ctx *gin.Context,
+	txn storepkg.Transaction,
+	dbVMPool *v1.VMPool,
+	userVMPool *v1.VMPool,
+	updated *bool,
+) responder.Responder {
+	if userVMPool.Namespace != "" && userVMPool.Namespace != dbVMPool.Namespace {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("\"namespace\" field cannot be modified"))
+	}
+	if responder := validateMetadata(&userVMPool.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVMPoolTemplate(&userVMPool.Template); responder != nil {
+		return responder
+	}
+	if responder := controller.resolvePriorityTxn(ctx, txn, &userVMPool.Template,
+		&dbVMPool.Template); responder != nil {
+		return responder
+	}
+
+	// Only track the service account that changed the template,
+	// and not the one that merely scaled the pool
+	userVMPool.Template.CreatedBy = dbVMPool.Template.CreatedBy
+
+	if !cmp.Equal(dbVMPool.Template, userVMPool.Template, cmpopts.EquateEmpty()) {
+		dbVMPool.Template = userVMPool.Template
+		dbVMPool.Template.CreatedBy = vmSetTemplateCreatedBy(ctx)
+	}
+	dbVMPool.Replicas = userVMPool.Replicas
+	updateMetadata(&dbVMPool.Meta, &userVMPool.Meta)
+
+	if err := txn.SetVMPool(*dbVMPool); err != nil {
+		controller.logger.Errorf("failed to update VM pool in the DB: %v", err)
+
+		return responder.Code(http.StatusInternalServerError)
+	}
+
+	*updated = true
+
+	return responder.JSON(http.StatusOK, dbVMPool)
+}
+
+func (controller *Controller) getVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmPool, err := txn.GetVMPool(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmPool.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
+		return responder.JSON(http.StatusOK, vmPool)
+	})
+}
+
+func (controller *Controller) listVMPools(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	namespaceSelector, authorizeResponder := controller.namespaceSelector(ctx, v1.ServiceAccountRoleComputeRead)
+	if authorizeResponder != nil {
+		return authorizeResponder
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	selector = append(selector, namespaceSelector...)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmPools, err := txn.ListVMPools()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		vmPools = lo.Filter(vmPools, func(vmPool v1.VMPool, _ int) bool {
+			return selector.Matches(&vmPool)
+		})
+
+		return responder.JSON(http.StatusOK, &vmPools)
+	})
+}
+
+func (controller *Controller) deleteVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		vmPool, err := txn.GetVMPool(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmPool.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		// Delete the pool's VMs that weren't claimed,
+		// the claimed VMs no longer belong to the pool
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		for _, vm := range vms {
+			if vm.Namespace != vmPool.Namespace || vm.Pool != vmPool.Name {
+				continue
+			}
+
+			if err := txn.DeleteVM(vm.Namespace, vm.Name); err != nil {
+				return responder.Error(err)
+			}
+			if err := txn.DeleteEvents("vms", vm.UID); err != nil {
+				return responder.Error(err)
+			}
+
+			lifecycle.Report(&vm, "VM deleted", controller.logger)
+		}
+
+		if err := txn.DeleteVMPool(namespace, name); err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.Code(http.StatusOK)
+	})
+}
+
+func (controller *Controller) claimVMPool(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	// The claim is optional
+	var claim v1.VMPoolClaim
+
+	if err := ctx.ShouldBindJSON(&claim); err != nil && !errors.Is(err, io.EOF) {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if responder := validateMetadata(&v1.Meta{
+		Labels:      claim.Labels,
+		Annotations: claim.Annotations,
+	}); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var claimed bool
+
+	response := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		// Reset the state in case the transaction is retried
+		claimed = false
+
+		vmPool, err := txn.GetVMPool(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmPool.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		// Only the running VMs can be claimed, starting with the oldest ones
+		readyVMs := lo.Filter(vms, func(vm v1.VM, _ int) bool {
+			return vm.Namespace == vmPool.Namespace && vm.Pool == vmPool.Name &&
+				vm.Status == v1.VMStatusRunning && !vm.PowerState.TerminalState()
+		})
+
+		if len(readyVMs) == 0 {
+			vmPool.Misses++
+
+			if err := txn.SetVMPool(*vmPool); err != nil {
+				return responder.Error(err)
+			}
+
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM pool %q has no ready VMs "+
+				"to claim, try again later", vmPool.Name))
+		}
+
+		vm := slices.MinFunc(readyVMs, func(a, b v1.VM) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		// Detach the VM from the pool and re-label it
+		vm.Pool = ""
+		if len(claim.Labels) != 0 {
+			vm.Meta.Labels = lo.Assign(vm.Meta.Labels, claim.Labels)
+		}
+		if len(claim.Annotations) != 0 {
+			vm.Annotations = lo.Assign(vm.Annotations, claim.Annotations)
+		}
+
+		if err := txn.SetVM(vm); err != nil {
+			return responder.Error(err)
+		}
+
+		vmPool.Hits++
+		vmPool.ReadyReplicas = uint64(len(readyVMs) - 1)
+
+		if err := txn.SetVMPool(*vmPool); err != nil {
+			return responder.Error(err)
+		}
+
+		claimed = true
+
+		lifecycle.Report(&vm, "VM claimed", controller.logger)
+
+		return responder.JSON(http.StatusOK, &vm)
+	})
+
+	if claimed {
+		// Request immediate scheduling to replenish the pool
+		controller.scheduler.RequestScheduling()
+	}
+
+	return response
+}
+
+// validateVMPoolTemplate validates the VM pool's template and
+// clears the template's fields that are ignored by the Controller.
+func (controller *Controller) validateVMPoolTemplate(template *v1.VM) responder.Responder {
+	if template.Image == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM pool's template image is empty"))
+	}
+	if template.Group != "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM pool's template "+
+			"cannot specify a VM group"))
+	}
+	if responder := validateMetadata(&template.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVM(template); responder != nil {
+		return responder
+	}
+
+	template.Meta = v1.Meta{
+		Labels:      template.Meta.Labels,
+		Annotations: template.Annotations,
+	}
+
+	return nil
+}
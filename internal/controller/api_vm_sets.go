@@ -0,0 +1,389 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+)
+
+func (controller *Controller) createVMSet(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	var vmSet v1.VMSet
+
+	if err := ctx.ShouldBindJSON(&vmSet); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if responder := resolveNamespace(ctx, &vmSet.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, vmSet.Namespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	if vmSet.Name == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM set name is empty"))
+	} else if err := simplename.Validate(vmSet.Name); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("VM set name %v", err))
+	}
+	if responder := validateMetadata(&vmSet.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVMSetTemplate(&vmSet.Template); responder != nil {
+		return responder
+	}
+
+	// Provide defaults
+	vmSet.Generation = 0
+	vmSet.ReadyReplicas = 0
+	vmSet.UpdatedReplicas = 0
+	vmSet.StatusMessage = ""
+	vmSet.CreatedAt = time.Now()
+	vmSet.Template.CreatedBy = vmSetTemplateCreatedBy(ctx)
+
+	response := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		_, err := txn.GetVMSet(vmSet.Namespace, vmSet.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the VM set exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM set with this name already exists"))
+		}
+
+		if responder := controller.resolvePriorityTxn(ctx, txn, &vmSet.Template, nil); responder != nil {
+			return responder
+		}
+
+		if err := txn.SetVMSet(vmSet); err != nil {
+			controller.logger.Errorf("failed to create VM set in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &vmSet)
+	})
+
+	// Request immediate scheduling to create the set's VMs
+	controller.scheduler.RequestScheduling()
+
+	return response
+}
+
+func (controller *Controller) updateVMSet(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	var userVMSet v1.VMSet
+
+	if err := ctx.ShouldBindJSON(&userVMSet); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVMSet, err := txn.GetVMSet(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &dbVMSet.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		if responder := precondition.check("VM set", dbVMSet.Version, nil); responder != nil {
+			return responder
+		}
+
+		return controller.updateVMSetTxn(ctx, txn, dbVMSet, &userVMSet, &updated)
+	})
+
+	if updated {
+		// Request immediate scheduling to scale the set or roll out its template
+		controller.scheduler.RequestScheduling()
+	}
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VMSet, error) {
+		return txn.GetVMSet(namespace, name)
+	})
+}
+
+func (controller *Controller) patchVMSet(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	patch, parseResponder := readMergePatch(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVMSet, err := txn.GetVMSet(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &dbVMSet.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		if responder := precondition.check("VM set", dbVMSet.Version, nil); responder != nil {
+			return responder
+		}
+
+		userVMSet, patchResponder := applyMergePatch(dbVMSet, patch)
+		if patchResponder != nil {
+			return patchResponder
+		}
+
+		if userVMSet.Name != dbVMSet.Name {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("\"name\" field cannot be modified"))
+		}
+
+		normalizePatchedMetadata(&userVMSet.Meta)
+
+		return controller.updateVMSetTxn(ctx, txn, dbVMSet, userVMSet, &updated)
+	})
+
+	if updated {
+		// Request immediate scheduling to scale the set or roll out its template
+		controller.scheduler.RequestScheduling()
+	}
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VMSet, error) {
+		return txn.GetVMSet(namespace, name)
+	})
+}
+
+// updateVMSetTxn applies the user-modifiable fields of the VM set to the VM set stored
+// in the DB, incrementing its generation when the template changes to roll it out.
+func (controller *Controller) updateVMSetTxn(
+	ctx *gin.Context,
+	txn storepkg.Transaction,
+	dbVMSet *v1.VMSet,
+	userVMSet *v1.VMSet,
+	updated *bool,
+) responder.Responder {
+	if userVMSet.Namespace != "" && userVMSet.Namespace != dbVMSet.Namespace {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("\"namespace\" field cannot be modified"))
+	}
+	if responder := validateMetadata(&userVMSet.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVMSetTemplate(&userVMSet.Template); responder != nil {
+		return responder
+	}
+	if responder := controller.resolvePriorityTxn(ctx, txn, &userVMSet.Template,
+		&dbVMSet.Template); responder != nil {
+		return responder
+	}
+
+	// Only the template changes are rolled out, and not
+	// the changes of the service account that updates it
+	userVMSet.Template.CreatedBy = dbVMSet.Template.CreatedBy
+
+	if !cmp.Equal(dbVMSet.Template, userVMSet.Template, cmpopts.EquateEmpty()) {
+		dbVMSet.Template = userVMSet.Template
+		dbVMSet.Template.CreatedBy = vmSetTemplateCreatedBy(ctx)
+		dbVMSet.Generation++
+	}
+	dbVMSet.Replicas = userVMSet.Replicas
+	dbVMSet.MaxUnavailable = userVMSet.MaxUnavailable
+	updateMetadata(&dbVMSet.Meta, &userVMSet.Meta)
+
+	if err := txn.SetVMSet(*dbVMSet); err != nil {
+		controller.logger.Errorf("failed to update VM set in the DB: %v", err)
+
+		return responder.Code(http.StatusInternalServerError)
+	}
+
+	*updated = true
+
+	return responder.JSON(http.StatusOK, dbVMSet)
+}
+
+func (controller *Controller) getVMSet(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmSet, err := txn.GetVMSet(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmSet.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
+		return responder.JSON(http.StatusOK, vmSet)
+	})
+}
+
+func (controller *Controller) listVMSets(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	namespaceSelector, authorizeResponder := controller.namespaceSelector(ctx, v1.ServiceAccountRoleComputeRead)
+	if authorizeResponder != nil {
+		return authorizeResponder
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	selector = append(selector, namespaceSelector...)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmSets, err := txn.ListVMSets()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		vmSets = lo.Filter(vmSets, func(vmSet v1.VMSet, _ int) bool {
+			return selector.Matches(&vmSet)
+		})
+
+		return responder.JSON(http.StatusOK, &vmSets)
+	})
+}
+
+func (controller *Controller) deleteVMSet(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		vmSet, err := txn.GetVMSet(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vmSet.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
+		// Delete the set's VMs along with the set
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		for _, vm := range vms {
+			if vm.Namespace != vmSet.Namespace || vm.VMSet != vmSet.Name {
+				continue
+			}
+
+			if err := txn.DeleteVM(vm.Namespace, vm.Name); err != nil {
+				return responder.Error(err)
+			}
+			if err := txn.DeleteEvents("vms", vm.UID); err != nil {
+				return responder.Error(err)
+			}
+
+			lifecycle.Report(&vm, "VM deleted", controller.logger)
+		}
+
+		if err := txn.DeleteVMSet(namespace, name); err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.Code(http.StatusOK)
+	})
+}
+
+// validateVMSetTemplate validates the VM set's template and
+// clears the template's fields that are ignored by the Controller.
+func (controller *Controller) validateVMSetTemplate(template *v1.VM) responder.Responder {
+	if template.Image == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM set's template image is empty"))
+	}
+	if template.Group != "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM set's template "+
+			"cannot specify a VM group"))
+	}
+	if responder := validateMetadata(&template.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVM(template); responder != nil {
+		return responder
+	}
+
+	template.Meta = v1.Meta{
+		Labels:      template.Meta.Labels,
+		Annotations: template.Annotations,
+	}
+
+	return nil
+}
+
+func vmSetTemplateCreatedBy(ctx *gin.Context) string {
+	if serviceAccount, ok := serviceAccountFromContext(ctx); ok {
+		return serviceAccount.Name
+	}
+
+	return ""
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	"github.com/cirruslabs/orchard/internal/simplename"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+func (controller *Controller) createVMTemplate(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	var vmTemplate v1.VMTemplate
+
+	if err := ctx.ShouldBindJSON(&vmTemplate); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	// Validate VM template name
+	if vmTemplate.Name == "" {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM template name is empty"))
+	} else if err := simplename.Validate(vmTemplate.Name); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM template %v", err))
+	}
+
+	if responder := validateMetadata(&vmTemplate.Meta); responder != nil {
+		return responder
+	}
+	if responder := validateClusterScoped(&vmTemplate.Meta, "VM template"); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVMTemplateSpec(&vmTemplate.Spec); responder != nil {
+		return responder
+	}
+
+	vmTemplate.CreatedAt = time.Now()
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		// Does the VM template resource with this name already exists?
+		_, err := txn.GetVMTemplate(vmTemplate.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the VM template exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM template with this name already exists"))
+		}
+
+		if err := txn.SetVMTemplate(vmTemplate); err != nil {
+			controller.logger.Errorf("failed to create the VM template in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &vmTemplate)
+	})
+}
+
+func (controller *Controller) updateVMTemplate(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	var userVMTemplate v1.VMTemplate
+
+	if err := ctx.ShouldBindJSON(&userVMTemplate); err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
+	}
+
+	if responder := validateMetadata(&userVMTemplate.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.validateVMTemplateSpec(&userVMTemplate.Spec); responder != nil {
+		return responder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVMTemplate, err := txn.GetVMTemplate(name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := precondition.check("VM template", dbVMTemplate.Version, nil); responder != nil {
+			return responder
+		}
+
+		dbVMTemplate.Spec = userVMTemplate.Spec
+		updateMetadata(&dbVMTemplate.Meta, &userVMTemplate.Meta)
+
+		if err := txn.SetVMTemplate(*dbVMTemplate); err != nil {
+			controller.logger.Errorf("failed to update VM template in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		updated = true
+
+		return responder.JSON(http.StatusOK, dbVMTemplate)
+	})
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VMTemplate, error) {
+		return txn.GetVMTemplate(name)
+	})
+}
+
+func (controller *Controller) getVMTemplate(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleAdminRead, v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmTemplate, err := txn.GetVMTemplate(name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.JSON(http.StatusOK, vmTemplate)
+	})
+}
+
+func (controller *Controller) listVMTemplates(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleAdminRead, v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vmTemplates, err := txn.ListVMTemplates()
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		vmTemplates = lo.Filter(vmTemplates, func(vmTemplate v1.VMTemplate, _ int) bool {
+			return selector.Matches(&vmTemplate)
+		})
+
+		return responder.JSON(http.StatusOK, &vmTemplates)
+	})
+}
+
+func (controller *Controller) deleteVMTemplate(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+
+	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		if err := txn.DeleteVMTemplate(name); err != nil {
+			return responder.Error(err)
+		}
+
+		return responder.Code(http.StatusOK)
+	})
+}
+
+// validateVMTemplateSpec validates the template's specification without providing
+// the defaults, which are only provided once the VM is created from the template
+// to avoid mistaking them for the fields specified in the template.
+func (controller *Controller) validateVMTemplateSpec(spec *v1.VM) responder.Responder {
+	if spec.Template != "" {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("VM template cannot refer to another VM template"))
+	}
+
+	// Only the specification is retained
+	spec.Meta = v1.Meta{
+		Labels:      spec.Meta.Labels,
+		Annotations: spec.Annotations,
+	}
+	if responder := validateMetadata(&spec.Meta); responder != nil {
+		return responder
+	}
+
+	specCopy := *spec
+
+	return controller.validateVM(&specCopy)
+}
+
+// instantiateVMTemplate creates a VM from the template, overriding
+// the template's fields with the ones specified in the request body.
+func (controller *Controller) instantiateVMTemplate(name string, body []byte) (*v1.VM, responder.Responder) {
+	var vmTemplate *v1.VMTemplate
+
+	if responder := controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		var err error
+
+		vmTemplate, err = txn.GetVMTemplate(name)
+		if err != nil {
+			if errors.Is(err, storepkg.ErrNotFound) {
+				return responder.JSON(http.StatusPreconditionFailed,
+					NewErrorResponse("VM template %q does not exist", name))
+			}
+
+			return responder.Error(err)
+		}
+
+		return nil
+	}); responder != nil {
+		return nil, responder
+	}
+
+	return applyMergePatch(&vmTemplate.Spec, body)
+}
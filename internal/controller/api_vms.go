@@ -1,20 +1,19 @@
 package controller
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
 	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/internal/responder"
 	"github.com/cirruslabs/orchard/internal/simplename"
 	"github.com/cirruslabs/orchard/internal/worker/ondiskname"
-	"github.com/cirruslabs/orchard/pkg/client"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-cmp/cmp"
@@ -23,16 +22,44 @@ import (
 )
 
 func (controller *Controller) createVM(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
 		return responder
 	}
 
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return responder.JSON(http.StatusBadRequest, NewErrorResponse("failed to read the VM"))
+	}
+
 	var vm v1.VM
 
-	if err := ctx.ShouldBindJSON(&vm); err != nil {
+	if err := json.Unmarshal(body, &vm); err != nil {
 		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
 	}
 
+	// Instantiate the VM from the template, if any, with the
+	// fields specified in the request overriding the template's
+	if vm.Template != "" {
+		templatedVM, templateResponder := controller.instantiateVMTemplate(vm.Template, body)
+		if templateResponder != nil {
+			return templateResponder
+		}
+
+		vm = *templatedVM
+	}
+
+	// Validate the VM and simulate its placement without persisting anything
+	dryRun := ctx.Query("dryRun") == "true"
+
+	if responder := resolveNamespace(ctx, &vm.Meta); responder != nil {
+		return responder
+	}
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, vm.Namespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
 	if vm.Name == "" {
 		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM name is empty"))
 	} else if err := simplename.Validate(vm.Name); err != nil {
@@ -42,13 +69,26 @@ func (controller *Controller) createVM(ctx *gin.Context) responder.Responder {
 	if vm.Image == "" {
 		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM image is empty"))
 	}
+	if responder := validateMetadata(&vm.Meta); responder != nil {
+		return responder
+	}
 
 	// Provide defaults
 	vm.Status = v1.VMStatusPending
 	vm.CreatedAt = time.Now()
 	vm.RestartedAt = time.Time{}
 	vm.RestartCount = 0
+	vm.NextRestartAt = time.Time{}
+	vm.ConsecutiveFailures = 0
+	vm.FinishedAt = time.Time{}
+	vm.Pool = ""
+	vm.VMSet = ""
+	vm.VMSetGeneration = 0
 	vm.UID = uuid.New().String()
+	vm.CreatedBy = ""
+	if serviceAccount, ok := serviceAccountFromContext(ctx); ok {
+		vm.CreatedBy = serviceAccount.Name
+	}
 	vm.PowerState = v1.PowerStateRunning
 	vm.LocalName = ondiskname.New(vm.Name, vm.UID, vm.RestartCount).String()
 	//nolint:staticcheck // yes, this is deprecated, but we still maintain it for backward compatibility
@@ -62,6 +102,71 @@ func (controller *Controller) createVM(ctx *gin.Context) responder.Responder {
 		},
 	}
 
+	if responder := controller.validateVM(&vm); responder != nil {
+		return responder
+	}
+
+	// Dry run never writes anything, so it only needs a read-only transaction
+	storeOperation := controller.storeUpdate
+	if dryRun {
+		storeOperation = controller.storeView
+	}
+
+	response := storeOperation(func(txn storepkg.Transaction) responder.Responder {
+		// Ensure that the VM group exists in the VM's namespace
+		if vm.Group != "" {
+			_, err := txn.GetVMGroup(vm.Namespace, vm.Group)
+			if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+				return responder.Error(err)
+			}
+			if err != nil {
+				return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("VM group %q "+
+					"does not exist in namespace %q", vm.Group, vm.Namespace))
+			}
+		}
+
+		// Does the VM resource with this name already exists?
+		_, err := txn.GetVM(vm.Namespace, vm.Name)
+		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
+			controller.logger.Errorf("failed to check if the VM exists in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+		if err == nil {
+			return responder.JSON(http.StatusConflict, NewErrorResponse("VM with this name already exists"))
+		}
+
+		if responder := controller.resolvePriorityTxn(ctx, txn, &vm, nil); responder != nil {
+			return responder
+		}
+
+		if responder := controller.admitQuotasTxn(txn, vm); responder != nil {
+			return responder
+		}
+
+		if dryRun {
+			return controller.simulateVMTxn(txn, vm)
+		}
+
+		if err := txn.SetVM(vm); err != nil {
+			controller.logger.Errorf("failed to create VM in the DB: %v", err)
+
+			return responder.Code(http.StatusInternalServerError)
+		}
+
+		return responder.JSON(http.StatusOK, &vm)
+	})
+	if dryRun {
+		return response
+	}
+	// request immediate scheduling
+	controller.scheduler.RequestScheduling()
+	return response
+}
+
+// validateVM validates the user-provided VM specification
+// and provides the defaults for the fields that are not set.
+func (controller *Controller) validateVM(vm *v1.VM) responder.Responder {
 	// Provide platform defaults
 	if vm.OS == "" {
 		vm.OS = v1.OSDarwin
@@ -117,38 +222,156 @@ func (controller *Controller) createVM(ctx *gin.Context) responder.Responder {
 		vm.RestartPolicy = v1.RestartPolicyNever
 	}
 
+	if vm.MaxRestarts != 0 && vm.RestartPolicy != v1.RestartPolicyOnFailure {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("maxRestarts can only be specified for the %s restart policy",
+				v1.RestartPolicyOnFailure))
+	}
+
+	// Validate preemption policy and provide a default value if it's missing
+	if vm.PreemptionPolicy != "" {
+		if _, err := v1.NewPreemptionPolicyFromString(string(vm.PreemptionPolicy)); err != nil {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("unsupported preemption policy: %q", vm.PreemptionPolicy))
+		}
+	} else {
+		vm.PreemptionPolicy = v1.PreemptionPolicyNever
+	}
+
+	// Validate tolerations
+	for _, toleration := range vm.Tolerations {
+		if err := toleration.Validate(); err != nil {
+			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+		}
+	}
+
+	// Validate affinity
+	if err := vm.Affinity.Validate(); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+	}
+
 	// Validate hostDirs
 	if responder := controller.validateHostDirs(vm.HostDirs); responder != nil {
 		return responder
 	}
 
-	response := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		// Does the VM resource with this name already exists?
-		_, err := txn.GetVM(vm.Name)
-		if err != nil && !errors.Is(err, storepkg.ErrNotFound) {
-			controller.logger.Errorf("failed to check if the VM exists in the DB: %v", err)
+	return nil
+}
 
-			return responder.Code(http.StatusInternalServerError)
+// resolvePriorityTxn assigns the priority and the preemption policy of the VM's priority
+// class, if any. Otherwise, only the administrators are allowed to raise the VM's priority
+// or to let it preempt the other VMs.
+//
+// When the VM's template is updated, the previous VM specification is passed in, in which
+// case the priority settings that haven't changed are kept as is.
+func (controller *Controller) resolvePriorityTxn(
+	ctx *gin.Context,
+	txn storepkg.Transaction,
+	vm *v1.VM,
+	previous *v1.VM,
+) responder.Responder {
+	if previous != nil && vm.PriorityClassName == previous.PriorityClassName &&
+		vm.Priority == previous.Priority && vm.PreemptionPolicy == previous.PreemptionPolicy {
+		return nil
+	}
+
+	if vm.PriorityClassName == "" {
+		if vm.Priority <= 0 && vm.PreemptionPolicy != v1.PreemptionPolicyPreemptLowerPriority {
+			return nil
 		}
-		if err == nil {
-			return responder.JSON(http.StatusConflict, NewErrorResponse("VM with this name already exists"))
+
+		if controller.authorize(ctx, v1.ServiceAccountRoleAdminWrite) != nil {
+			return responder.JSON(http.StatusUnauthorized, NewErrorResponse("only the service accounts "+
+				"with the %s role can set a positive priority or the %s preemption policy, "+
+				"please use a priority class instead", v1.ServiceAccountRoleAdminWrite,
+				v1.PreemptionPolicyPreemptLowerPriority))
 		}
 
-		if err := txn.SetVM(vm); err != nil {
-			controller.logger.Errorf("failed to create VM in the DB: %v", err)
+		return nil
+	}
 
-			return responder.Code(http.StatusInternalServerError)
-		}
+	clusterSettings, err := txn.GetClusterSettings()
+	if err != nil {
+		return responder.Error(err)
+	}
 
-		return responder.JSON(http.StatusOK, &vm)
+	priorityClass, ok := clusterSettings.PriorityClasses.Find(vm.PriorityClassName)
+	if !ok {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("priority class %q does not exist", vm.PriorityClassName))
+	}
+
+	if vm.Priority != 0 && vm.Priority != priorityClass.Value {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("priority %d "+
+			"conflicts with the priority %d of the priority class %q", vm.Priority,
+			priorityClass.Value, priorityClass.Name))
+	}
+
+	if vm.PreemptionPolicy == v1.PreemptionPolicyPreemptLowerPriority &&
+		priorityClass.EffectivePreemptionPolicy() != v1.PreemptionPolicyPreemptLowerPriority {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("priority class %q "+
+			"does not allow the %s preemption policy", priorityClass.Name,
+			v1.PreemptionPolicyPreemptLowerPriority))
+	}
+
+	vm.Priority = priorityClass.Value
+	vm.PreemptionPolicy = priorityClass.EffectivePreemptionPolicy()
+
+	return nil
+}
+
+// simulateVMTxn runs the scheduler against the current cluster state with the VM added
+// to it and returns the VM as it would've been created, with the worker it would've been
+// scheduled on or the status message explaining why it would've stayed pending.
+func (controller *Controller) simulateVMTxn(txn storepkg.Transaction, vm v1.VM) responder.Responder {
+	vms, err := txn.ListVMs()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	workers, err := txn.ListWorkers()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	vmGroups, err := txn.ListVMGroups()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	quotas, err := txn.ListQuotas()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	clusterSettings, err := txn.GetClusterSettings()
+	if err != nil {
+		return responder.Error(err)
+	}
+
+	result := scheduler.Simulate(scheduler.Simulation{
+		Workers:              workers,
+		VMs:                  append(vms, vm),
+		VMGroups:             vmGroups,
+		Quotas:               quotas,
+		ClusterSettings:      *clusterSettings,
+		WorkerOfflineTimeout: controller.workerOfflineTimeout,
+		FairShareUsage:       controller.scheduler.FairShareUsage(),
 	})
-	// request immediate scheduling
-	controller.scheduler.RequestScheduling()
-	return response
+
+	simulatedVM := result.VMs[len(result.VMs)-1]
+
+	if explanation, ok := result.Explanations[v1.NamespacedName(simulatedVM.Namespace,
+		simulatedVM.Name)]; ok {
+		simulatedVM.StatusMessage = explanation.Message
+	}
+
+	return responder.JSON(http.StatusOK, &simulatedVM)
 }
 
 func (controller *Controller) updateVMSpec(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
 		return responder
 	}
 
@@ -158,81 +381,192 @@ func (controller *Controller) updateVMSpec(ctx *gin.Context) responder.Responder
 		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
 	}
 
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
-	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		dbVM, err := txn.GetVM(name)
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVM, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
-		if dbVM.TerminalState() {
-			return responder.JSON(http.StatusPreconditionFailed,
-				NewErrorResponse("cannot update VM in a terminal state"))
+		if responder := controller.authorizeNamespaced(ctx, &dbVM.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
 		}
 
-		// Platform sanity checks
-		if dbVM.OS != userVM.OS || dbVM.Arch != userVM.Arch || dbVM.Runtime != userVM.Runtime {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"os\", \"arch\" "+
-				"and \"runtime\" fields cannot be modified"))
+		if responder := precondition.check("VM", dbVM.Version, &dbVM.Generation); responder != nil {
+			return responder
 		}
 
-		if err := userVM.Validate(); err != nil {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
-		}
+		return controller.updateVMSpecTxn(txn, dbVM, &userVM, &updated)
+	})
 
-		// Softnet-specific logic: automatically enable Softnet when NetSoftnetAllow or NetSoftnetBlock are set
-		// and propagate deprecated and non-deprecated boolean fields into each other
-		if userVM.NetSoftnetDeprecated || userVM.NetSoftnet || len(userVM.NetSoftnetAllow) != 0 || len(userVM.NetSoftnetBlock) != 0 {
-			userVM.NetSoftnetDeprecated = true
-			userVM.NetSoftnet = true
-		}
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VM, error) {
+		return txn.GetVM(namespace, name)
+	})
+}
 
-		// Suspendable-specific sanity checks
-		if dbVM.Suspendable && !userVM.Suspendable {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"suspendable\" cannot be "+
-				"toggled for suspendable VMs"))
-		}
-		if dbVM.Suspendable && dbVM.NetSoftnet != userVM.NetSoftnet {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"netSoftnet\" cannot be "+
-				"toggled for suspendable VMs"))
+func (controller *Controller) patchVM(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	patch, parseResponder := readMergePatch(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbVM, err := txn.GetVM(namespace, name)
+		if err != nil {
+			return responder.Error(err)
 		}
 
-		// Power state-specific sanity checks
-		if !userVM.PowerState.Valid() {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
-				"value: %s", userVM.PowerState))
+		if responder := controller.authorizeNamespaced(ctx, &dbVM.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
 		}
-		if dbVM.PowerState.TerminalState() {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
-				"transition: cannot transition from a terminal power state"))
+
+		if responder := precondition.check("VM", dbVM.Version, &dbVM.Generation); responder != nil {
+			return responder
 		}
-		if !dbVM.Suspendable && userVM.PowerState == v1.PowerStateSuspended {
-			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
-				"transition: only suspendable VMs can be suspended"))
+
+		userVM, patchResponder := applyMergePatch(dbVM, patch)
+		if patchResponder != nil {
+			return patchResponder
 		}
 
-		if cmp.Equal(dbVM.VMSpec, userVM.VMSpec) {
-			// Nothing was changed
-			return responder.JSON(http.StatusOK, dbVM)
+		if userVM.Name != dbVM.Name {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("\"name\" field cannot be modified"))
 		}
 
+		normalizePatchedMetadata(&userVM.Meta)
+
+		return controller.updateVMSpecTxn(txn, dbVM, userVM, &updated)
+	})
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.VM, error) {
+		return txn.GetVM(namespace, name)
+	})
+}
+
+// updateVMSpecTxn validates and applies the user-provided specification
+// and metadata to the VM stored in the DB.
+func (controller *Controller) updateVMSpecTxn(
+	txn storepkg.Transaction,
+	dbVM *v1.VM,
+	userVM *v1.VM,
+	updated *bool,
+) responder.Responder {
+	if responder := validateMetadata(&userVM.Meta); responder != nil {
+		return responder
+	}
+
+	if userVM.Namespace != "" && userVM.Namespace != dbVM.Namespace {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("\"namespace\" field cannot be modified"))
+	}
+
+	// Platform sanity checks
+	if dbVM.OS != userVM.OS || dbVM.Arch != userVM.Arch || dbVM.Runtime != userVM.Runtime {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"os\", \"arch\" "+
+			"and \"runtime\" fields cannot be modified"))
+	}
+
+	if err := userVM.Validate(); err != nil {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+	}
+
+	// Softnet-specific logic: automatically enable Softnet when NetSoftnetAllow or NetSoftnetBlock are set
+	// and propagate deprecated and non-deprecated boolean fields into each other
+	if userVM.NetSoftnetDeprecated || userVM.NetSoftnet || len(userVM.NetSoftnetAllow) != 0 || len(userVM.NetSoftnetBlock) != 0 {
+		userVM.NetSoftnetDeprecated = true
+		userVM.NetSoftnet = true
+	}
+
+	// Suspendable-specific sanity checks
+	if dbVM.Suspendable && !userVM.Suspendable {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"suspendable\" cannot be "+
+			"toggled for suspendable VMs"))
+	}
+	if dbVM.Suspendable && dbVM.NetSoftnet != userVM.NetSoftnet {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("\"netSoftnet\" cannot be "+
+			"toggled for suspendable VMs"))
+	}
+
+	// Power state-specific sanity checks
+	if !userVM.PowerState.Valid() {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
+			"value: %s", userVM.PowerState))
+	}
+	if !dbVM.Suspendable && userVM.PowerState == v1.PowerStateSuspended {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
+			"transition: only suspendable VMs can be suspended"))
+	}
+
+	specChanged := !cmp.Equal(dbVM.VMSpec, userVM.VMSpec)
+
+	// Only the metadata can be changed once the VM is in a terminal state
+	if specChanged && dbVM.TerminalState() {
+		return responder.JSON(http.StatusPreconditionFailed,
+			NewErrorResponse("cannot update VM in a terminal state"))
+	}
+	if specChanged && dbVM.PowerState.TerminalState() {
+		return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("invalid \"powerState\" "+
+			"transition: cannot transition from a terminal power state"))
+	}
+
+	metadataChanged := updateMetadata(&dbVM.Meta, &userVM.Meta)
+
+	if !metadataChanged && !specChanged {
+		// Nothing was changed
+		return responder.JSON(http.StatusOK, dbVM)
+	}
+
+	if specChanged {
 		// VM specification was changed
 		dbVM.VMSpec = userVM.VMSpec
 		dbVM.Generation++
+	}
 
-		if err := txn.SetVM(*dbVM); err != nil {
-			controller.logger.Errorf("failed to update VM in the DB: %v", err)
+	if err := txn.SetVM(*dbVM); err != nil {
+		controller.logger.Errorf("failed to update VM in the DB: %v", err)
 
-			return responder.Code(http.StatusInternalServerError)
-		}
+		return responder.Code(http.StatusInternalServerError)
+	}
 
-		return responder.JSON(http.StatusOK, dbVM)
-	})
+	*updated = true
+
+	return responder.JSON(http.StatusOK, dbVM)
 }
 
 func (controller *Controller) updateVMState(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
 		return responder
 	}
 
@@ -243,13 +577,25 @@ func (controller *Controller) updateVMState(ctx *gin.Context) responder.Responde
 	}
 
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	// Older workers don't specify the "namespace" query parameter,
+	// but they do send the whole VM, including its namespace
+	if ctx.Query("namespace") == "" && userVM.Namespace != "" {
+		namespace = userVM.Namespace
+	}
 
 	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		dbVM, err := txn.GetVM(name)
+		dbVM, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
+		if responder := controller.authorizeNamespaced(ctx, &dbVM.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+
 		if dbVM.TerminalState() && dbVM.Status != userVM.Status {
 			return responder.JSON(http.StatusPreconditionFailed,
 				NewErrorResponse("cannot update status for a VM in a terminal state"))
@@ -275,74 +621,135 @@ func (controller *Controller) updateVMState(ctx *gin.Context) responder.Responde
 }
 
 func (controller *Controller) getVM(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeRead); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
 		return responder
 	}
 
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
 	if ctx.Query("watch") == "true" {
+		if responder := controller.authorizeNamespaced(ctx, &v1.Meta{Namespace: namespace}, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
 		ctx.Header("Content-Type", "application/x-ndjson")
 
-		watchCh, errCh, err := controller.store.WatchVM(ctx, name)
+		watchCh, errCh, err := controller.store.WatchVM(ctx, namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
-		for {
-			select {
-			case watchMessage := <-watchCh:
-				jsonBytes, err := json.Marshal(watchMessage)
-				if err != nil {
-					controller.logger.Errorf("failed to marshal watch message "+
-						"for VM %q to JSON: %v", name, err)
-
-					return responder.Empty()
-				}
-
-				if _, err = ctx.Writer.Write(jsonBytes); err != nil {
-					return responder.Empty()
-				}
-				if _, err := ctx.Writer.WriteString("\n"); err != nil {
-					return responder.Empty()
-				}
-				ctx.Writer.Flush()
-			case err := <-errCh:
-				controller.logger.Errorf("failed to watch VM %q in the DB: %v", name, err)
-
-				return responder.Empty()
-			case <-ctx.Done():
-				return responder.Empty()
-			}
-		}
+		return streamWatchMessages(controller, ctx, fmt.Sprintf("VM %q", name), watchCh, errCh, nil)
 	}
 
 	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
-		vm, err := txn.GetVM(name)
+		vm, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
+		if responder := controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
 		return responder.JSON(http.StatusOK, vm)
 	})
 }
 
+func (controller *Controller) getVMScheduling(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
+		return responder
+	}
+
+	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
+
+	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+		vm, err := txn.GetVM(namespace, name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
+		switch {
+		case vm.IsScheduled():
+			return responder.JSON(http.StatusOK, &v1.SchedulingExplanation{
+				Message: fmt.Sprintf("VM is scheduled on worker %s", vm.Worker),
+			})
+		case vm.TerminalState(), vm.PowerState.TerminalState():
+			return responder.JSON(http.StatusOK, &v1.SchedulingExplanation{
+				Message: "VM is not pending scheduling",
+			})
+		}
+
+		if explanation, ok := controller.scheduler.Explanation(vm.UID); ok {
+			return responder.JSON(http.StatusOK, &explanation)
+		}
+
+		// The explanations are only kept in memory of the controller that
+		// runs the scheduler, so fall back to the VM's status message
+		message := vm.StatusMessage
+		if message == "" {
+			message = "VM has not been evaluated by the scheduler yet"
+		}
+
+		return responder.JSON(http.StatusOK, &v1.SchedulingExplanation{
+			Message: message,
+		})
+	})
+}
+
 func (controller *Controller) listVMs(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeRead); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
 		return responder
 	}
 
-	var filters []v1.Filter
+	namespaceSelector, authorizeResponder := controller.namespaceSelector(ctx, v1.ServiceAccountRoleComputeRead)
+	if authorizeResponder != nil {
+		return authorizeResponder
+	}
+
+	if ctx.Query("watch") == "true" {
+		return watchCollection(controller, ctx, "VMs", namespaceSelector, func(resourceVersion uint64) (
+			chan storepkg.WatchMessage[v1.VM], chan error, error) {
+			return controller.store.WatchVMs(ctx, resourceVersion)
+		})
+	}
 
-	if filterRaw := ctx.Query("filter"); filterRaw != "" {
-		for _, filterRaw := range strings.Split(filterRaw, ",") {
-			filter, err := v1.NewFilter(filterRaw)
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	selector = append(selector, namespaceSelector...)
+
+	options, parseResponder := parseListOptions(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	if options.Limit > 0 || len(options.Cursor) != 0 || options.Order != "" {
+		return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+			page, err := listPage(options, selector, txn.ListVMsPage)
 			if err != nil {
-				return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+				return responder.Error(err)
+			}
+			if len(page.NextCursor) != 0 {
+				ctx.Header("X-Next-Cursor", encodeCursor(page.NextCursor))
 			}
 
-			filters = append(filters, filter)
-		}
+			return responder.JSON(http.StatusOK, page.Items)
+		})
 	}
 
 	resultCh := controller.single.DoChan("list-vms", func() (interface{}, error) {
@@ -381,13 +788,10 @@ func (controller *Controller) listVMs(ctx *gin.Context) responder.Responder {
 	// return [] when no objects are found
 	vms := []v1.VM{}
 
-Outer:
 	// Use index-based loop to avoid per-iteration copies of v1.VM
 	for i := range allVMs {
-		for _, filter := range filters {
-			if !allVMs[i].Match(filter) {
-				continue Outer
-			}
+		if !selector.Matches(&allVMs[i]) {
+			continue
 		}
 		vms = append(vms, allVMs[i])
 	}
@@ -396,18 +800,24 @@ Outer:
 }
 
 func (controller *Controller) deleteVM(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
 		return responder
 	}
 
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
 	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		vm, err := txn.GetVM(name)
+		vm, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
-		err = txn.DeleteVM(name)
+		if responder := controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
+		err = txn.DeleteVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
@@ -423,7 +833,8 @@ func (controller *Controller) deleteVM(ctx *gin.Context) responder.Responder {
 }
 
 func (controller *Controller) appendVMEvents(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite); responder != nil {
 		return responder
 	}
 
@@ -434,12 +845,17 @@ func (controller *Controller) appendVMEvents(ctx *gin.Context) responder.Respond
 	}
 
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
 	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
-		vm, err := txn.GetVM(name)
+		vm, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
+		if responder := controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeWrite); responder != nil {
+			return responder
+		}
 		if err := txn.AppendEvents(events, "vms", vm.UID); err != nil {
 			return responder.Error(err)
 		}
@@ -449,92 +865,41 @@ func (controller *Controller) appendVMEvents(ctx *gin.Context) responder.Respond
 }
 
 func (controller *Controller) listVMEvents(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeRead); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAll, anyNamespace,
+		v1.ServiceAccountRoleComputeRead); responder != nil {
 		return responder
 	}
 
 	name := ctx.Param("name")
-	options, parseResponder := parseListVMEventsOptions(ctx)
+	namespace := requestNamespace(ctx)
+	options, parseResponder := parseListOptions(ctx)
 	if parseResponder != nil {
 		return parseResponder
 	}
 
 	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
-		vm, err := txn.GetVM(name)
+		vm, err := txn.GetVM(namespace, name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
+		if responder := controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAll,
+			v1.ServiceAccountRoleComputeRead); responder != nil {
+			return responder
+		}
+
 		page, err := txn.ListEventsPage(options, "vms", vm.UID)
 		if err != nil {
 			return responder.Error(err)
 		}
 		if len(page.NextCursor) != 0 {
-			ctx.Header("X-Next-Cursor", encodeEventCursor(page.NextCursor))
+			ctx.Header("X-Next-Cursor", encodeCursor(page.NextCursor))
 		}
 
 		return responder.JSON(http.StatusOK, page.Items)
 	})
 }
 
-func parseListVMEventsOptions(ctx *gin.Context) (storepkg.ListOptions, responder.Responder) {
-	var options storepkg.ListOptions
-
-	limitRaw := ctx.Query("limit")
-	orderRaw := ctx.Query("order")
-	cursorRaw := ctx.Query("cursor")
-
-	if limitRaw != "" {
-		limit, ok := parsePositiveInt(limitRaw)
-		if !ok {
-			return options, responder.JSON(http.StatusBadRequest,
-				NewErrorResponse("invalid limit %q: expected positive integer", limitRaw))
-		}
-		options.Limit = limit
-	}
-
-	if orderRaw != "" {
-		order, err := client.ParseLogsOrder(orderRaw)
-		if err != nil {
-			return options, responder.JSON(http.StatusBadRequest, NewErrorResponse("%s", err))
-		}
-		options.Order = storepkg.ListOrder(order)
-	}
-
-	if cursorRaw != "" {
-		cursor, err := decodeEventCursor(cursorRaw)
-		if err != nil {
-			return options, responder.JSON(http.StatusBadRequest,
-				NewErrorResponse("invalid cursor %q", cursorRaw))
-		}
-		options.Cursor = cursor
-	}
-
-	return options, nil
-}
-
-func parsePositiveInt(raw string) (int, bool) {
-	value, err := strconv.ParseInt(raw, 10, 0)
-	if err != nil || value <= 0 {
-		return 0, false
-	}
-
-	return int(value), true
-}
-
-func encodeEventCursor(cursor []byte) string {
-	return base64.RawURLEncoding.EncodeToString(cursor)
-}
-
-func decodeEventCursor(cursorRaw string) ([]byte, error) {
-	cursor, err := base64.RawURLEncoding.DecodeString(cursorRaw)
-	if err == nil {
-		return cursor, nil
-	}
-
-	return base64.URLEncoding.DecodeString(cursorRaw)
-}
-
 func (controller *Controller) validateHostDirs(hostDirs []v1.HostDir) responder.Responder {
 	if len(hostDirs) == 0 {
 		return nil
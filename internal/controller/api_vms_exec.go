@@ -11,6 +11,7 @@ import (
 
 	"github.com/avast/retry-go/v5"
 	"github.com/cirruslabs/orchard/internal/controller/sshexec"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/internal/execstream"
 	"github.com/cirruslabs/orchard/internal/responder"
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
@@ -19,13 +20,14 @@ import (
 )
 
 func (controller *Controller) execVM(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorizeAny(ctx, v1.ServiceAccountRoleComputeWrite,
-		v1.ServiceAccountRoleComputeConnect); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite, v1.ServiceAccountRoleComputeConnect); responder != nil {
 		return responder
 	}
 
 	// Retrieve and parse path and query parameters
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 	sessionID := ctx.Query("session")
 	if sessionID == "" {
 		sessionID = ctx.Query("cmux_session_id")
@@ -49,14 +51,15 @@ func (controller *Controller) execVM(ctx *gin.Context) responder.Responder {
 	}
 
 	if sessionID != "" {
-		return controller.execVMReconnectable(ctx, name, sessionID, spec, runCommand, wait)
+		return controller.execVMReconnectable(ctx, namespace, name, sessionID, spec, runCommand, wait)
 	}
 
-	return controller.execVMLegacy(ctx, name, spec, runCommand, wait)
+	return controller.execVMLegacy(ctx, namespace, name, spec, runCommand, wait)
 }
 
 func (controller *Controller) execVMLegacy(
 	ctx *gin.Context,
+	namespace string,
 	name string,
 	spec execSessionSpec,
 	runCommand string,
@@ -66,7 +69,7 @@ func (controller *Controller) execVMLegacy(
 	waitContext, waitContextCancel := context.WithTimeout(ctx, time.Duration(wait)*time.Second)
 	defer waitContextCancel()
 
-	vm, responderImpl := controller.waitForVM(waitContext, name)
+	vm, responderImpl := controller.waitForVM(ctx, waitContext, namespace, name)
 	if responderImpl != nil {
 		return responderImpl
 	}
@@ -75,7 +78,7 @@ func (controller *Controller) execVMLegacy(
 		ctx,
 		waitContext,
 		vm,
-		execSessionKey{vmName: name},
+		execSessionKey{vmNamespace: namespace, vmName: name},
 		spec,
 		runCommand,
 		nil,
@@ -107,6 +110,7 @@ func (controller *Controller) execVMLegacy(
 
 func (controller *Controller) execVMReconnectable(
 	ctx *gin.Context,
+	namespace string,
 	name string,
 	sessionID string,
 	spec execSessionSpec,
@@ -114,12 +118,26 @@ func (controller *Controller) execVMReconnectable(
 	wait uint64,
 ) responder.Responder {
 	key := execSessionKey{
-		vmName:    name,
-		sessionID: sessionID,
+		vmNamespace: namespace,
+		vmName:      name,
+		sessionID:   sessionID,
 	}
 
 	session, ok := controller.execSessions.get(key)
 	if ok {
+		// Ensure that the caller has access to the session's VM
+		if responder := controller.storeView(func(txn storepkg.Transaction) responder.Responder {
+			vm, err := txn.GetVM(namespace, name)
+			if err != nil {
+				return responder.Error(err)
+			}
+
+			return controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAny,
+				v1.ServiceAccountRoleComputeWrite, v1.ServiceAccountRoleComputeConnect)
+		}); responder != nil {
+			return responder
+		}
+
 		if !session.specMatches(spec) {
 			return responder.JSON(http.StatusConflict,
 				NewErrorResponse("exec session %q is already running with different options", sessionID))
@@ -133,7 +151,7 @@ func (controller *Controller) execVMReconnectable(
 		waitContext, waitContextCancel := context.WithTimeout(ctx, time.Duration(wait)*time.Second)
 		defer waitContextCancel()
 
-		vm, responderImpl := controller.waitForVM(waitContext, name)
+		vm, responderImpl := controller.waitForVM(ctx, waitContext, namespace, name)
 		if responderImpl != nil {
 			return responderImpl
 		}
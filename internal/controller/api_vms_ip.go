@@ -19,13 +19,14 @@ import (
 var errIPRequest = errors.New("failed to request VM's IP")
 
 func (controller *Controller) ip(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorizeAny(ctx, v1.ServiceAccountRoleComputeWrite,
-		v1.ServiceAccountRoleComputeConnect); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite, v1.ServiceAccountRoleComputeConnect); responder != nil {
 		return responder
 	}
 
 	// Retrieve and parse path and query parameters
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
 	waitRaw := ctx.DefaultQuery("wait", "0")
 	wait, err := strconv.ParseUint(waitRaw, 10, 16)
@@ -36,7 +37,7 @@ func (controller *Controller) ip(ctx *gin.Context) responder.Responder {
 	defer waitContextCancel()
 
 	// Look-up the VM
-	vm, responderImpl := controller.waitForVM(waitContext, name)
+	vm, responderImpl := controller.waitForVM(ctx, waitContext, namespace, name)
 	if responderImpl != nil {
 		return responderImpl
 	}
@@ -26,13 +26,14 @@ import (
 var errPortForwardRequest = errors.New("failed to request port forwarding")
 
 func (controller *Controller) portForwardVM(ctx *gin.Context) responder.Responder {
-	if responder := controller.authorizeAny(ctx, v1.ServiceAccountRoleComputeWrite,
-		v1.ServiceAccountRoleComputeConnect); responder != nil {
+	if responder := controller.authorizeNamespace(ctx, AuthorizeModeAny, anyNamespace,
+		v1.ServiceAccountRoleComputeWrite, v1.ServiceAccountRoleComputeConnect); responder != nil {
 		return responder
 	}
 
 	// Retrieve and parse path and query parameters
 	name := ctx.Param("name")
+	namespace := requestNamespace(ctx)
 
 	portRaw := ctx.Query("port")
 	port, err := strconv.ParseUint(portRaw, 10, 16)
@@ -53,7 +54,7 @@ func (controller *Controller) portForwardVM(ctx *gin.Context) responder.Responde
 	waitContext, waitContextCancel := context.WithTimeout(ctx, time.Duration(wait)*time.Second)
 	defer waitContextCancel()
 
-	vm, responderImpl := controller.waitForVM(waitContext, name)
+	vm, responderImpl := controller.waitForVM(ctx, waitContext, namespace, name)
 	if responderImpl != nil {
 		return responderImpl
 	}
@@ -159,18 +160,24 @@ func (controller *Controller) portForward(
 	}
 }
 
-func (controller *Controller) waitForVM(ctx context.Context, name string) (*v1.VM, responder.Responder) {
+func (controller *Controller) waitForVM(
+	ctx *gin.Context,
+	waitContext context.Context,
+	namespace string,
+	name string,
+) (*v1.VM, responder.Responder) {
 	var vm *v1.VM
 	var err error
 
 	for {
 		if lookupResponder := controller.storeView(func(txn storepkg.Transaction) responder.Responder {
-			vm, err = txn.GetVM(name)
+			vm, err = txn.GetVM(namespace, name)
 			if err != nil {
 				return responder.Error(err)
 			}
 
-			return nil
+			return controller.authorizeNamespaced(ctx, &vm.Meta, AuthorizeModeAny,
+				v1.ServiceAccountRoleComputeWrite, v1.ServiceAccountRoleComputeConnect)
 		}); lookupResponder != nil {
 			return nil, lookupResponder
 		}
@@ -184,7 +191,7 @@ func (controller *Controller) waitForVM(ctx context.Context, name string) (*v1.V
 			return vm, nil
 		}
 		select {
-		case <-ctx.Done():
+		case <-waitContext.Done():
 			return nil, responder.JSON(http.StatusRequestTimeout,
 				NewErrorResponse("VM is not running on '%s' worker", vm.Worker))
 		case <-time.After(1 * time.Second):
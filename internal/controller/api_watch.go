@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/responder"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type watchFunc[T any] func(resourceVersion uint64) (chan storepkg.WatchMessage[T], chan error, error)
+
+// watchCollection streams the changes to a collection of resources
+// as newline-delimited JSON, optionally resuming from the resource
+// version specified in the "resourceVersion" query parameter.
+//
+// Only the changes to the resources matching the selector are streamed,
+// which is used to hide the resources in the namespaces that the caller
+// has no access to.
+func watchCollection[T any](
+	controller *Controller,
+	ctx *gin.Context,
+	kind string,
+	selector v1.Selector,
+	watch watchFunc[T],
+) responder.Responder {
+	if ctx.Query("filter") != "" || ctx.Query("selector") != "" {
+		return responder.JSON(http.StatusBadRequest,
+			NewErrorResponse("filtering is not supported when watching %s", kind))
+	}
+
+	var resourceVersion uint64
+
+	if resourceVersionRaw := ctx.Query("resourceVersion"); resourceVersionRaw != "" {
+		var err error
+
+		resourceVersion, err = strconv.ParseUint(resourceVersionRaw, 10, 64)
+		if err != nil {
+			return responder.JSON(http.StatusBadRequest,
+				NewErrorResponse("invalid resource version %q: expected non-negative integer", resourceVersionRaw))
+		}
+	}
+
+	watchCh, errCh, err := watch(resourceVersion)
+	if err != nil {
+		if errors.Is(err, storepkg.ErrWatchExpired) {
+			return responder.JSON(http.StatusGone, NewErrorResponse("%v, please re-list the %s "+
+				"and watch again without specifying the resource version", err, kind))
+		}
+
+		return responder.Error(err)
+	}
+
+	ctx.Header("Content-Type", "application/x-ndjson")
+
+	return streamWatchMessages(controller, ctx, kind, watchCh, errCh,
+		func(watchMessage *storepkg.WatchMessage[T]) bool {
+			return selector.Matches(&watchMessage.Object)
+		})
+}
+
+func streamWatchMessages[T any](
+	controller *Controller,
+	ctx *gin.Context,
+	description string,
+	watchCh chan storepkg.WatchMessage[T],
+	errCh chan error,
+	match func(watchMessage *storepkg.WatchMessage[T]) bool,
+) responder.Responder {
+	for {
+		select {
+		case watchMessage, ok := <-watchCh:
+			if !ok {
+				return responder.Empty()
+			}
+
+			if match != nil && !match(&watchMessage) {
+				continue
+			}
+
+			jsonBytes, err := json.Marshal(watchMessage)
+			if err != nil {
+				controller.logger.Errorf("failed to marshal watch message "+
+					"for %s to JSON: %v", description, err)
+
+				return responder.Empty()
+			}
+
+			if _, err = ctx.Writer.Write(jsonBytes); err != nil {
+				return responder.Empty()
+			}
+			if _, err := ctx.Writer.WriteString("\n"); err != nil {
+				return responder.Empty()
+			}
+			ctx.Writer.Flush()
+		case err, ok := <-errCh:
+			if !ok {
+				return responder.Empty()
+			}
+
+			if errors.Is(err, storepkg.ErrWatchExpired) {
+				controller.logger.Debugf("watch for %s has expired: %v", description, err)
+			} else {
+				controller.logger.Errorf("failed to watch %s in the DB: %v", description, err)
+			}
+
+			return responder.Empty()
+		case <-ctx.Done():
+			return responder.Empty()
+		}
+	}
+}
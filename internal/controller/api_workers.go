@@ -30,6 +30,16 @@ func (controller *Controller) createWorker(ctx *gin.Context) responder.Responder
 			NewErrorResponse("worker name %v", err))
 	}
 
+	if responder := validateMetadata(&worker.Meta); responder != nil {
+		return responder
+	}
+	if responder := validateClusterScoped(&worker.Meta, "worker"); responder != nil {
+		return responder
+	}
+	if responder := validateTaints(worker.Taints); responder != nil {
+		return responder
+	}
+
 	// Provide platform defaults
 	if worker.Arch == "" {
 		worker.Arch = v1.ArchitectureARM64
@@ -78,6 +88,7 @@ func (controller *Controller) createWorker(ctx *gin.Context) responder.Responder
 		dbWorker.Labels = worker.Labels
 		dbWorker.DefaultCPU = worker.DefaultCPU
 		dbWorker.DefaultMemory = worker.DefaultMemory
+		dbWorker.CachedImages = worker.CachedImages
 
 		if err := txn.SetWorker(*dbWorker); err != nil {
 			return responder.Error(err)
@@ -98,27 +109,126 @@ func (controller *Controller) updateWorker(ctx *gin.Context) responder.Responder
 		return responder.JSON(http.StatusBadRequest, NewErrorResponse("invalid JSON was provided"))
 	}
 
-	return controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
 		dbWorker, err := txn.GetWorker(userWorker.Name)
 		if err != nil {
 			return responder.Error(err)
 		}
 
-		if !userWorker.LastSeen.IsZero() {
-			dbWorker.LastSeen = userWorker.LastSeen
+		if responder := precondition.check("worker", dbWorker.Version, nil); responder != nil {
+			return responder
 		}
-		dbWorker.SchedulingPaused = userWorker.SchedulingPaused
 
-		if err := txn.SetWorker(*dbWorker); err != nil {
-			controller.logger.Errorf("failed to update worker in the DB: %v", err)
+		return controller.updateWorkerTxn(txn, dbWorker, &userWorker, &updated)
+	})
 
-			return responder.Code(http.StatusInternalServerError)
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.Worker, error) {
+		return txn.GetWorker(userWorker.Name)
+	})
+}
+
+func (controller *Controller) patchWorker(ctx *gin.Context) responder.Responder {
+	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeWrite); responder != nil {
+		return responder
+	}
+
+	patch, parseResponder := readMergePatch(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	precondition, parseResponder := parsePrecondition(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	name := ctx.Param("name")
+
+	var updated bool
+
+	result := controller.storeUpdate(func(txn storepkg.Transaction) responder.Responder {
+		updated = false
+
+		dbWorker, err := txn.GetWorker(name)
+		if err != nil {
+			return responder.Error(err)
+		}
+
+		if responder := precondition.check("worker", dbWorker.Version, nil); responder != nil {
+			return responder
 		}
 
-		return responder.JSON(200, &dbWorker)
+		userWorker, patchResponder := applyMergePatch(dbWorker, patch)
+		if patchResponder != nil {
+			return patchResponder
+		}
+
+		if userWorker.Name != dbWorker.Name {
+			return responder.JSON(http.StatusPreconditionFailed,
+				NewErrorResponse("\"name\" field cannot be modified"))
+		}
+
+		normalizePatchedMetadata(&userWorker.Meta)
+
+		if userWorker.Taints == nil {
+			userWorker.Taints = []v1.Taint{}
+		}
+
+		return controller.updateWorkerTxn(txn, dbWorker, userWorker, &updated)
+	})
+
+	return respondWithUpdated(controller, result, updated, func(txn storepkg.Transaction) (*v1.Worker, error) {
+		return txn.GetWorker(name)
 	})
 }
 
+// updateWorkerTxn applies the user-modifiable fields of the worker to the worker stored in the DB.
+func (controller *Controller) updateWorkerTxn(
+	txn storepkg.Transaction,
+	dbWorker *v1.Worker,
+	userWorker *v1.Worker,
+	updated *bool,
+) responder.Responder {
+	if responder := validateMetadata(&userWorker.Meta); responder != nil {
+		return responder
+	}
+	if responder := validateTaints(userWorker.Taints); responder != nil {
+		return responder
+	}
+
+	// Cached images are reported by the worker alongside the LastSeen
+	if !userWorker.LastSeen.IsZero() {
+		dbWorker.LastSeen = userWorker.LastSeen
+		dbWorker.CachedImages = userWorker.CachedImages
+	}
+	dbWorker.SchedulingPaused = userWorker.SchedulingPaused
+	// Similarly to the metadata, nil taints are left intact to avoid
+	// wiping them on updates from the workers that are not aware of them
+	if userWorker.Taints != nil {
+		dbWorker.Taints = userWorker.Taints
+	}
+	updateMetadata(&dbWorker.Meta, &userWorker.Meta)
+
+	if err := txn.SetWorker(*dbWorker); err != nil {
+		controller.logger.Errorf("failed to update worker in the DB: %v", err)
+
+		return responder.Code(http.StatusInternalServerError)
+	}
+
+	*updated = true
+
+	return responder.JSON(200, &dbWorker)
+}
+
 func (controller *Controller) getWorker(ctx *gin.Context) responder.Responder {
 	if responder := controller.authorize(ctx, v1.ServiceAccountRoleComputeRead); responder != nil {
 		return responder
@@ -141,13 +251,33 @@ func (controller *Controller) listWorkers(ctx *gin.Context) responder.Responder
 		return responder
 	}
 
+	if ctx.Query("watch") == "true" {
+		return watchCollection(controller, ctx, "workers", nil, func(resourceVersion uint64) (
+			chan storepkg.WatchMessage[v1.Worker], chan error, error) {
+			return controller.store.WatchWorkers(ctx, resourceVersion)
+		})
+	}
+
+	selector, parseResponder := parseListSelector(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
+	options, parseResponder := parseListOptions(ctx)
+	if parseResponder != nil {
+		return parseResponder
+	}
+
 	return controller.storeView(func(txn storepkg.Transaction) responder.Responder {
-		workers, err := txn.ListWorkers()
+		page, err := listPage(options, selector, txn.ListWorkersPage)
 		if err != nil {
 			return responder.Error(err)
 		}
+		if len(page.NextCursor) != 0 {
+			ctx.Header("X-Next-Cursor", encodeCursor(page.NextCursor))
+		}
 
-		return responder.JSON(http.StatusOK, &workers)
+		return responder.JSON(http.StatusOK, page.Items)
 	})
 }
 
@@ -166,3 +296,13 @@ func (controller *Controller) deleteWorker(ctx *gin.Context) responder.Responder
 		return responder.Code(http.StatusOK)
 	})
 }
+
+func validateTaints(taints []v1.Taint) responder.Responder {
+	for _, taint := range taints {
+		if err := taint.Validate(); err != nil {
+			return responder.JSON(http.StatusPreconditionFailed, NewErrorResponse("%v", err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	"go.uber.org/zap"
+)
+
+const backupDBEntryName = "db.badger"
+
+var (
+	ErrBackupUnsupported = errors.New("backups are not supported by this store")
+	ErrRestoreFailed     = errors.New("failed to restore from backup")
+)
+
+// Backup writes a tar archive containing a consistent snapshot
+// of the database and the PKI material from the data directory.
+func (controller *Controller) Backup(w io.Writer) error {
+	backuper, ok := controller.store.(storepkg.Backuper)
+	if !ok {
+		return ErrBackupUnsupported
+	}
+
+	// Tar needs to know the entry size in advance, so dump the database
+	// to a temporary file first, this also ensures that nothing is written
+	// to w in case the database backup fails
+	dbBackupFile, err := os.CreateTemp("", "orchard-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary file for the database backup: %w", err)
+	}
+	defer func() {
+		_ = dbBackupFile.Close()
+		_ = os.Remove(dbBackupFile.Name())
+	}()
+
+	if err := backuper.Backup(dbBackupFile); err != nil {
+		return fmt.Errorf("failed to back up the database: %w", err)
+	}
+
+	dbBackupSize, err := dbBackupFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := dbBackupFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	tarWriter := tar.NewWriter(w)
+	modTime := time.Now()
+
+	for _, path := range controller.dataDir.pkiPaths() {
+		fileBytes, err := os.ReadFile(path)
+		if err != nil {
+			// PKI material might've been provided
+			// externally instead of the data directory
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return err
+		}
+
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    filepath.Base(path),
+			Mode:    0600,
+			Size:    int64(len(fileBytes)),
+			ModTime: modTime,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tarWriter.Write(fileBytes); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    backupDBEntryName,
+		Mode:    0600,
+		Size:    dbBackupSize,
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tarWriter, dbBackupFile); err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+// Restore populates the data directory from the tar archive
+// previously created with Controller.Backup().
+//
+// The data directory should not contain a database.
+func Restore(dataDir *DataDir, r io.Reader, logger *zap.SugaredLogger) error {
+	if _, err := os.Stat(dataDir.DBPath()); err == nil {
+		return fmt.Errorf("%w: database already exists at path %s", ErrRestoreFailed, dataDir.DBPath())
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %v", ErrRestoreFailed, err)
+	}
+
+	pkiPaths := map[string]string{}
+
+	for _, path := range dataDir.pkiPaths() {
+		pkiPaths[filepath.Base(path)] = path
+	}
+
+	tarReader := tar.NewReader(r)
+
+	var dbRestored bool
+
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("%w: failed to read the backup archive: %v", ErrRestoreFailed, err)
+		}
+
+		if header.Name == backupDBEntryName {
+			if err := badger.Restore(dataDir.DBPath(), tarReader, logger); err != nil {
+				return fmt.Errorf("%w: failed to load the database: %v", ErrRestoreFailed, err)
+			}
+
+			dbRestored = true
+
+			continue
+		}
+
+		path, ok := pkiPaths[header.Name]
+		if !ok {
+			return fmt.Errorf("%w: unexpected entry %q in the backup archive", ErrRestoreFailed, header.Name)
+		}
+
+		fileBytes, err := io.ReadAll(tarReader)
+		if err != nil {
+			return fmt.Errorf("%w: failed to read %s from the backup archive: %v",
+				ErrRestoreFailed, header.Name, err)
+		}
+
+		if err := os.WriteFile(path, fileBytes, 0600); err != nil {
+			return fmt.Errorf("%w: failed to write %s: %v", ErrRestoreFailed, path, err)
+		}
+	}
+
+	if !dbRestored {
+		return fmt.Errorf("%w: backup archive contains no database", ErrRestoreFailed)
+	}
+
+	return nil
+}
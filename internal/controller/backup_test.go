@@ -0,0 +1,73 @@
+//nolint:testpackage // we need to have access for Controller for this test
+package controller
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	v1pkg "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBackupRestore(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	// Populate the source data directory
+	srcDataDir, err := NewDataDir(t.TempDir())
+	require.NoError(t, err)
+
+	srcStore, err := badger.NewBadgerStore(srcDataDir.DBPath(), false, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, srcStore.Update(func(txn storepkg.Transaction) error {
+		return txn.SetVM(v1pkg.VM{Meta: v1pkg.Meta{Name: "test", Namespace: v1pkg.DefaultNamespace}, Image: "ghcr.io/cirruslabs/macos-tahoe-base:latest"})
+	}))
+
+	require.NoError(t, os.WriteFile(srcDataDir.ControllerCertificatePath(), []byte("certificate"), 0600))
+	require.NoError(t, os.WriteFile(srcDataDir.SSHHostKeyPath(), []byte("host key"), 0600))
+
+	controller := &Controller{
+		dataDir: srcDataDir,
+		store:   srcStore,
+	}
+
+	var backup bytes.Buffer
+
+	require.NoError(t, controller.Backup(&backup))
+
+	// Restore into an empty data directory
+	dstDataDir, err := NewDataDir(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, Restore(dstDataDir, bytes.NewReader(backup.Bytes()), logger))
+
+	certificateBytes, err := os.ReadFile(dstDataDir.ControllerCertificatePath())
+	require.NoError(t, err)
+	require.Equal(t, "certificate", string(certificateBytes))
+
+	hostKeyBytes, err := os.ReadFile(dstDataDir.SSHHostKeyPath())
+	require.NoError(t, err)
+	require.Equal(t, "host key", string(hostKeyBytes))
+
+	// Controller's key was not present in the source data directory
+	_, err = os.Stat(dstDataDir.ControllerKeyPath())
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	dstStore, err := badger.NewBadgerStore(dstDataDir.DBPath(), false, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, dstStore.View(func(txn storepkg.Transaction) error {
+		vm, err := txn.GetVM(v1pkg.DefaultNamespace, "test")
+		require.NoError(t, err)
+		require.Equal(t, "ghcr.io/cirruslabs/macos-tahoe-base:latest", vm.Image)
+
+		return nil
+	}))
+
+	// Restoring on top of an existing database is not allowed
+	require.ErrorIs(t, Restore(dstDataDir, bytes.NewReader(backup.Bytes()), logger), ErrRestoreFailed)
+}
@@ -8,9 +8,11 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/cirruslabs/orchard/internal/controller/leaderelection"
 	"github.com/cirruslabs/orchard/internal/controller/notifier"
 	"github.com/cirruslabs/orchard/internal/controller/rendezvous"
 	"github.com/cirruslabs/orchard/internal/controller/scheduler"
@@ -48,6 +50,7 @@ type Controller struct {
 	insecureAuthDisabled               bool
 	scheduler                          *scheduler.Scheduler
 	store                              storepkg.Store
+	leaderElection                     leaderelection.LeaderElection
 	logger                             *zap.SugaredLogger
 	grpcServer                         *grpc.Server
 	workerNotifier                     *notifier.Notifier
@@ -107,20 +110,36 @@ func New(opts ...Option) (*Controller, error) {
 		controller.logger.With("component", "exec-ssh"),
 	)
 
-	// Instantiate the database
-	store, err := badger.NewBadgerStore(controller.dataDir.DBPath(), controller.disableDBCompression,
-		controller.logger)
-	if err != nil {
-		return nil, err
+	// Instantiate the database, unless the store was provided via WithStore()
+	if controller.store == nil {
+		store, err := badger.NewBadgerStore(controller.dataDir.DBPath(), controller.disableDBCompression,
+			controller.logger)
+		if err != nil {
+			return nil, err
+		}
+		controller.store = store
+	}
+	store := controller.store
+
+	// Controller with an embedded database is always the leader,
+	// unless the leader election was provided via WithLeaderElection()
+	if controller.leaderElection == nil {
+		identity, err := os.Hostname()
+		if err != nil {
+			identity = "controller"
+		}
+
+		controller.leaderElection = leaderelection.NewLocal(identity)
 	}
-	controller.store = store
 
 	// Instantiate the worker notifier
 	controller.workerNotifier = notifier.NewNotifier(controller.logger.With("component", "rpc"))
 
 	// Instantiate the scheduler
+	var err error
+
 	controller.scheduler, err = scheduler.NewScheduler(store, controller.workerNotifier,
-		controller.workerOfflineTimeout, controller.logger)
+		controller.leaderElection, controller.workerOfflineTimeout, controller.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +186,11 @@ func New(opts ...Option) (*Controller, error) {
 		ReadHeaderTimeout: 60 * time.Second,
 	}
 
+	// Bring the database schema up-to-date
+	if err := storepkg.Migrate(controller.store, storepkg.Migrations, controller.logger); err != nil {
+		return nil, fmt.Errorf("%w: failed to migrate the database schema: %v", ErrInitFailed, err)
+	}
+
 	// Ensure cluster settings object is present
 	if err := controller.store.Update(func(txn storepkg.Transaction) error {
 		_, err := txn.GetClusterSettings()
@@ -180,16 +204,6 @@ func New(opts ...Option) (*Controller, error) {
 			ErrInitFailed, err)
 	}
 
-	// Migrate VMs that were created before platform fields were introduced
-	if err := controller.vmsEnsurePlatformDefaults(); err != nil {
-		return nil, fmt.Errorf("%w: failed to migrate VM platform defaults: %v", ErrInitFailed, err)
-	}
-
-	// Migrate workers that were created before platform fields were introduced
-	if err := controller.workersEnsurePlatformDefaults(); err != nil {
-		return nil, fmt.Errorf("%w: failed to migrate VM platform defaults: %v", ErrInitFailed, err)
-	}
-
 	// Metrics
 	if err := controller.initializeMetrics(); err != nil {
 		return nil, err
@@ -198,74 +212,6 @@ func New(opts ...Option) (*Controller, error) {
 	return controller, nil
 }
 
-func (controller *Controller) vmsEnsurePlatformDefaults() error {
-	return controller.store.Update(func(txn storepkg.Transaction) error {
-		vms, err := txn.ListVMs()
-		if err != nil {
-			return err
-		}
-
-		for _, vm := range vms {
-			updated := false
-
-			if vm.OS == "" {
-				vm.OS = v1.OSDarwin
-				updated = true
-			}
-			if vm.Arch == "" {
-				vm.Arch = v1.ArchitectureARM64
-				updated = true
-			}
-			if vm.Runtime == "" {
-				vm.Runtime = v1.RuntimeTart
-				updated = true
-			}
-
-			if !updated {
-				continue
-			}
-
-			if err := txn.SetVM(vm); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	})
-}
-
-func (controller *Controller) workersEnsurePlatformDefaults() error {
-	return controller.store.Update(func(txn storepkg.Transaction) error {
-		workers, err := txn.ListWorkers()
-		if err != nil {
-			return err
-		}
-
-		for _, worker := range workers {
-			updated := false
-
-			if worker.Arch == "" {
-				worker.Arch = v1.ArchitectureARM64
-				updated = true
-			}
-			if worker.Runtime == "" {
-				worker.Runtime = v1.RuntimeTart
-				updated = true
-			}
-
-			if !updated {
-				continue
-			}
-
-			if err := txn.SetWorker(worker); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	})
-}
-
 func (controller *Controller) ServiceAccounts() ([]v1.ServiceAccount, error) {
 	var serviceAccounts []v1.ServiceAccount
 	var err error
@@ -306,6 +252,14 @@ func (controller *Controller) DeleteServiceAccount(name string) error {
 }
 
 func (controller *Controller) Run(ctx context.Context) error {
+	// Participate in the leader election, only the
+	// leader will run the scheduling loop iterations
+	go func() {
+		if err := controller.leaderElection.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			controller.logger.Errorf("leader election failed: %v", err)
+		}
+	}()
+
 	// Run the scheduler so that each VM will eventually
 	// be assigned to a specific Worker
 	go controller.scheduler.Run()
@@ -425,6 +379,92 @@ func (controller *Controller) initializeMetrics() error {
 		return err
 	}
 
+	_, err = opentelemetry.DefaultMeter.Int64ObservableGauge("org.cirruslabs.orchard.controller.leader",
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			status := controller.leaderElection.Status()
+
+			var isLeader int64
+
+			if status.IsLeader() {
+				isLeader = 1
+			}
+
+			observer.Observe(isLeader, metric.WithAttributes(
+				attribute.String("identity", status.Identity),
+				attribute.String("leader", status.Leader),
+			))
+
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = opentelemetry.DefaultMeter.Int64ObservableGauge("org.cirruslabs.orchard.controller.leader_term",
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			status := controller.leaderElection.Status()
+
+			observer.Observe(int64(status.Term), metric.WithAttributes(
+				attribute.String("identity", status.Identity),
+			))
+
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = opentelemetry.DefaultMeter.Int64ObservableGauge("org.cirruslabs.orchard.controller.pool_ready_vms",
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			return controller.store.View(func(txn storepkg.Transaction) error {
+				vmPools, err := txn.ListVMPools()
+				if err != nil {
+					return err
+				}
+
+				for _, vmPool := range vmPools {
+					observer.Observe(int64(vmPool.ReadyReplicas), metric.WithAttributes(
+						attribute.String("namespace", vmPool.Namespace),
+						attribute.String("pool", vmPool.Name),
+					))
+				}
+
+				return nil
+			})
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = opentelemetry.DefaultMeter.Int64ObservableCounter("org.cirruslabs.orchard.controller.pool_claims",
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			return controller.store.View(func(txn storepkg.Transaction) error {
+				vmPools, err := txn.ListVMPools()
+				if err != nil {
+					return err
+				}
+
+				for _, vmPool := range vmPools {
+					for result, count := range map[string]uint64{"hit": vmPool.Hits, "miss": vmPool.Misses} {
+						observer.Observe(int64(count), metric.WithAttributes(
+							attribute.String("namespace", vmPool.Namespace),
+							attribute.String("pool", vmPool.Name),
+							attribute.String("result", result),
+						))
+					}
+				}
+
+				return nil
+			})
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	_, err = opentelemetry.DefaultMeter.Int64ObservableGauge("org.cirruslabs.orchard.controller.worker_resource",
 		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
 			return controller.store.View(func(txn storepkg.Transaction) error {
@@ -99,6 +99,14 @@ func (dataDir *DataDir) SSHHostKeyPath() string {
 	return filepath.Join(dataDir.path, "ssh_host_ed25519_key")
 }
 
+func (dataDir *DataDir) pkiPaths() []string {
+	return []string{
+		dataDir.ControllerCertificatePath(),
+		dataDir.ControllerKeyPath(),
+		dataDir.SSHHostKeyPath(),
+	}
+}
+
 func (dataDir *DataDir) Initialized() (bool, error) {
 	dataDirEntries, err := os.ReadDir(dataDir.path)
 	if err != nil {
@@ -64,8 +64,9 @@ func (spec execSessionSpec) equal(other execSessionSpec) bool {
 }
 
 type execSessionKey struct {
-	vmName    string
-	sessionID string
+	vmNamespace string
+	vmName      string
+	sessionID   string
 }
 
 type execSessionCreation struct {
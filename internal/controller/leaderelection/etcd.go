@@ -0,0 +1,153 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.uber.org/zap"
+)
+
+const etcdElectionPrefix = "/leader-election"
+
+// Etcd is a lease-based leader election on top of etcd.
+//
+// The leader holds a key that is attached to a lease, which is kept alive
+// for as long as the controller replica is able to talk to etcd. Once the
+// lease expires (e.g. the leader crashed or got partitioned), the key is
+// automatically deleted and one of the followers becomes a new leader.
+type Etcd struct {
+	client   *clientv3.Client
+	identity string
+	ttl      time.Duration
+	logger   *zap.SugaredLogger
+
+	status     Status
+	statusLock sync.Mutex
+}
+
+func NewEtcd(client *clientv3.Client, identity string, ttl time.Duration, logger *zap.SugaredLogger) *Etcd {
+	return &Etcd{
+		client:   client,
+		identity: identity,
+		ttl:      ttl,
+		logger:   logger.With("component", "leader-election"),
+		status: Status{
+			Identity: identity,
+		},
+	}
+}
+
+func (etcd *Etcd) Run(ctx context.Context) error {
+	for {
+		if err := etcd.campaign(ctx); err != nil {
+			if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			etcd.logger.Warnf("leader election failed, retrying: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (etcd *Etcd) Status() Status {
+	etcd.statusLock.Lock()
+	defer etcd.statusLock.Unlock()
+
+	return etcd.status
+}
+
+func (etcd *Etcd) setStatus(leader string, term uint64) {
+	etcd.statusLock.Lock()
+	defer etcd.statusLock.Unlock()
+
+	if etcd.status.Leader != leader || etcd.status.Term != term {
+		etcd.logger.Infof("observed a new leader %q (term %d)", leader, term)
+	}
+
+	etcd.status.Leader = leader
+	etcd.status.Term = term
+}
+
+func (etcd *Etcd) campaign(ctx context.Context) error {
+	session, err := concurrency.NewSession(etcd.client, concurrency.WithTTL(int(etcd.ttl.Seconds())),
+		concurrency.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+
+	// Forget about the previous leader, since we
+	// might have been partitioned for a while
+	etcd.setStatus("", 0)
+
+	election := concurrency.NewElection(session, etcdElectionPrefix)
+
+	observeCtx, observeCancel := context.WithCancel(ctx)
+	defer observeCancel()
+
+	// Keep track of the current leader while campaigning,
+	// this is needed to serve the leader information
+	// when we're a follower
+	go func() {
+		for response := range election.Observe(observeCtx) {
+			if len(response.Kvs) == 0 {
+				continue
+			}
+
+			etcd.setStatus(string(response.Kvs[0].Value), uint64(response.Kvs[0].CreateRevision))
+		}
+	}()
+
+	campaignCtx, campaignCancel := context.WithCancel(ctx)
+	defer campaignCancel()
+
+	// Stop campaigning once the session is lost
+	go func() {
+		select {
+		case <-session.Done():
+			campaignCancel()
+		case <-campaignCtx.Done():
+		}
+	}()
+
+	// Blocks until we're elected
+	if err := election.Campaign(campaignCtx, etcd.identity); err != nil {
+		return err
+	}
+
+	etcd.setStatus(etcd.identity, uint64(election.Rev()))
+
+	// Hold the leadership until either the session
+	// is lost or we're asked to stop
+	select {
+	case <-session.Done():
+		etcd.setStatus("", 0)
+
+		return errors.New("lost the leader election session")
+	case <-ctx.Done():
+		etcd.setStatus("", 0)
+
+		// Give up the leadership gracefully so that other
+		// replicas don't have to wait for the lease to expire
+		resignCtx, resignCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer resignCancel()
+
+		if err := election.Resign(resignCtx); err != nil {
+			etcd.logger.Warnf("failed to resign from the leader election: %v", err)
+		}
+
+		return ctx.Err()
+	}
+}
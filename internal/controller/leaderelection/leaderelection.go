@@ -0,0 +1,32 @@
+package leaderelection
+
+import (
+	"context"
+)
+
+// Status is a point-in-time view of the leader election
+// from the perspective of a single controller replica.
+type Status struct {
+	// Identity of this controller replica.
+	Identity string
+
+	// Leader is the identity of the current leader,
+	// empty when the leader is not yet known.
+	Leader string
+
+	// Term is a monotonically increasing number that
+	// changes each time a new leader is elected.
+	Term uint64
+}
+
+func (status Status) IsLeader() bool {
+	return status.Leader != "" && status.Leader == status.Identity
+}
+
+type LeaderElection interface {
+	// Run participates in the leader election until the context is canceled.
+	Run(ctx context.Context) error
+
+	// Status returns the current status of the leader election.
+	Status() Status
+}
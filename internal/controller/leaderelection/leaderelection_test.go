@@ -0,0 +1,59 @@
+package leaderelection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/leaderelection"
+	"github.com/cirruslabs/orchard/internal/tests/embeddedetcd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocal(t *testing.T) {
+	status := leaderelection.NewLocal("controller-a").Status()
+
+	require.True(t, status.IsLeader())
+	require.Equal(t, "controller-a", status.Leader)
+}
+
+func TestEtcdFailover(t *testing.T) {
+	client := embeddedetcd.Start(t)
+	logger := zap.NewNop().Sugar()
+
+	first := leaderelection.NewEtcd(client, "controller-a", 5*time.Second, logger)
+	second := leaderelection.NewEtcd(client, "controller-b", 5*time.Second, logger)
+
+	firstCtx, firstCancel := context.WithCancel(t.Context())
+	defer firstCancel()
+
+	go func() {
+		_ = first.Run(firstCtx)
+	}()
+
+	// The first candidate should become a leader
+	require.Eventually(t, func() bool {
+		return first.Status().IsLeader()
+	}, 30*time.Second, 100*time.Millisecond)
+
+	go func() {
+		_ = second.Run(t.Context())
+	}()
+
+	// The second candidate should observe the first candidate as a leader
+	require.Eventually(t, func() bool {
+		return second.Status().Leader == "controller-a"
+	}, 30*time.Second, 100*time.Millisecond)
+	require.False(t, second.Status().IsLeader())
+	firstTerm := second.Status().Term
+
+	// Once the first candidate steps down,
+	// the second candidate should take over
+	firstCancel()
+
+	require.Eventually(t, func() bool {
+		return second.Status().IsLeader()
+	}, 30*time.Second, 100*time.Millisecond)
+	require.Greater(t, second.Status().Term, firstTerm)
+}
@@ -0,0 +1,31 @@
+package leaderelection
+
+import (
+	"context"
+)
+
+// Local is a leader election for a controller that uses an embedded
+// database, in which case the controller is always the leader.
+type Local struct {
+	identity string
+}
+
+func NewLocal(identity string) *Local {
+	return &Local{
+		identity: identity,
+	}
+}
+
+func (local *Local) Run(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (local *Local) Status() Status {
+	return Status{
+		Identity: local.identity,
+		Leader:   local.identity,
+		Term:     1,
+	}
+}
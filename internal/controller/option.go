@@ -4,6 +4,8 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/cirruslabs/orchard/internal/controller/leaderelection"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh"
 )
@@ -84,6 +86,22 @@ func WithDisableDBCompression() Option {
 	}
 }
 
+// WithStore overrides the default Badger-backed store
+// that resides in the data directory.
+func WithStore(store storepkg.Store) Option {
+	return func(controller *Controller) {
+		controller.store = store
+	}
+}
+
+// WithLeaderElection overrides the default leader election,
+// in which this controller is always the leader.
+func WithLeaderElection(leaderElection leaderelection.LeaderElection) Option {
+	return func(controller *Controller) {
+		controller.leaderElection = leaderElection
+	}
+}
+
 func WithPingInterval(pingInterval time.Duration) Option {
 	return func(controller *Controller) {
 		controller.pingInterval = pingInterval
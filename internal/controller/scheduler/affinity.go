@@ -0,0 +1,279 @@
+package scheduler
+
+import (
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// ClusterState is a scheduler's view of the workers eligible
+// to run a VM and of the VMs already scheduled on all workers.
+type ClusterState struct {
+	Workers   []v1.Worker
+	WorkerVMs map[string][]v1.VM
+}
+
+type weightedSelector struct {
+	selector v1.Selector
+	weight   int64
+}
+
+type topologySpread struct {
+	topologyKey    string
+	maxSkew        int64
+	selector       v1.Selector
+	scheduleAnyway bool
+}
+
+// Placement evaluates the VM's affinity, anti-affinity and topology
+// spread constraints, as well as the soft taints against the workers.
+type Placement struct {
+	vm v1.VM
+
+	workerRequired  v1.Selector
+	workerPreferred []weightedSelector
+
+	vmRequired    v1.Selector
+	vmPreferred   []weightedSelector
+	vmTopologyKey string
+
+	topologySpreads []topologySpread
+}
+
+func NewPlacement(vm v1.VM) (*Placement, error) {
+	placement := &Placement{
+		vm: vm,
+	}
+
+	affinity := vm.Affinity
+	if affinity == nil {
+		return placement, nil
+	}
+
+	var err error
+
+	if workerAffinity := affinity.WorkerAffinity; workerAffinity != nil {
+		placement.workerRequired, err = v1.ParseSelector(workerAffinity.Required)
+		if err != nil {
+			return nil, err
+		}
+
+		placement.workerPreferred, err = parseWeightedSelectors(workerAffinity.Preferred)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vmAntiAffinity := affinity.VMAntiAffinity; vmAntiAffinity != nil {
+		placement.vmRequired, err = v1.ParseSelector(vmAntiAffinity.Required)
+		if err != nil {
+			return nil, err
+		}
+
+		placement.vmPreferred, err = parseWeightedSelectors(vmAntiAffinity.Preferred)
+		if err != nil {
+			return nil, err
+		}
+
+		placement.vmTopologyKey = vmAntiAffinity.TopologyKey
+	}
+
+	for _, constraint := range affinity.TopologySpread {
+		selector, err := v1.ParseSelector(constraint.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		maxSkew := int64(constraint.MaxSkew)
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+
+		placement.topologySpreads = append(placement.topologySpreads, topologySpread{
+			topologyKey:    constraint.TopologyKey,
+			maxSkew:        maxSkew,
+			selector:       selector,
+			scheduleAnyway: constraint.WhenUnsatisfiable == v1.WhenUnsatisfiableScheduleAnyway,
+		})
+	}
+
+	return placement, nil
+}
+
+func parseWeightedSelectors(weightedSelectors []v1.WeightedSelector) ([]weightedSelector, error) {
+	var result []weightedSelector
+
+	for _, item := range weightedSelectors {
+		selector, err := v1.ParseSelector(item.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, weightedSelector{
+			selector: selector,
+			weight:   item.Weight,
+		})
+	}
+
+	return result, nil
+}
+
+// WorkerMatches returns true if the worker satisfies the required worker affinity.
+func (placement *Placement) WorkerMatches(worker v1.Worker) bool {
+	return placement.workerRequired.Matches(&worker)
+}
+
+// Feasible returns true if the VM can be placed on the worker without
+// violating the required anti-affinity and topology spread constraints.
+func (placement *Placement) Feasible(worker v1.Worker, state ClusterState) bool {
+	if !placement.WorkerMatches(worker) {
+		return false
+	}
+
+	if len(placement.vmRequired) != 0 && placement.numMatchingVMs(worker, state, placement.vmTopologyKey,
+		placement.vmRequired) != 0 {
+		return false
+	}
+
+	for _, spread := range placement.topologySpreads {
+		if spread.scheduleAnyway {
+			continue
+		}
+
+		skew, ok := placement.skew(worker, state, spread)
+		if !ok || skew > spread.maxSkew {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Score returns the preference of placing the VM on the worker, the higher the better.
+func (placement *Placement) Score(worker v1.Worker, state ClusterState) int64 {
+	var score int64
+
+	for _, preferred := range placement.workerPreferred {
+		if preferred.selector.Matches(&worker) {
+			score += preferred.weight
+		}
+	}
+
+	for _, preferred := range placement.vmPreferred {
+		if placement.numMatchingVMs(worker, state, placement.vmTopologyKey, preferred.selector) != 0 {
+			score -= preferred.weight
+		}
+	}
+
+	// Avoid the workers with the untolerated taints that have
+	// TaintEffectPreferNoSchedule as much as the heaviest preference
+	score -= int64(len(v1.UntoleratedTaints(worker.Taints, placement.vm.Tolerations,
+		v1.TaintEffectPreferNoSchedule))) * 100
+
+	// Each unit of skew above the maximum is as
+	// undesirable as the heaviest preference
+	for _, spread := range placement.topologySpreads {
+		if !spread.scheduleAnyway {
+			continue
+		}
+
+		skew, ok := placement.skew(worker, state, spread)
+		if !ok {
+			continue
+		}
+
+		if skew > spread.maxSkew {
+			score -= (skew - spread.maxSkew) * 100
+		}
+	}
+
+	return score
+}
+
+// numMatchingVMs counts the VMs in the same namespace matching the selector that run
+// in the worker's topology domain, which is either the worker itself (when topologyKey
+// is empty) or all workers that have the same topologyKey label value.
+func (placement *Placement) numMatchingVMs(
+	worker v1.Worker,
+	state ClusterState,
+	topologyKey string,
+	selector v1.Selector,
+) int64 {
+	var result int64
+
+	countVMs := func(workerName string) {
+		for _, vm := range state.WorkerVMs[workerName] {
+			if placement.counts(vm, selector) {
+				result++
+			}
+		}
+	}
+
+	if topologyKey == "" {
+		countVMs(worker.Name)
+
+		return result
+	}
+
+	domain, ok := worker.Labels[topologyKey]
+	if !ok {
+		countVMs(worker.Name)
+
+		return result
+	}
+
+	for _, otherWorker := range state.Workers {
+		if otherDomain, ok := otherWorker.Labels[topologyKey]; ok && otherDomain == domain {
+			countVMs(otherWorker.Name)
+		}
+	}
+
+	return result
+}
+
+// skew returns the difference between the number of matching VMs in the worker's
+// topology domain (accounting for the VM being placed) and the minimum number of
+// matching VMs across all topology domains, or false if the worker has no domain.
+func (placement *Placement) skew(worker v1.Worker, state ClusterState, spread topologySpread) (int64, bool) {
+	domain, ok := worker.Labels[spread.topologyKey]
+	if !ok {
+		return 0, false
+	}
+
+	counts := map[string]int64{
+		domain: 0,
+	}
+
+	for _, otherWorker := range state.Workers {
+		otherDomain, ok := otherWorker.Labels[spread.topologyKey]
+		if !ok {
+			continue
+		}
+
+		if _, ok := counts[otherDomain]; !ok {
+			counts[otherDomain] = 0
+		}
+
+		for _, vm := range state.WorkerVMs[otherWorker.Name] {
+			if placement.counts(vm, spread.selector) {
+				counts[otherDomain]++
+			}
+		}
+	}
+
+	minCount := counts[domain]
+
+	for _, count := range counts {
+		minCount = min(minCount, count)
+	}
+
+	return counts[domain] + 1 - minCount, true
+}
+
+// counts returns true if the other VM should be accounted for
+// in the anti-affinity and topology spread constraints.
+func (placement *Placement) counts(vm v1.VM, selector v1.Selector) bool {
+	if vm.UID == placement.vm.UID || vm.TerminalState() || vm.Namespace != placement.vm.Namespace {
+		return false
+	}
+
+	return selector.Matches(&vm)
+}
@@ -0,0 +1,127 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementWorkerAffinity(t *testing.T) {
+	placement, err := scheduler.NewPlacement(v1.VM{
+		Affinity: &v1.Affinity{
+			WorkerAffinity: &v1.WorkerAffinity{
+				Required: "labels.rack in (a,b)",
+				Preferred: []v1.WeightedSelector{
+					{Selector: "labels.gpu", Weight: 10},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rackA := v1.Worker{Meta: v1.Meta{Name: "rack-a"}, Labels: v1.Labels{"rack": "a"}}
+	rackAGPU := v1.Worker{Meta: v1.Meta{Name: "rack-a-gpu"}, Labels: v1.Labels{"rack": "a", "gpu": "true"}}
+	rackC := v1.Worker{Meta: v1.Meta{Name: "rack-c"}, Labels: v1.Labels{"rack": "c"}}
+
+	state := scheduler.ClusterState{Workers: []v1.Worker{rackA, rackAGPU, rackC}}
+
+	require.True(t, placement.Feasible(rackA, state))
+	require.True(t, placement.Feasible(rackAGPU, state))
+	require.False(t, placement.Feasible(rackC, state))
+
+	require.EqualValues(t, 0, placement.Score(rackA, state))
+	require.EqualValues(t, 10, placement.Score(rackAGPU, state))
+}
+
+func TestPlacementVMAntiAffinity(t *testing.T) {
+	shard := func(name string, shard string, namespace string) v1.VM {
+		return v1.VM{
+			Meta: v1.Meta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"shard": shard},
+			},
+			UID: name,
+		}
+	}
+
+	vm := shard("shard-1-replica-2", "1", v1.DefaultNamespace)
+	vm.Affinity = &v1.Affinity{
+		VMAntiAffinity: &v1.VMAntiAffinity{
+			Required: "metadataLabels.shard=1",
+		},
+	}
+
+	placement, err := scheduler.NewPlacement(vm)
+	require.NoError(t, err)
+
+	workerA := v1.Worker{Meta: v1.Meta{Name: "worker-a"}}
+	workerB := v1.Worker{Meta: v1.Meta{Name: "worker-b"}}
+	workerC := v1.Worker{Meta: v1.Meta{Name: "worker-c"}}
+
+	state := scheduler.ClusterState{
+		Workers: []v1.Worker{workerA, workerB, workerC},
+		WorkerVMs: map[string][]v1.VM{
+			"worker-a": {shard("shard-1-replica-1", "1", v1.DefaultNamespace)},
+			"worker-b": {shard("shard-2-replica-1", "2", v1.DefaultNamespace)},
+			"worker-c": {shard("shard-1-replica-1", "1", "other")},
+		},
+	}
+
+	require.False(t, placement.Feasible(workerA, state))
+	require.True(t, placement.Feasible(workerB, state))
+	require.True(t, placement.Feasible(workerC, state), "VMs from other namespaces should not be considered")
+}
+
+func TestPlacementTopologySpread(t *testing.T) {
+	newVM := func(name string) v1.VM {
+		return v1.VM{
+			Meta: v1.Meta{
+				Name:   name,
+				Labels: map[string]string{"app": "ci"},
+			},
+			UID: name,
+		}
+	}
+
+	vm := newVM("new")
+	vm.Affinity = &v1.Affinity{
+		TopologySpread: []v1.TopologySpreadConstraint{
+			{TopologyKey: "rack", Selector: "metadataLabels.app=ci"},
+		},
+	}
+
+	placement, err := scheduler.NewPlacement(vm)
+	require.NoError(t, err)
+
+	workerA1 := v1.Worker{Meta: v1.Meta{Name: "a1"}, Labels: v1.Labels{"rack": "a"}}
+	workerA2 := v1.Worker{Meta: v1.Meta{Name: "a2"}, Labels: v1.Labels{"rack": "a"}}
+	workerB1 := v1.Worker{Meta: v1.Meta{Name: "b1"}, Labels: v1.Labels{"rack": "b"}}
+	workerNoRack := v1.Worker{Meta: v1.Meta{Name: "no-rack"}}
+
+	state := scheduler.ClusterState{
+		Workers: []v1.Worker{workerA1, workerA2, workerB1, workerNoRack},
+		WorkerVMs: map[string][]v1.VM{
+			"a1": {newVM("existing-1")},
+			"a2": {newVM("existing-2")},
+			"b1": {newVM("existing-3")},
+		},
+	}
+
+	// Rack "a" already has 2 VMs and rack "b" only has 1
+	require.False(t, placement.Feasible(workerA1, state))
+	require.False(t, placement.Feasible(workerA2, state))
+	require.True(t, placement.Feasible(workerB1, state))
+	require.False(t, placement.Feasible(workerNoRack, state))
+
+	// With ScheduleAnyway, all workers are feasible, but rack "b" is preferred
+	vm.Affinity.TopologySpread[0].WhenUnsatisfiable = v1.WhenUnsatisfiableScheduleAnyway
+
+	placement, err = scheduler.NewPlacement(vm)
+	require.NoError(t, err)
+
+	require.True(t, placement.Feasible(workerA1, state))
+	require.Less(t, placement.Score(workerA1, state), placement.Score(workerB1, state))
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+)
+
+const (
+	reasonOffline              = "offline"
+	reasonSchedulingPaused     = "scheduling paused"
+	reasonArchRuntimeMismatch  = "arch/runtime mismatch"
+	reasonLabelMismatch        = "label mismatch"
+	reasonUntoleratedTaint     = "untolerated taint"
+	reasonWorkerAffinity       = "worker affinity mismatch"
+	reasonPlacementConstraints = "VM anti-affinity or topology spread constraint"
+	reasonQuotaExceeded        = "quota exceeded"
+)
+
+func reasonInsufficientResource(resource string) string {
+	return fmt.Sprintf("insufficient %s", resource)
+}
+
+// insufficientResource returns the first (in alphabetical order)
+// resource required by the VM that the worker lacks.
+func insufficientResource(resourcesRemaining v1.Resources, resources v1.Resources) string {
+	keys := lo.Keys(resources)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if resources[key] > resourcesRemaining[key] {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// NewSchedulingExplanation summarizes the worker rejections in a message like
+// "0/12 workers are available: 8 insufficient org.cirruslabs.tart-vms, 4 label mismatch".
+func NewSchedulingExplanation(numWorkers int, rejections []v1.WorkerRejection) v1.SchedulingExplanation {
+	reasons, counts := rankReasons(rejections)
+
+	message := fmt.Sprintf("0/%d workers are available", numWorkers)
+
+	if len(reasons) != 0 {
+		message += ": " + strings.Join(lo.Map(reasons, func(reason string, _ int) string {
+			return fmt.Sprintf("%d %s", counts[reason], reason)
+		}), ", ")
+	}
+
+	return v1.SchedulingExplanation{
+		Message: message,
+		Workers: rejections,
+	}
+}
+
+// rankReasons returns the distinct rejection reasons, the most frequent
+// reasons first and ties ordered alphabetically, along with their counts.
+func rankReasons(rejections []v1.WorkerRejection) ([]string, map[string]int) {
+	counts := lo.CountValuesBy(rejections, func(rejection v1.WorkerRejection) string {
+		return rejection.Reason
+	})
+
+	reasons := lo.Keys(counts)
+	slices.SortFunc(reasons, func(a, b string) int {
+		if result := cmp.Compare(counts[b], counts[a]); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	return reasons, counts
+}
+
+// pendingStatusMessage returns the status message of a VM that remains pending
+// because of the explanation. Unlike the explanation's message, it only mentions
+// the most frequent rejection reason and not the per-reason worker counts, which
+// fluctuate as the workers come and go and would otherwise cause the pending VMs
+// to be rewritten on each scheduling loop iteration.
+func pendingStatusMessage(explanation v1.SchedulingExplanation) string {
+	reasons, _ := rankReasons(explanation.Workers)
+	if len(reasons) == 0 {
+		return explanation.Message
+	}
+
+	return fmt.Sprintf("no workers are available (most common reason: %s)", reasons[0])
+}
+
+// Explanation returns the explanation of why the VM was not scheduled
+// during the last scheduling loop iteration, if any.
+func (scheduler *Scheduler) Explanation(uid string) (v1.SchedulingExplanation, bool) {
+	scheduler.explanationsLock.Lock()
+	defer scheduler.explanationsLock.Unlock()
+
+	explanation, ok := scheduler.explanations[uid]
+
+	return explanation, ok
+}
+
+func (scheduler *Scheduler) setExplanations(explanations map[string]v1.SchedulingExplanation) {
+	scheduler.explanationsLock.Lock()
+	defer scheduler.explanationsLock.Unlock()
+
+	scheduler.explanations = explanations
+}
+
+// updatePendingStatusMessages propagates the explanations to the status messages
+// of the VMs that are still pending, only updating the VMs whose status message
+// has actually changed.
+func (scheduler *Scheduler) updatePendingStatusMessages(
+	unscheduledVMs []v1.VM,
+	explanations map[string]v1.SchedulingExplanation,
+) error {
+	vmsToUpdate := lo.Filter(unscheduledVMs, func(vm v1.VM, _ int) bool {
+		explanation, ok := explanations[vm.UID]
+
+		return ok && vm.StatusMessage != pendingStatusMessage(explanation)
+	})
+	if len(vmsToUpdate) == 0 {
+		return nil
+	}
+
+	return scheduler.store.Update(func(txn storepkg.Transaction) error {
+		for _, vm := range vmsToUpdate {
+			currentVM, err := txn.GetVM(vm.Namespace, vm.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					continue
+				}
+
+				return err
+			}
+
+			if currentVM.UID != vm.UID || currentVM.IsScheduled() || currentVM.TerminalState() {
+				continue
+			}
+
+			statusMessage := pendingStatusMessage(explanations[vm.UID])
+			if currentVM.StatusMessage == statusMessage {
+				continue
+			}
+
+			currentVM.StatusMessage = statusMessage
+
+			if err := txn.SetVM(*currentVM); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func explanationWithMessage(message string) v1.SchedulingExplanation {
+	return v1.SchedulingExplanation{
+		Message:     message,
+		EvaluatedAt: time.Now(),
+	}
+}
+
+func gangExplanationMessage(vmGroup v1.VMGroup, statusMessage string) string {
+	return fmt.Sprintf("VM group %s cannot be scheduled yet: %s", vmGroup.Name, statusMessage)
+}
@@ -0,0 +1,116 @@
+//nolint:testpackage // we need to have access to the Scheduler's internals for this test
+package scheduler
+
+import (
+	"testing"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// countingStore counts the VM writes made through the store.
+type countingStore struct {
+	storepkg.Store
+
+	vmWrites int
+}
+
+func (store *countingStore) Update(cb func(txn storepkg.Transaction) error) error {
+	return store.Store.Update(func(txn storepkg.Transaction) error {
+		return cb(&countingTransaction{Transaction: txn, store: store})
+	})
+}
+
+type countingTransaction struct {
+	storepkg.Transaction
+
+	store *countingStore
+}
+
+func (txn *countingTransaction) SetVM(vm v1.VM) error {
+	txn.store.vmWrites++
+
+	return txn.Transaction.SetVM(vm)
+}
+
+func TestUpdatePendingStatusMessages(t *testing.T) {
+	badgerStore, err := badger.NewBadgerStore(t.TempDir(), false, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	store := &countingStore{Store: badgerStore}
+
+	scheduler := &Scheduler{
+		store: store,
+	}
+
+	require.NoError(t, badgerStore.Update(func(txn storepkg.Transaction) error {
+		return txn.SetVM(v1.VM{
+			Meta: v1.Meta{
+				Name:      "pending",
+				Namespace: v1.DefaultNamespace,
+			},
+			UID:   "pending-uid",
+			Image: "example.com/doesnt/matter:latest",
+		})
+	}))
+
+	updatePendingStatusMessages := func(rejections ...v1.WorkerRejection) v1.VM {
+		var vm *v1.VM
+
+		require.NoError(t, badgerStore.View(func(txn storepkg.Transaction) error {
+			vm, err = txn.GetVM(v1.DefaultNamespace, "pending")
+
+			return err
+		}))
+
+		require.NoError(t, scheduler.updatePendingStatusMessages([]v1.VM{*vm}, map[string]v1.SchedulingExplanation{
+			vm.UID: NewSchedulingExplanation(len(rejections), rejections),
+		}))
+
+		require.NoError(t, badgerStore.View(func(txn storepkg.Transaction) error {
+			vm, err = txn.GetVM(v1.DefaultNamespace, "pending")
+
+			return err
+		}))
+
+		return *vm
+	}
+
+	// The explanation is propagated to the VM
+	vm := updatePendingStatusMessages(
+		v1.WorkerRejection{Worker: "a", Reason: reasonInsufficientResource(v1.ResourceTartVMs)},
+		v1.WorkerRejection{Worker: "b", Reason: reasonLabelMismatch},
+	)
+	require.Equal(t, "no workers are available (most common reason: insufficient org.cirruslabs.tart-vms)",
+		vm.StatusMessage)
+	require.Equal(t, 1, store.vmWrites)
+
+	// Nothing has changed, so the VM is not written
+	updatePendingStatusMessages(
+		v1.WorkerRejection{Worker: "a", Reason: reasonInsufficientResource(v1.ResourceTartVMs)},
+		v1.WorkerRejection{Worker: "b", Reason: reasonLabelMismatch},
+	)
+	require.Equal(t, 1, store.vmWrites)
+
+	// The per-reason counts have changed, but the most common reason
+	// is still the same, so the VM is not written either
+	updatePendingStatusMessages(
+		v1.WorkerRejection{Worker: "a", Reason: reasonInsufficientResource(v1.ResourceTartVMs)},
+		v1.WorkerRejection{Worker: "b", Reason: reasonLabelMismatch},
+		v1.WorkerRejection{Worker: "c", Reason: reasonInsufficientResource(v1.ResourceTartVMs)},
+		v1.WorkerRejection{Worker: "d", Reason: reasonOffline},
+	)
+	require.Equal(t, 1, store.vmWrites)
+
+	// The most common reason has changed, so the VM is written
+	vm = updatePendingStatusMessages(
+		v1.WorkerRejection{Worker: "a", Reason: reasonOffline},
+		v1.WorkerRejection{Worker: "b", Reason: reasonLabelMismatch},
+		v1.WorkerRejection{Worker: "c", Reason: reasonOffline},
+	)
+	require.Equal(t, "no workers are available (most common reason: offline)", vm.StatusMessage)
+	require.Equal(t, 2, store.vmWrites)
+}
@@ -0,0 +1,31 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchedulingExplanation(t *testing.T) {
+	rejections := []v1.WorkerRejection{
+		{Worker: "a", Reason: "label mismatch"},
+		{Worker: "b", Reason: "insufficient org.cirruslabs.tart-vms"},
+		{Worker: "c", Reason: "offline"},
+		{Worker: "d", Reason: "insufficient org.cirruslabs.tart-vms"},
+		{Worker: "e", Reason: "label mismatch"},
+		{Worker: "f", Reason: "insufficient org.cirruslabs.tart-vms"},
+	}
+
+	explanation := scheduler.NewSchedulingExplanation(6, rejections)
+
+	// Most frequent reasons come first, ties are ordered alphabetically
+	require.Equal(t, "0/6 workers are available: 3 insufficient org.cirruslabs.tart-vms, "+
+		"2 label mismatch, 1 offline", explanation.Message)
+	require.Equal(t, rejections, explanation.Workers)
+}
+
+func TestNewSchedulingExplanationNoWorkers(t *testing.T) {
+	require.Equal(t, "0/0 workers are available", scheduler.NewSchedulingExplanation(0, nil).Message)
+}
@@ -0,0 +1,253 @@
+package scheduler
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// fairShareResources are the resources whose usage is taken into account by the fair-share queueing.
+var fairShareResources = []string{v1.ResourceLogicalCores, v1.ResourceMemoryMiB, v1.ResourceTartVMs}
+
+// FairShareUsage is the amount of each resource used by each fair-share queue.
+type FairShareUsage map[string]map[string]float64
+
+// NewFairShareUsage returns the resources assigned to the VMs of each
+// fair-share queue that are currently scheduled and are not in a terminal state.
+func NewFairShareUsage(vms []v1.VM, fairShare *v1.FairShare) FairShareUsage {
+	usage := FairShareUsage{}
+
+	for _, vm := range vms {
+		if vm.IsScheduled() && !vm.TerminalState() {
+			usage.Add(fairShare.Queue(vm), vm)
+		}
+	}
+
+	return usage
+}
+
+// Add accounts the VM's resources to the queue's usage.
+func (usage FairShareUsage) Add(queue string, vm v1.VM) {
+	queueUsage, ok := usage[queue]
+	if !ok {
+		queueUsage = map[string]float64{}
+		usage[queue] = queueUsage
+	}
+
+	cpu := vm.AssignedCPU
+	if cpu == 0 {
+		cpu = vm.CPU
+	}
+
+	memory := vm.AssignedMemory
+	if memory == 0 {
+		memory = vm.Memory
+	}
+
+	queueUsage[v1.ResourceLogicalCores] += float64(cpu)
+	queueUsage[v1.ResourceMemoryMiB] += float64(memory)
+	queueUsage[v1.ResourceTartVMs] += float64(vm.Resources[v1.ResourceTartVMs])
+}
+
+// Decayed returns the exponentially decayed average of the queues' usage, with the
+// previous usage losing half of its weight every halfLife in favor of the current usage.
+//
+// When halfLife is zero, the current usage is returned as is.
+func (usage FairShareUsage) Decayed(current FairShareUsage, elapsed time.Duration, halfLife time.Duration) FairShareUsage {
+	if halfLife == 0 {
+		return current
+	}
+
+	factor := math.Pow(0.5, elapsed.Seconds()/halfLife.Seconds())
+
+	result := FairShareUsage{}
+
+	for _, queues := range []FairShareUsage{usage, current} {
+		for queue := range queues {
+			result[queue] = map[string]float64{}
+
+			for _, resource := range fairShareResources {
+				result[queue][resource] = usage[queue][resource]*factor + current[queue][resource]*(1-factor)
+			}
+		}
+	}
+
+	return result
+}
+
+// Clone returns a deep copy of the usage.
+func (usage FairShareUsage) Clone() FairShareUsage {
+	result := FairShareUsage{}
+
+	for queue, queueUsage := range usage {
+		result[queue] = maps.Clone(queueUsage)
+	}
+
+	return result
+}
+
+// FairShareCapacity returns the total amount of each resource that the workers have.
+func FairShareCapacity(workers []v1.Worker) v1.Resources {
+	capacity := v1.Resources{}
+
+	for _, worker := range workers {
+		for _, resource := range fairShareResources {
+			capacity[resource] += worker.Resources[resource]
+		}
+	}
+
+	return capacity
+}
+
+// FairShareOrder reorders the unscheduled VMs (as sorted by ProcessVMs()) so
+// that the VMs with the same priority are interleaved across the fair-share queues.
+//
+// Each queue's share is its dominant resource share (the largest fraction of the cluster's
+// capacity of CPU, memory and Tart VMs used by the queue) divided by the queue's weight.
+// The next VM is always taken from the queue with the lowest share (preferring the queue
+// with the oldest VM on a tie), after which the VM's resources are added to the queue's
+// usage as if that VM was already scheduled.
+func FairShareOrder(
+	unscheduledVMs []v1.VM,
+	usage FairShareUsage,
+	capacity v1.Resources,
+	fairShare *v1.FairShare,
+) []v1.VM {
+	usage = usage.Clone()
+
+	result := make([]v1.VM, 0, len(unscheduledVMs))
+
+	for start := 0; start < len(unscheduledVMs); {
+		// Only interleave the VMs with the same priority
+		end := start
+		for end < len(unscheduledVMs) && unscheduledVMs[end].Priority == unscheduledVMs[start].Priority {
+			end++
+		}
+
+		queues := map[string][]v1.VM{}
+		var queueNames []string
+
+		for _, vm := range unscheduledVMs[start:end] {
+			queue := fairShare.Queue(vm)
+
+			if _, ok := queues[queue]; !ok {
+				queueNames = append(queueNames, queue)
+			}
+
+			queues[queue] = append(queues[queue], vm)
+		}
+
+		for range end - start {
+			var best string
+			var bestShare float64
+			var found bool
+
+			for _, queue := range queueNames {
+				if len(queues[queue]) == 0 {
+					continue
+				}
+
+				share := dominantShare(usage[queue], capacity) / float64(fairShare.Weight(queue))
+
+				if !found || fairShareLess(share, bestShare, queues[queue][0], queues[best][0]) {
+					best = queue
+					bestShare = share
+					found = true
+				}
+			}
+
+			result = append(result, queues[best][0])
+			usage.Add(best, queues[best][0])
+			queues[best] = queues[best][1:]
+		}
+
+		start = end
+	}
+
+	return result
+}
+
+// fairShareLess compares the shares of two queues, treating the shares that only differ due to
+// the floating-point rounding as equal, in which case the queue with the oldest VM goes first.
+func fairShareLess(shareA float64, shareB float64, vmA v1.VM, vmB v1.VM) bool {
+	if math.Abs(shareA-shareB) > 1e-9*max(shareA, shareB) {
+		return shareA < shareB
+	}
+
+	return vmA.CreatedAt.Before(vmB.CreatedAt)
+}
+
+// dominantShare returns the largest fraction of the resource's capacity
+// used by the queue, the resources with no capacity are not taken into account.
+func dominantShare(queueUsage map[string]float64, capacity v1.Resources) float64 {
+	var result float64
+
+	for _, resource := range fairShareResources {
+		if capacity[resource] == 0 {
+			continue
+		}
+
+		result = max(result, queueUsage[resource]/float64(capacity[resource]))
+	}
+
+	return result
+}
+
+// FairShareUsage returns the usage of the fair-share queues averaged over time,
+// or nil if the scheduler hasn't started tracking it yet.
+func (scheduler *Scheduler) FairShareUsage() FairShareUsage {
+	scheduler.fairShareUsageLock.Lock()
+	defer scheduler.fairShareUsageLock.Unlock()
+
+	if scheduler.fairShareUsage == nil {
+		return nil
+	}
+
+	return scheduler.fairShareUsage.Clone()
+}
+
+// updateFairShareUsage folds the current usage of the fair-share
+// queues into their usage averaged over time and returns the result.
+func (scheduler *Scheduler) updateFairShareUsage(vms []v1.VM, fairShare *v1.FairShare) FairShareUsage {
+	scheduler.fairShareUsageLock.Lock()
+	defer scheduler.fairShareUsageLock.Unlock()
+
+	now := time.Now()
+	current := NewFairShareUsage(vms, fairShare)
+
+	if scheduler.fairShareUsage == nil {
+		scheduler.fairShareUsage = current
+	} else {
+		scheduler.fairShareUsage = scheduler.fairShareUsage.Decayed(current,
+			now.Sub(scheduler.fairShareUsageUpdatedAt), fairShare.UsageHalfLife())
+	}
+
+	scheduler.fairShareUsageUpdatedAt = now
+
+	return scheduler.fairShareUsage.Clone()
+}
+
+func (scheduler *Scheduler) resetFairShareUsage() {
+	scheduler.fairShareUsageLock.Lock()
+	defer scheduler.fairShareUsageLock.Unlock()
+
+	scheduler.fairShareUsage = nil
+}
+
+// PendingVMsPerQueue returns the number of unscheduled VMs
+// that are not in a terminal state in each fair-share queue.
+func PendingVMsPerQueue(vms []v1.VM, fairShare *v1.FairShare) map[string]int64 {
+	result := map[string]int64{}
+
+	for _, vm := range vms {
+		if vm.IsScheduled() || vm.TerminalState() {
+			continue
+		}
+
+		result[fairShare.Queue(vm)]++
+	}
+
+	return result
+}
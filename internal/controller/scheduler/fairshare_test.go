@@ -0,0 +1,178 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShareOrder(t *testing.T) {
+	now := time.Now()
+
+	newVM := func(name string, team string, createdAt time.Time) v1.VM {
+		return v1.VM{
+			Meta: v1.Meta{
+				Name:      name,
+				CreatedAt: createdAt,
+				Labels: map[string]string{
+					"team": team,
+				},
+			},
+			Resources: v1.Resources{
+				v1.ResourceTartVMs: 1,
+			},
+		}
+	}
+
+	capacity := v1.Resources{v1.ResourceTartVMs: 10}
+
+	fairShare := &v1.FairShare{
+		Label: "team",
+		Weights: map[string]uint64{
+			"b": 2,
+		},
+	}
+
+	// Team A has submitted a burst of VMs first, but team B has
+	// twice the weight, so it gets two VMs for each VM of team A
+	vms := []v1.VM{
+		newVM("a1", "a", now),
+		newVM("a2", "a", now.Add(1*time.Second)),
+		newVM("a3", "a", now.Add(2*time.Second)),
+		newVM("b1", "b", now.Add(3*time.Second)),
+		newVM("b2", "b", now.Add(4*time.Second)),
+		newVM("b3", "b", now.Add(5*time.Second)),
+		newVM("b4", "b", now.Add(6*time.Second)),
+	}
+
+	unscheduledVMs, _ := scheduler.ProcessVMs(vms)
+
+	require.Equal(t, []string{"a1", "b1", "b2", "a2", "b3", "b4", "a3"},
+		vmNames(scheduler.FairShareOrder(unscheduledVMs, scheduler.NewFairShareUsage(vms, fairShare),
+			capacity, fairShare)))
+
+	// Team A already runs two VMs, so team B catches up first
+	running := []v1.VM{
+		newVM("a-running-1", "a", now.Add(-time.Hour)),
+		newVM("a-running-2", "a", now.Add(-time.Hour)),
+	}
+	for i := range running {
+		running[i].Worker = "worker"
+	}
+
+	require.Equal(t, []string{"b1", "b2", "b3", "b4", "a1", "a2", "a3"},
+		vmNames(scheduler.FairShareOrder(unscheduledVMs,
+			scheduler.NewFairShareUsage(append(vms, running...), fairShare), capacity, fairShare)))
+
+	// Priority still takes precedence over the fair-share
+	urgent := newVM("a-urgent", "a", now.Add(time.Hour))
+	urgent.Priority = 100
+
+	unscheduledVMs, _ = scheduler.ProcessVMs(append(vms, urgent))
+
+	require.Equal(t, "a-urgent", scheduler.FairShareOrder(unscheduledVMs,
+		scheduler.NewFairShareUsage(vms, fairShare), capacity, fairShare)[0].Name)
+
+	require.Equal(t, map[string]int64{"a": 4, "b": 4},
+		scheduler.PendingVMsPerQueue(append(vms, urgent, running[0]), fairShare))
+}
+
+func TestFairShareOrderDominantResource(t *testing.T) {
+	now := time.Now()
+
+	newVM := func(name string, team string, createdAt time.Time, cpu uint64) v1.VM {
+		return v1.VM{
+			Meta: v1.Meta{
+				Name:      name,
+				CreatedAt: createdAt,
+				Labels: map[string]string{
+					"team": team,
+				},
+			},
+			CPU: cpu,
+			Resources: v1.Resources{
+				v1.ResourceTartVMs: 1,
+			},
+		}
+	}
+
+	fairShare := &v1.FairShare{Label: "team"}
+
+	capacity := scheduler.FairShareCapacity([]v1.Worker{
+		{Resources: v1.Resources{v1.ResourceTartVMs: 2, v1.ResourceLogicalCores: 16}},
+		{Resources: v1.Resources{v1.ResourceTartVMs: 2, v1.ResourceLogicalCores: 16}},
+	})
+	require.Equal(t, v1.Resources{
+		v1.ResourceTartVMs:      4,
+		v1.ResourceLogicalCores: 32,
+		v1.ResourceMemoryMiB:    0,
+	}, capacity)
+
+	// Both teams run a single VM, but team A's VM uses half of the cluster's
+	// CPU, so team B's VMs go first until its CPU share catches up
+	running := []v1.VM{
+		newVM("a-running", "a", now.Add(-time.Hour), 16),
+		newVM("b-running", "b", now.Add(-time.Hour), 2),
+	}
+	for i := range running {
+		running[i].Worker = "worker"
+	}
+
+	vms := append([]v1.VM{
+		newVM("a1", "a", now, 2),
+		newVM("b1", "b", now.Add(time.Second), 8),
+		newVM("b2", "b", now.Add(2*time.Second), 8),
+	}, running...)
+
+	unscheduledVMs, _ := scheduler.ProcessVMs(vms)
+
+	require.Equal(t, []string{"b1", "a1", "b2"}, vmNames(scheduler.FairShareOrder(unscheduledVMs,
+		scheduler.NewFairShareUsage(vms, fairShare), capacity, fairShare)))
+}
+
+func TestFairShareUsageDecayed(t *testing.T) {
+	previous := scheduler.FairShareUsage{
+		"a": {v1.ResourceTartVMs: 4},
+	}
+	current := scheduler.FairShareUsage{
+		"b": {v1.ResourceTartVMs: 2},
+	}
+
+	// Without the half-life, only the current usage matters
+	require.Equal(t, current, previous.Decayed(current, time.Minute, 0))
+
+	// After a single half-life, the previous usage counts half as much
+	decayed := previous.Decayed(current, time.Hour, time.Hour)
+	require.InDelta(t, 2, decayed["a"][v1.ResourceTartVMs], 1e-9)
+	require.InDelta(t, 1, decayed["b"][v1.ResourceTartVMs], 1e-9)
+
+	// Team A that has just finished running its VMs is still
+	// considered to be using more than team B for a while
+	fairShare := &v1.FairShare{Label: "team"}
+	now := time.Now()
+
+	unscheduledVMs, _ := scheduler.ProcessVMs([]v1.VM{
+		{
+			Meta:      v1.Meta{Name: "a1", CreatedAt: now, Labels: map[string]string{"team": "a"}},
+			Resources: v1.Resources{v1.ResourceTartVMs: 1},
+		},
+		{
+			Meta:      v1.Meta{Name: "b1", CreatedAt: now.Add(time.Second), Labels: map[string]string{"team": "b"}},
+			Resources: v1.Resources{v1.ResourceTartVMs: 1},
+		},
+	})
+
+	require.Equal(t, []string{"b1", "a1"}, vmNames(scheduler.FairShareOrder(unscheduledVMs,
+		previous.Decayed(scheduler.FairShareUsage{}, time.Hour, time.Hour),
+		v1.Resources{v1.ResourceTartVMs: 10}, fairShare)))
+}
+
+func vmNames(vms []v1.VM) []string {
+	return lo.Map(vms, func(vm v1.VM, _ int) string {
+		return vm.Name
+	})
+}
@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// FilterPlugin rules out the workers that are not able to run a VM.
+type FilterPlugin interface {
+	// Filter returns the reason why the worker is not able
+	// to run the VM or an empty string if it's able to.
+	Filter(vm v1.VM, worker v1.Worker, state *CycleState) string
+}
+
+// ScorePlugin expresses a preference of placing a VM on some workers over the others.
+type ScorePlugin interface {
+	// Score returns the worker's score, the higher the better. The scores
+	// don't need to be in any particular range, since the framework
+	// normalizes them across the workers.
+	Score(vm v1.VM, worker v1.Worker, state *CycleState) int64
+}
+
+// CycleState is the state shared by the plugins when evaluating the workers for a VM.
+type CycleState struct {
+	// Placement of the VM.
+	Placement *Placement
+
+	// WorkerInfos is the lagging resource usage of the workers.
+	WorkerInfos WorkerInfos
+
+	// ClusterState consists of the workers that passed the filters
+	// and the VMs that run on them. It's only available to
+	// the capacity filters and the score plugins.
+	ClusterState ClusterState
+}
+
+type weightedScorePlugin struct {
+	plugin ScorePlugin
+	weight uint64
+}
+
+// Framework ranks the workers for a VM using the filter and the score plugins.
+type Framework struct {
+	// filters rule out the workers that will never be able to run the VM
+	filters []FilterPlugin
+
+	// capacityFilters rule out the workers that can't run the VM at the moment,
+	// which might change once the VMs running on them are gone
+	capacityFilters []FilterPlugin
+
+	scorePlugins []weightedScorePlugin
+}
+
+// Ranking is the outcome of evaluating the workers for a VM.
+type Ranking struct {
+	// Candidates are the workers that passed the filters, but not necessarily
+	// the capacity filters, ordered by preference. These are the workers
+	// on which preempting the VMs might help.
+	Candidates []v1.Worker
+
+	// Feasible are the candidates that also passed
+	// the capacity filters, ordered by preference.
+	Feasible []v1.Worker
+
+	// Rejections explain why the rest of the workers were ruled out.
+	Rejections []v1.WorkerRejection
+
+	Placement *Placement
+	State     ClusterState
+}
+
+// NewFramework returns a framework with the built-in filters
+// and the score plugins with the specified weights.
+func NewFramework(workerOfflineTimeout time.Duration, weights v1.SchedulerScoreWeights) *Framework {
+	framework := &Framework{
+		filters: append(eligibilityFilters(workerOfflineTimeout), workerAffinityFilter{}),
+		capacityFilters: []FilterPlugin{
+			resourceFitFilter{},
+			placementFilter{},
+		},
+	}
+
+	for name, weight := range weights {
+		plugin, ok := scorePlugins[name]
+		if !ok || weight == 0 {
+			continue
+		}
+
+		framework.scorePlugins = append(framework.scorePlugins, weightedScorePlugin{
+			plugin: plugin,
+			weight: weight,
+		})
+	}
+
+	return framework
+}
+
+// Rank evaluates the workers for the VM.
+func (framework *Framework) Rank(
+	vm v1.VM,
+	workers []v1.Worker,
+	workerInfos WorkerInfos,
+	workerVMs map[string][]v1.VM,
+) (Ranking, error) {
+	placement, err := NewPlacement(vm)
+	if err != nil {
+		return Ranking{}, err
+	}
+
+	state := &CycleState{
+		Placement:   placement,
+		WorkerInfos: workerInfos,
+	}
+
+	var ranking Ranking
+
+	for _, worker := range workers {
+		if reason := runFilters(framework.filters, vm, worker, state); reason != "" {
+			ranking.Rejections = append(ranking.Rejections, v1.WorkerRejection{
+				Worker: worker.Name,
+				Reason: reason,
+			})
+
+			continue
+		}
+
+		ranking.Candidates = append(ranking.Candidates, worker)
+	}
+
+	state.ClusterState = ClusterState{
+		Workers:   ranking.Candidates,
+		WorkerVMs: workerVMs,
+	}
+
+	// Prefer the workers with the highest weighted score,
+	// preserving the order of the workers on a tie
+	scores := framework.score(vm, ranking.Candidates, state)
+
+	ranking.Candidates = slices.Clone(ranking.Candidates)
+	slices.SortStableFunc(ranking.Candidates, func(a, b v1.Worker) int {
+		return cmp.Compare(scores[b.Name], scores[a.Name])
+	})
+
+	for _, worker := range ranking.Candidates {
+		if reason := runFilters(framework.capacityFilters, vm, worker, state); reason != "" {
+			ranking.Rejections = append(ranking.Rejections, v1.WorkerRejection{
+				Worker: worker.Name,
+				Reason: reason,
+			})
+
+			continue
+		}
+
+		ranking.Feasible = append(ranking.Feasible, worker)
+	}
+
+	ranking.Placement = placement
+	ranking.State = state.ClusterState
+
+	return ranking, nil
+}
+
+// score calculates the weighted sum of the normalized scores of each worker.
+func (framework *Framework) score(vm v1.VM, workers []v1.Worker, state *CycleState) map[string]float64 {
+	result := map[string]float64{}
+
+	for _, scorePlugin := range framework.scorePlugins {
+		rawScores := make([]int64, len(workers))
+
+		for i, worker := range workers {
+			rawScores[i] = scorePlugin.plugin.Score(vm, worker, state)
+		}
+
+		for i, normalizedScore := range normalizeScores(rawScores) {
+			result[workers[i].Name] += float64(scorePlugin.weight) * normalizedScore
+		}
+	}
+
+	return result
+}
+
+// normalizeScores maps the scores to the range from 0 to 100.
+func normalizeScores(scores []int64) []float64 {
+	result := make([]float64, len(scores))
+
+	if len(scores) == 0 {
+		return result
+	}
+
+	lowest, highest := slices.Min(scores), slices.Max(scores)
+	if lowest == highest {
+		return result
+	}
+
+	for i, score := range scores {
+		result[i] = float64(score-lowest) * 100 / float64(highest-lowest)
+	}
+
+	return result
+}
+
+func runFilters(filters []FilterPlugin, vm v1.VM, worker v1.Worker, state *CycleState) string {
+	for _, filter := range filters {
+		if reason := filter.Filter(vm, worker, state); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
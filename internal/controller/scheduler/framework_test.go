@@ -0,0 +1,137 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func newFrameworkTestWorker(name string, tartVMs uint64, labels v1.Labels) v1.Worker {
+	return v1.Worker{
+		Meta:      v1.Meta{Name: name},
+		LastSeen:  time.Now(),
+		Resources: v1.Resources{v1.ResourceTartVMs: tartVMs},
+		Labels:    labels,
+	}
+}
+
+func workerNames(workers []v1.Worker) []string {
+	return lo.Map(workers, func(worker v1.Worker, _ int) string {
+		return worker.Name
+	})
+}
+
+func TestFrameworkPresets(t *testing.T) {
+	workers := []v1.Worker{
+		newFrameworkTestWorker("idle", 2, nil),
+		newFrameworkTestWorker("busy", 2, nil),
+		newFrameworkTestWorker("full", 2, nil),
+	}
+
+	workerInfos := scheduler.WorkerInfos{}
+	workerInfos.AddVM("busy", v1.Resources{v1.ResourceTartVMs: 1})
+	workerInfos.AddVM("full", v1.Resources{v1.ResourceTartVMs: 1})
+	workerInfos.AddVM("full", v1.Resources{v1.ResourceTartVMs: 1})
+
+	vm := v1.VM{Resources: v1.Resources{v1.ResourceTartVMs: 1}}
+
+	// Busiest workers first, the full worker is only a preemption candidate
+	ranking, err := scheduler.NewFramework(time.Minute, v1.SchedulerProfileOptimizeUtilization.ScoreWeights()).
+		Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"full", "busy", "idle"}, workerNames(ranking.Candidates))
+	require.Equal(t, []string{"busy", "idle"}, workerNames(ranking.Feasible))
+	require.Equal(t, []v1.WorkerRejection{
+		{Worker: "full", Reason: "insufficient org.cirruslabs.tart-vms"},
+	}, ranking.Rejections)
+
+	// Least occupied workers first
+	ranking, err = scheduler.NewFramework(time.Minute, v1.SchedulerProfileDistributeLoad.ScoreWeights()).
+		Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"idle", "busy"}, workerNames(ranking.Feasible))
+}
+
+func TestFrameworkWeights(t *testing.T) {
+	workers := []v1.Worker{
+		newFrameworkTestWorker("idle", 3, nil),
+		newFrameworkTestWorker("busy-gpu", 3, v1.Labels{"gpu": "true"}),
+		newFrameworkTestWorker("offline", 3, nil),
+		newFrameworkTestWorker("busy", 3, nil),
+	}
+	workers[2].LastSeen = time.Now().Add(-time.Hour)
+
+	workerInfos := scheduler.WorkerInfos{}
+	workerInfos.AddVM("busy", v1.Resources{v1.ResourceTartVMs: 1})
+	workerInfos.AddVM("busy", v1.Resources{v1.ResourceTartVMs: 1})
+	workerInfos.AddVM("busy-gpu", v1.Resources{v1.ResourceTartVMs: 1})
+
+	vm := v1.VM{
+		Resources: v1.Resources{v1.ResourceTartVMs: 1},
+		Affinity: &v1.Affinity{
+			WorkerAffinity: &v1.WorkerAffinity{
+				Preferred: []v1.WeightedSelector{
+					{Selector: "labels.gpu", Weight: 10},
+				},
+			},
+		},
+	}
+
+	// The affinity outweighs the bin-packing
+	ranking, err := scheduler.NewFramework(time.Minute, v1.SchedulerScoreWeights{
+		v1.SchedulerScorePluginAffinity:   10,
+		v1.SchedulerScorePluginBinPacking: 1,
+	}).Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"busy-gpu", "busy", "idle"}, workerNames(ranking.Feasible))
+	require.Equal(t, []v1.WorkerRejection{
+		{Worker: "offline", Reason: "offline"},
+	}, ranking.Rejections)
+
+	// The bin-packing outweighs the affinity
+	ranking, err = scheduler.NewFramework(time.Minute, v1.SchedulerScoreWeights{
+		v1.SchedulerScorePluginAffinity:   1,
+		v1.SchedulerScorePluginBinPacking: 10,
+	}).Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"busy", "busy-gpu", "idle"}, workerNames(ranking.Feasible))
+
+	// Without any score plugins, the order of the workers is preserved
+	ranking, err = scheduler.NewFramework(time.Minute, nil).Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"idle", "busy-gpu", "busy"}, workerNames(ranking.Feasible))
+}
+
+func TestFrameworkImageLocality(t *testing.T) {
+	workers := []v1.Worker{
+		newFrameworkTestWorker("busy", 3, nil),
+		newFrameworkTestWorker("cached", 3, nil),
+	}
+	workers[1].CachedImages = []string{"ghcr.io/cirruslabs/macos-sequoia-base:latest"}
+
+	workerInfos := scheduler.WorkerInfos{}
+	workerInfos.AddVM("busy", v1.Resources{v1.ResourceTartVMs: 1})
+
+	vm := v1.VM{
+		Image:     "ghcr.io/cirruslabs/macos-sequoia-base:latest",
+		Resources: v1.Resources{v1.ResourceTartVMs: 1},
+	}
+
+	// Image locality outweighs the bin-packing
+	ranking, err := scheduler.NewFramework(time.Minute, v1.SchedulerProfileOptimizeUtilization.ScoreWeights()).
+		Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cached", "busy"}, workerNames(ranking.Feasible))
+
+	// The VMs with other images are bin-packed as usual
+	vm.Image = "ghcr.io/cirruslabs/macos-sonoma-base:latest"
+
+	ranking, err = scheduler.NewFramework(time.Minute, v1.SchedulerProfileOptimizeUtilization.ScoreWeights()).
+		Rank(vm, workers, workerInfos, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"busy", "cached"}, workerNames(ranking.Feasible))
+}
@@ -0,0 +1,253 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+type gangAssignment struct {
+	vm     v1.VM
+	worker v1.Worker
+}
+
+// GroupMembers keeps track of the number of VM group members,
+// keyed by the group's v1.NamespacedName().
+type GroupMembers map[string]GroupMemberCounts
+
+type GroupMemberCounts struct {
+	// Active is the number of members that are not in a terminal state.
+	Active uint64
+
+	// Scheduled is the number of active members that are currently scheduled.
+	Scheduled uint64
+}
+
+func NewGroupMembers(vms []v1.VM) GroupMembers {
+	groupMembers := GroupMembers{}
+
+	for _, vm := range vms {
+		if vm.Group == "" || vm.TerminalState() {
+			continue
+		}
+
+		group := v1.NamespacedName(vm.Namespace, vm.Group)
+
+		counts := groupMembers[group]
+
+		counts.Active++
+
+		if vm.IsScheduled() {
+			counts.Scheduled++
+		}
+
+		groupMembers[group] = counts
+	}
+
+	return groupMembers
+}
+
+func (groupMembers GroupMembers) AddScheduled(group string) {
+	counts := groupMembers[group]
+	counts.Scheduled++
+	groupMembers[group] = counts
+}
+
+// GroupStatus returns the status of the VM group based on its member counts.
+func GroupStatus(vmGroup v1.VMGroup, counts GroupMemberCounts) (v1.VMGroupStatus, string) {
+	if counts.Scheduled >= vmGroup.MinMember {
+		return v1.VMGroupStatusScheduled, ""
+	}
+
+	if counts.Active < vmGroup.MinMember {
+		return v1.VMGroupStatusPending, fmt.Sprintf("waiting for %d more member(s) to be created",
+			vmGroup.MinMember-counts.Active)
+	}
+
+	return v1.VMGroupStatusPending, ""
+}
+
+// planGang finds the placements for the group's unscheduled members on a copy of the
+// cluster state, succeeding only when at least MinMember of the group's members
+// end up being scheduled.
+//
+// When the group cannot be scheduled, a status message explaining why is returned.
+func planGang(
+	vmGroup v1.VMGroup,
+	unscheduledVMs []v1.VM,
+	counts GroupMemberCounts,
+	workers []v1.Worker,
+	workerInfos WorkerInfos,
+	workerVMs map[string][]v1.VM,
+	quotaUsage *QuotaUsage,
+	framework *Framework,
+) ([]gangAssignment, string) {
+	if counts.Active < vmGroup.MinMember {
+		_, statusMessage := GroupStatus(vmGroup, counts)
+
+		return nil, statusMessage
+	}
+
+	// Work on a copy of the lagging resource usage,
+	// since the placements are tentative at this point
+	simulatedWorkerInfos := workerInfos.Clone()
+	simulatedWorkerVMs := maps.Clone(workerVMs)
+	simulatedQuotaUsage := quotaUsage.Clone()
+
+	var assignments []gangAssignment
+
+	for _, member := range unscheduledVMs {
+		if member.Group != vmGroup.Name || member.Namespace != vmGroup.Namespace ||
+			member.TerminalState() || member.PowerState.TerminalState() {
+			continue
+		}
+
+		ranking, err := framework.Rank(member, workers, simulatedWorkerInfos, simulatedWorkerVMs)
+		if err != nil {
+			// The member's affinity is invalid, so it can't be placed
+			continue
+		}
+
+		for _, worker := range ranking.Feasible {
+			if simulatedQuotaUsage.Admit(member, worker) != nil {
+				continue
+			}
+
+			simulatedWorkerInfos.AddVM(worker.Name, member.Resources)
+			simulatedQuotaUsage.Assign(member, worker)
+			simulatedWorkerVMs[worker.Name] = append(slices.Clone(simulatedWorkerVMs[worker.Name]), member)
+
+			assignments = append(assignments, gangAssignment{
+				vm:     member,
+				worker: worker,
+			})
+
+			break
+		}
+	}
+
+	if counts.Scheduled+uint64(len(assignments)) < vmGroup.MinMember {
+		return nil, fmt.Sprintf("only %d of the minimum %d members can be scheduled",
+			counts.Scheduled+uint64(len(assignments)), vmGroup.MinMember)
+	}
+
+	return assignments, ""
+}
+
+// assignGang assigns the group's unscheduled members to the planned workers in a single transaction.
+func (committer *storeCommitter) assignGang(
+	vmGroup v1.VMGroup,
+	assignments []gangAssignment,
+) ([]gangAssignment, error) {
+	scheduler := committer.scheduler
+
+	var result []gangAssignment
+
+	err := scheduler.store.Update(func(txn storepkg.Transaction) error {
+		// Start from scratch, since the transaction might be retried
+		result = nil
+
+		currentVMGroup, err := txn.GetVMGroup(vmGroup.Namespace, vmGroup.Name)
+		if err != nil {
+			if errors.Is(err, storepkg.ErrNotFound) {
+				return ErrVMSchedulingSkipped
+			}
+
+			return err
+		}
+
+		if !currentVMGroup.CreatedAt.Equal(vmGroup.CreatedAt) {
+			// The group had changed, so we'll re-evaluate
+			// it in the next scheduling loop iteration
+			return ErrVMSchedulingSkipped
+		}
+
+		for _, assignment := range assignments {
+			vm := assignment.vm
+
+			if err := scheduler.assignTxn(txn, &vm, assignment.worker); err != nil {
+				return err
+			}
+
+			result = append(result, gangAssignment{
+				vm:     vm,
+				worker: assignment.worker,
+			})
+		}
+
+		currentVMGroup.Status = v1.VMGroupStatusScheduled
+		currentVMGroup.StatusMessage = ""
+		currentVMGroup.ScheduledAt = time.Now()
+
+		return txn.SetVMGroup(*currentVMGroup)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.logger.Infof("gang-scheduled %d VM(s) of group %s", len(result), vmGroup.Name)
+
+	for _, assignment := range result {
+		committer.assigned(assignment.vm, assignment.worker)
+	}
+
+	return result, nil
+}
+
+// updateGroupStatuses updates the status of the VM groups whose status has changed.
+func (scheduler *Scheduler) updateGroupStatuses(
+	vmGroups []v1.VMGroup,
+	groupMembers GroupMembers,
+	gangStatusMessages map[string]string,
+) error {
+	if len(vmGroups) == 0 {
+		return nil
+	}
+
+	return scheduler.store.Update(func(txn storepkg.Transaction) error {
+		for _, vmGroup := range vmGroups {
+			currentVMGroup, err := txn.GetVMGroup(vmGroup.Namespace, vmGroup.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					continue
+				}
+
+				return err
+			}
+
+			if !currentVMGroup.CreatedAt.Equal(vmGroup.CreatedAt) {
+				continue
+			}
+
+			group := v1.NamespacedName(currentVMGroup.Namespace, currentVMGroup.Name)
+
+			status, statusMessage := GroupStatus(*currentVMGroup, groupMembers[group])
+			if gangStatusMessage, ok := gangStatusMessages[group]; ok && statusMessage == "" &&
+				status == v1.VMGroupStatusPending {
+				statusMessage = gangStatusMessage
+			}
+
+			if currentVMGroup.Status == status && currentVMGroup.StatusMessage == statusMessage {
+				continue
+			}
+
+			if status == v1.VMGroupStatusScheduled && currentVMGroup.Status != status {
+				currentVMGroup.ScheduledAt = time.Now()
+			}
+
+			currentVMGroup.Status = status
+			currentVMGroup.StatusMessage = statusMessage
+
+			if err := txn.SetVMGroup(*currentVMGroup); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
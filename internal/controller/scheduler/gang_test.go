@@ -0,0 +1,45 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupMembers(t *testing.T) {
+	groupMembers := scheduler.NewGroupMembers([]v1.VM{
+		{Meta: v1.Meta{Namespace: "a"}, Group: "trio", Worker: "worker"},
+		{Meta: v1.Meta{Namespace: "a"}, Group: "trio"},
+		{Meta: v1.Meta{Namespace: "a"}, Group: "trio", Worker: "worker", Status: v1.VMStatusFailed},
+		{Meta: v1.Meta{Namespace: "a"}, Group: "duo"},
+		{Meta: v1.Meta{Namespace: "b"}, Group: "duo", Worker: "worker"},
+		{},
+	})
+
+	require.Equal(t, scheduler.GroupMembers{
+		"a/trio": {Active: 2, Scheduled: 1},
+		"a/duo":  {Active: 1},
+		"b/duo":  {Active: 1, Scheduled: 1},
+	}, groupMembers)
+
+	groupMembers.AddScheduled("a/duo")
+	require.Equal(t, scheduler.GroupMemberCounts{Active: 1, Scheduled: 1}, groupMembers["a/duo"])
+}
+
+func TestGroupStatus(t *testing.T) {
+	vmGroup := v1.VMGroup{MinMember: 3}
+
+	status, statusMessage := scheduler.GroupStatus(vmGroup, scheduler.GroupMemberCounts{Active: 1})
+	require.Equal(t, v1.VMGroupStatusPending, status)
+	require.Equal(t, "waiting for 2 more member(s) to be created", statusMessage)
+
+	status, statusMessage = scheduler.GroupStatus(vmGroup, scheduler.GroupMemberCounts{Active: 3, Scheduled: 2})
+	require.Equal(t, v1.VMGroupStatusPending, status)
+	require.Empty(t, statusMessage)
+
+	status, statusMessage = scheduler.GroupStatus(vmGroup, scheduler.GroupMemberCounts{Active: 4, Scheduled: 3})
+	require.Equal(t, v1.VMGroupStatusScheduled, status)
+	require.Empty(t, statusMessage)
+}
@@ -0,0 +1,288 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/samber/lo"
+)
+
+// committer carries out the planner's decisions, either by persisting
+// them in the store or by applying them to the in-memory cluster state.
+type committer interface {
+	// assign assigns the VM to the worker, returning ErrVMSchedulingSkipped
+	// to skip the VM or ErrWorkerSchedulingSkipped to try the next worker.
+	assign(vm *v1.VM, worker v1.Worker) error
+
+	// assignGang assigns the VM group's members to the workers all at
+	// once and returns the members as they were assigned.
+	assignGang(vmGroup v1.VMGroup, assignments []gangAssignment) ([]gangAssignment, error)
+
+	// preempt evicts the victims on the worker in favor of the preemptor.
+	preempt(preemptor v1.VM, candidate preemptionCandidate) error
+}
+
+// planner places the unscheduled VMs on the workers, keeping track of the lagging
+// resource usage as it goes. It's shared between the scheduling loop iteration and
+// the simulation, which only differ in how the placements are committed.
+type planner struct {
+	workers   []v1.Worker
+	framework *Framework
+	committer committer
+
+	// key identifies the VMs in the explanations
+	key func(vm v1.VM) string
+
+	workerInfos WorkerInfos
+	quotaUsage  *QuotaUsage
+
+	// VMs scheduled on each worker, used for the affinity and preemption
+	workerVMs map[string][]v1.VM
+
+	// VM groups and their members, VM groups are gang-scheduled only once per
+	// pass, when encountering the group's first unscheduled member
+	vmGroups           map[string]v1.VMGroup
+	groupMembers       GroupMembers
+	gangStatusMessages map[string]string
+
+	// Workers on which the VMs were preempted during this pass,
+	// we only do that once per worker and re-evaluate the situation
+	// in the next pass when the victims are de-scheduled
+	preemptedWorkers mapset.Set[string]
+
+	// Keys of the VMs that were placed during this pass
+	placed mapset.Set[string]
+
+	// Explanations of why the VMs remain pending
+	explanations map[string]v1.SchedulingExplanation
+}
+
+func newPlanner(
+	vms []v1.VM,
+	workers []v1.Worker,
+	vmGroups []v1.VMGroup,
+	quotas []v1.Quota,
+	workerInfos WorkerInfos,
+	framework *Framework,
+	committer committer,
+	key func(vm v1.VM) string,
+) *planner {
+	workerVMs := map[string][]v1.VM{}
+
+	for _, vm := range vms {
+		if vm.IsScheduled() {
+			workerVMs[vm.Worker] = append(workerVMs[vm.Worker], vm)
+		}
+	}
+
+	return &planner{
+		workers:     workers,
+		framework:   framework,
+		committer:   committer,
+		key:         key,
+		workerInfos: workerInfos,
+		// Quota usage only increases during the pass
+		// because the CPU and memory defaults are assigned
+		quotaUsage: NewQuotaUsage(quotas, vms),
+		workerVMs:  workerVMs,
+		vmGroups: lo.KeyBy(vmGroups, func(vmGroup v1.VMGroup) string {
+			return v1.NamespacedName(vmGroup.Namespace, vmGroup.Name)
+		}),
+		groupMembers:       NewGroupMembers(vms),
+		gangStatusMessages: map[string]string{},
+		preemptedWorkers:   mapset.NewSet[string](),
+		placed:             mapset.NewSet[string](),
+		explanations:       map[string]v1.SchedulingExplanation{},
+	}
+}
+
+// plan places the unscheduled VMs (as sorted by ProcessVMs() or FairShareOrder())
+// in order, explaining why the VMs that couldn't be placed remain pending.
+func (planner *planner) plan(unscheduledVMs []v1.VM) error {
+NextVM:
+	for _, unscheduledVM := range unscheduledVMs {
+		// We don't support re-scheduling of VMs in terminal state
+		// and of the stopped/suspended VMs at the moment
+		if planner.placed.Contains(planner.key(unscheduledVM)) || unscheduledVM.TerminalState() ||
+			unscheduledVM.PowerState.TerminalState() {
+			continue
+		}
+
+		// Gang-schedule the group's members until the group has enough scheduled
+		// members, after which the remaining members are scheduled individually
+		if unscheduledVM.Group != "" {
+			handled, err := planner.planGroupMember(unscheduledVM, unscheduledVMs)
+			if err != nil {
+				return err
+			}
+
+			if handled {
+				continue
+			}
+		}
+
+		ranking, err := planner.framework.Rank(unscheduledVM, planner.workers, planner.workerInfos,
+			planner.workerVMs)
+		if err != nil {
+			planner.explain(unscheduledVM, explanationWithMessage(
+				fmt.Sprintf("failed to evaluate the affinity: %v", err)))
+
+			continue
+		}
+
+		rejections := ranking.Rejections
+
+		// Iterate through sorted workers and find a worker that can run this VM
+		for _, worker := range ranking.Feasible {
+			if planner.quotaUsage.Admit(unscheduledVM, worker) != nil {
+				rejections = append(rejections, v1.WorkerRejection{
+					Worker: worker.Name,
+					Reason: reasonQuotaExceeded,
+				})
+
+				continue
+			}
+
+			vm := unscheduledVM
+
+			if err := planner.committer.assign(&vm, worker); err != nil {
+				if errors.Is(err, ErrVMSchedulingSkipped) {
+					continue NextVM
+				}
+
+				if errors.Is(err, ErrWorkerSchedulingSkipped) {
+					continue
+				}
+
+				return err
+			}
+
+			planner.placeVM(vm, worker)
+
+			continue NextVM
+		}
+
+		// No worker can run this VM, explain why
+		planner.explain(unscheduledVM, NewSchedulingExplanation(len(planner.workers), rejections))
+
+		// Try evicting the lower-priority VMs
+		if unscheduledVM.PreemptionPolicy == v1.PreemptionPolicyPreemptLowerPriority {
+			if err := planner.planPreemption(unscheduledVM, ranking); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// planGroupMember gang-schedules the VM's group, if it doesn't have enough scheduled
+// members yet, returning true if the VM was handled as a part of the group.
+func (planner *planner) planGroupMember(unscheduledVM v1.VM, unscheduledVMs []v1.VM) (bool, error) {
+	groupKey := v1.NamespacedName(unscheduledVM.Namespace, unscheduledVM.Group)
+
+	group, ok := planner.vmGroups[groupKey]
+	if !ok {
+		// The group doesn't exist (anymore), so the VM stays pending
+		planner.explain(unscheduledVM, explanationWithMessage(
+			fmt.Sprintf("VM group %q does not exist", unscheduledVM.Group)))
+
+		return true, nil
+	}
+
+	if planner.groupMembers[groupKey].Scheduled >= group.MinMember {
+		return false, nil
+	}
+
+	statusMessage, handled := planner.gangStatusMessages[groupKey]
+	if !handled {
+		var assignments []gangAssignment
+
+		assignments, statusMessage = planGang(group, unscheduledVMs, planner.groupMembers[groupKey],
+			planner.workers, planner.workerInfos, planner.workerVMs, planner.quotaUsage, planner.framework)
+		planner.gangStatusMessages[groupKey] = statusMessage
+
+		if statusMessage == "" {
+			assignments, err := planner.committer.assignGang(group, assignments)
+			if err != nil {
+				if errors.Is(err, ErrVMSchedulingSkipped) || errors.Is(err, ErrWorkerSchedulingSkipped) {
+					return true, nil
+				}
+
+				return true, err
+			}
+
+			for _, assignment := range assignments {
+				planner.placeVM(assignment.vm, assignment.worker)
+			}
+		}
+	}
+
+	if statusMessage != "" {
+		planner.explain(unscheduledVM, explanationWithMessage(gangExplanationMessage(group, statusMessage)))
+	}
+
+	return true, nil
+}
+
+// planPreemption evicts the lower-priority VMs on the best worker, if any, to make room for the VM.
+func (planner *planner) planPreemption(unscheduledVM v1.VM, ranking Ranking) error {
+	var candidates []preemptionCandidate
+
+	for _, worker := range ranking.Candidates {
+		if planner.preemptedWorkers.Contains(worker.Name) ||
+			!ranking.Placement.Feasible(worker, ranking.State) ||
+			planner.quotaUsage.Admit(unscheduledVM, worker) != nil {
+			continue
+		}
+
+		victims, ok := SelectVictims(unscheduledVM, worker, planner.workerVMs[worker.Name])
+		if !ok {
+			continue
+		}
+
+		candidates = append(candidates, preemptionCandidate{
+			worker:  worker,
+			victims: victims,
+		})
+	}
+
+	candidate, ok := bestPreemptionCandidate(candidates)
+	if !ok || len(candidate.victims) == 0 {
+		// Either the preemption won't help or the resources
+		// are about to be released, so there's nothing to do
+		return nil
+	}
+
+	if err := planner.committer.preempt(unscheduledVM, candidate); err != nil {
+		if errors.Is(err, ErrVMSchedulingSkipped) || errors.Is(err, ErrWorkerSchedulingSkipped) {
+			return nil
+		}
+
+		return err
+	}
+
+	planner.preemptedWorkers.Add(candidate.worker.Name)
+
+	return nil
+}
+
+// placeVM updates the lagging resource usage after the VM was assigned to the worker.
+func (planner *planner) placeVM(vm v1.VM, worker v1.Worker) {
+	planner.workerInfos.AddVM(worker.Name, vm.Resources)
+	planner.workerVMs[worker.Name] = append(planner.workerVMs[worker.Name], vm)
+	planner.quotaUsage.Assign(vm, worker)
+	if vm.Group != "" {
+		planner.groupMembers.AddScheduled(v1.NamespacedName(vm.Namespace, vm.Group))
+	}
+	planner.placed.Add(planner.key(vm))
+}
+
+func (planner *planner) explain(vm v1.VM, explanation v1.SchedulingExplanation) {
+	explanation.EvaluatedAt = time.Now()
+
+	planner.explanations[planner.key(vm)] = explanation
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+var scorePlugins = map[v1.SchedulerScorePlugin]ScorePlugin{
+	v1.SchedulerScorePluginBinPacking:    binPackingScore{},
+	v1.SchedulerScorePluginSpread:        spreadScore{},
+	v1.SchedulerScorePluginAffinity:      affinityScore{},
+	v1.SchedulerScorePluginImageLocality: imageLocalityScore{},
+}
+
+// eligibilityFilters returns the filters that only depend
+// on the VM and the worker, and nothing else.
+//
+// Note that the VMs are never scheduled on the workers with the untolerated
+// taints that have TaintEffectNoExecute, since they would be evicted anyway.
+func eligibilityFilters(workerOfflineTimeout time.Duration) []FilterPlugin {
+	return []FilterPlugin{
+		workerStateFilter{workerOfflineTimeout: workerOfflineTimeout},
+		archRuntimeFilter{},
+		labelsFilter{},
+		taintsFilter{},
+	}
+}
+
+type workerStateFilter struct {
+	workerOfflineTimeout time.Duration
+}
+
+func (filter workerStateFilter) Filter(_ v1.VM, worker v1.Worker, _ *CycleState) string {
+	if worker.Offline(filter.workerOfflineTimeout) {
+		return reasonOffline
+	}
+
+	if worker.SchedulingPaused {
+		return reasonSchedulingPaused
+	}
+
+	return ""
+}
+
+type archRuntimeFilter struct{}
+
+func (archRuntimeFilter) Filter(vm v1.VM, worker v1.Worker, _ *CycleState) string {
+	if !compatibleArchAndRuntime(vm, worker) {
+		return reasonArchRuntimeMismatch
+	}
+
+	return ""
+}
+
+type labelsFilter struct{}
+
+func (labelsFilter) Filter(vm v1.VM, worker v1.Worker, _ *CycleState) string {
+	if !worker.Labels.Contains(vm.Labels) {
+		return reasonLabelMismatch
+	}
+
+	return ""
+}
+
+type taintsFilter struct{}
+
+func (taintsFilter) Filter(vm v1.VM, worker v1.Worker, _ *CycleState) string {
+	if len(v1.UntoleratedTaints(worker.Taints, vm.Tolerations, v1.TaintEffectNoSchedule)) != 0 ||
+		len(v1.UntoleratedTaints(worker.Taints, vm.Tolerations, v1.TaintEffectNoExecute)) != 0 {
+		return reasonUntoleratedTaint
+	}
+
+	return ""
+}
+
+type workerAffinityFilter struct{}
+
+func (workerAffinityFilter) Filter(_ v1.VM, worker v1.Worker, state *CycleState) string {
+	if !state.Placement.WorkerMatches(worker) {
+		return reasonWorkerAffinity
+	}
+
+	return ""
+}
+
+type resourceFitFilter struct{}
+
+func (resourceFitFilter) Filter(vm v1.VM, worker v1.Worker, state *CycleState) string {
+	resourcesRemaining := worker.Resources.Subtracted(state.WorkerInfos.Get(worker.Name).ResourcesUsed)
+
+	if !resourcesRemaining.CanFit(vm.Resources) {
+		return reasonInsufficientResource(insufficientResource(resourcesRemaining, vm.Resources))
+	}
+
+	return ""
+}
+
+type placementFilter struct{}
+
+func (placementFilter) Filter(_ v1.VM, worker v1.Worker, state *CycleState) string {
+	if !state.Placement.Feasible(worker, state.ClusterState) {
+		return reasonPlacementConstraints
+	}
+
+	return ""
+}
+
+type binPackingScore struct{}
+
+func (binPackingScore) Score(_ v1.VM, worker v1.Worker, state *CycleState) int64 {
+	return int64(state.WorkerInfos.Get(worker.Name).NumRunningVMs)
+}
+
+type spreadScore struct{}
+
+func (spreadScore) Score(_ v1.VM, worker v1.Worker, state *CycleState) int64 {
+	return -int64(state.WorkerInfos.Get(worker.Name).NumRunningVMs)
+}
+
+type affinityScore struct{}
+
+func (affinityScore) Score(_ v1.VM, worker v1.Worker, state *CycleState) int64 {
+	return state.Placement.Score(worker, state.ClusterState)
+}
+
+type imageLocalityScore struct{}
+
+func (imageLocalityScore) Score(vm v1.VM, worker v1.Worker, _ *CycleState) int64 {
+	if slices.Contains(worker.CachedImages, vm.Image) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,301 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/worker/ondiskname"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// poolLoopIteration keeps each VM pool at its desired number of warm VMs
+// by replacing the claimed and failed VMs, and returns the number of VMs
+// created.
+func (scheduler *Scheduler) poolLoopIteration() (int, error) {
+	// Get a lagging view of VM pools
+	var vmPools []v1.VMPool
+
+	if err := scheduler.store.View(func(txn storepkg.Transaction) error {
+		var err error
+
+		vmPools, err = txn.ListVMPools()
+
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	affectedWorkers := mapset.NewSet[string]()
+	var numCreated int
+
+	// Process each VM pool in an individual transaction,
+	// re-checking that the VM pool still exists
+	for _, vmPool := range vmPools {
+		var numCreatedPool int
+		var deletedVMs []v1.VM
+
+		if err := scheduler.store.Update(func(txn storepkg.Transaction) error {
+			// Reset the state in case the transaction is retried
+			numCreatedPool = 0
+			deletedVMs = nil
+
+			currentVMPool, err := txn.GetVMPool(vmPool.Namespace, vmPool.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					// VM pool ceased to exist, nothing to do
+					return nil
+				}
+
+				return err
+			}
+
+			numCreatedPool, deletedVMs, err = reconcilePoolTxn(txn, *currentVMPool, time.Now())
+
+			return err
+		}); err != nil {
+			return numCreated, err
+		}
+
+		numCreated += numCreatedPool
+
+		for _, deletedVM := range deletedVMs {
+			lifecycle.Report(&deletedVM, "VM deleted from the pool", scheduler.logger)
+
+			if deletedVM.Worker != "" {
+				affectedWorkers.Add(deletedVM.Worker)
+			}
+		}
+	}
+
+	scheduler.syncWorkers(affectedWorkers)
+
+	return numCreated, nil
+}
+
+// reconcilePoolTxn creates and deletes the pool's VMs to match the pool's desired
+// number of warm VMs, and returns the number of VMs created along with the VMs deleted.
+func reconcilePoolTxn(txn storepkg.Transaction, vmPool v1.VMPool, now time.Time) (int, []v1.VM, error) {
+	vms, err := txn.ListVMs()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	quotas, err := txn.ListQuotas()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var deletedVMs []v1.VM
+
+	deleteVM := func(vm v1.VM) error {
+		if err := deleteVMTxn(txn, vm); err != nil {
+			return err
+		}
+
+		deletedVMs = append(deletedVMs, vm)
+
+		return nil
+	}
+
+	isRunning := func(vm v1.VM) bool {
+		return vm.Status == v1.VMStatusRunning
+	}
+
+	// Get rid of the pool's VMs that can't be claimed anymore
+	var activeVMs []v1.VM
+
+	for _, vm := range vms {
+		if vm.Namespace != vmPool.Namespace || vm.Pool != vmPool.Name {
+			continue
+		}
+
+		if vm.TerminalState() || vm.PowerState.TerminalState() {
+			if err := deleteVM(vm); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		}
+
+		activeVMs = append(activeVMs, vm)
+	}
+
+	// Scale down by deleting the least useful VMs first, that is the VMs
+	// that are not running yet, and then the most recently created VMs
+	if uint64(len(activeVMs)) > vmPool.Replicas {
+		slices.SortStableFunc(activeVMs, func(a, b v1.VM) int {
+			if isRunning(a) != isRunning(b) {
+				if isRunning(a) {
+					return -1
+				}
+
+				return 1
+			}
+
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		for _, vm := range activeVMs[vmPool.Replicas:] {
+			if err := deleteVM(vm); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		activeVMs = activeVMs[:vmPool.Replicas]
+	}
+
+	// The deleted VMs no longer count towards the quotas
+	vms = lo.Reject(vms, func(vm v1.VM, _ int) bool {
+		return lo.ContainsBy(deletedVMs, func(deletedVM v1.VM) bool {
+			return deletedVM.UID == vm.UID
+		})
+	})
+
+	// Scale up, as long as the quotas permit
+	var numCreated int
+	var statusMessage string
+
+	for uint64(len(activeVMs)) < vmPool.Replicas {
+		vm, err := newPoolVMTxn(txn, vmPool, now)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if err := admitQuotas(quotas, vms, vm); err != nil {
+			statusMessage = fmt.Sprintf("cannot create more VMs: %v", err)
+
+			break
+		}
+
+		if err := txn.SetVM(vm); err != nil {
+			return 0, nil, err
+		}
+
+		vms = append(vms, vm)
+		activeVMs = append(activeVMs, vm)
+		numCreated++
+	}
+
+	// Update the pool's status
+	readyReplicas := uint64(lo.CountBy(activeVMs, isRunning))
+
+	if vmPool.ReadyReplicas != readyReplicas || vmPool.StatusMessage != statusMessage {
+		vmPool.ReadyReplicas = readyReplicas
+		vmPool.StatusMessage = statusMessage
+
+		if err := txn.SetVMPool(vmPool); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return numCreated, deletedVMs, nil
+}
+
+func admitQuotas(quotas []v1.Quota, vms []v1.VM, vm v1.VM) error {
+	for _, quota := range quotas {
+		if !quota.AppliesTo(vm) {
+			continue
+		}
+
+		if err := quota.Admit(quota.Usage(vms), vm.QuotaUsage()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteVMTxn(txn storepkg.Transaction, vm v1.VM) error {
+	if err := txn.DeleteVM(vm.Namespace, vm.Name); err != nil {
+		return err
+	}
+
+	return txn.DeleteEvents("vms", vm.UID)
+}
+
+// newPoolVMTxn instantiates a new VM from the pool's template.
+func newPoolVMTxn(txn storepkg.Transaction, vmPool v1.VMPool, now time.Time) (v1.VM, error) {
+	vm, err := newTemplatedVMTxn(txn, vmPool.Template, vmPool.Name, vmPool.Namespace, now)
+	if err != nil {
+		return v1.VM{}, err
+	}
+
+	vm.Pool = vmPool.Name
+
+	return vm, nil
+}
+
+// newTemplatedVMTxn instantiates a new VM from the template using
+// a name that starts with the prefix and is not yet taken by any
+// other VM.
+func newTemplatedVMTxn(
+	txn storepkg.Transaction,
+	template v1.VM,
+	prefix string,
+	namespace string,
+	now time.Time,
+) (v1.VM, error) {
+	for {
+		uid := uuid.New().String()
+		vm := newTemplatedVM(template, fmt.Sprintf("%s-%s", prefix, uid[:8]), namespace, uid, now)
+
+		_, err := txn.GetVM(vm.Namespace, vm.Name)
+		if errors.Is(err, storepkg.ErrNotFound) {
+			return vm, nil
+		}
+		if err != nil {
+			return v1.VM{}, err
+		}
+	}
+}
+
+func newTemplatedVM(template v1.VM, name string, namespace string, uid string, now time.Time) v1.VM {
+	vm := template
+
+	vm.Meta = v1.Meta{
+		Name:        name,
+		Namespace:   namespace,
+		CreatedAt:   now,
+		Labels:      template.Meta.Labels,
+		Annotations: template.Annotations,
+	}
+	vm.Pool = ""
+	vm.VMSet = ""
+	vm.VMSetGeneration = 0
+	vm.Group = ""
+	vm.UID = uid
+	vm.Status = v1.VMStatusPending
+	vm.StatusMessage = ""
+	vm.Worker = ""
+	vm.AssignedCPU = 0
+	vm.AssignedMemory = 0
+	vm.RestartedAt = time.Time{}
+	vm.RestartCount = 0
+	vm.NextRestartAt = time.Time{}
+	vm.ConsecutiveFailures = 0
+	vm.ImageFQN = ""
+	vm.ScheduledAt = time.Time{}
+	vm.StartedAt = time.Time{}
+	vm.FinishedAt = time.Time{}
+	vm.Generation = 0
+	vm.VMState = v1.VMState{}
+	vm.PowerState = v1.PowerStateRunning
+	vm.LocalName = ondiskname.New(vm.Name, vm.UID, vm.RestartCount).String()
+	//nolint:staticcheck // yes, this is deprecated, but we still maintain it for backward compatibility
+	vm.TartName = vm.LocalName
+	vm.Conditions = []v1.Condition{
+		{
+			Type:  v1.ConditionTypeScheduled,
+			State: v1.ConditionStateFalse,
+		},
+	}
+
+	return vm
+}
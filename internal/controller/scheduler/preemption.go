@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+type preemptionCandidate struct {
+	worker  v1.Worker
+	victims []v1.VM
+}
+
+// SelectVictims returns the lower-priority VMs running on the worker that need to be evicted
+// for the preemptor VM to fit, or false if the preemptor won't fit even after evicting them all.
+//
+// Only the VMs in the preemptor's namespace can be picked as victims,
+// so that the VMs of one tenant never evict the VMs of the other.
+//
+// The workerVMs are the VMs scheduled on the worker. VMs that are already in a terminal state
+// are about to release their resources, so they're never picked as victims and the resources
+// they use are considered free, in which case no victims might be needed at all.
+func SelectVictims(preemptor v1.VM, worker v1.Worker, workerVMs []v1.VM) ([]v1.VM, bool) {
+	resourcesRemaining := worker.Resources.Copy()
+
+	var candidates []v1.VM
+
+	for _, vm := range workerVMs {
+		if vm.TerminalState() {
+			continue
+		}
+
+		resourcesRemaining.Subtract(vm.Resources)
+
+		if vm.Priority < preemptor.Priority && vm.Namespace == preemptor.Namespace {
+			candidates = append(candidates, vm)
+		}
+	}
+
+	// Prefer evicting the lowest-priority VMs first,
+	// and among them, the ones that were created last
+	slices.SortStableFunc(candidates, func(a, b v1.VM) int {
+		if result := cmp.Compare(a.Priority, b.Priority); result != 0 {
+			return result
+		}
+
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	var victims []v1.VM
+
+	for _, candidate := range candidates {
+		if canFit(resourcesRemaining, preemptor.Resources) {
+			break
+		}
+
+		resourcesRemaining.Add(candidate.Resources)
+		victims = append(victims, candidate)
+	}
+
+	if !canFit(resourcesRemaining, preemptor.Resources) {
+		return nil, false
+	}
+
+	// Reprieve the victims that turned out to be unnecessary,
+	// starting with the ones having the highest priority
+	var result []v1.VM
+
+	for i := len(victims) - 1; i >= 0; i-- {
+		if canFit(resourcesRemaining.Subtracted(victims[i].Resources), preemptor.Resources) {
+			resourcesRemaining.Subtract(victims[i].Resources)
+
+			continue
+		}
+
+		result = append(result, victims[i])
+	}
+
+	slices.Reverse(result)
+
+	return result, true
+}
+
+// canFit is similar to v1.Resources.CanFit(), but accounts for the
+// negative remaining resources, which are possible when the worker's
+// resources were decreased after the VMs were scheduled on it.
+func canFit(resourcesRemaining v1.Resources, resources v1.Resources) bool {
+	for key, value := range resources {
+		if int64(resourcesRemaining[key]) < 0 || value > resourcesRemaining[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bestPreemptionCandidate picks a worker whose victims have the lowest
+// maximum priority, preferring the workers with fewer victims on a tie.
+func bestPreemptionCandidate(candidates []preemptionCandidate) (preemptionCandidate, bool) {
+	if len(candidates) == 0 {
+		return preemptionCandidate{}, false
+	}
+
+	maxPriority := func(victims []v1.VM) int64 {
+		var result int64
+
+		for i, victim := range victims {
+			if i == 0 || victim.Priority > result {
+				result = victim.Priority
+			}
+		}
+
+		return result
+	}
+
+	return slices.MinFunc(candidates, func(a, b preemptionCandidate) int {
+		// No victims means that the resources are about to be released
+		// by the VMs in a terminal state, so nothing needs to be evicted
+		if len(a.victims) == 0 || len(b.victims) == 0 {
+			return cmp.Compare(len(a.victims), len(b.victims))
+		}
+
+		if result := cmp.Compare(maxPriority(a.victims), maxPriority(b.victims)); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(len(a.victims), len(b.victims))
+	}), true
+}
+
+// preempt evicts the victims on the worker in favor of the preemptor by failing them,
+// the preemptor itself will be scheduled once the victims are de-scheduled by the
+// health checking loop iteration.
+func (scheduler *Scheduler) preempt(preemptor v1.VM, candidate preemptionCandidate) error {
+	return scheduler.store.Update(func(txn storepkg.Transaction) error {
+		currentPreemptor, err := txn.GetVM(preemptor.Namespace, preemptor.Name)
+		if err != nil {
+			if errors.Is(err, storepkg.ErrNotFound) {
+				return ErrVMSchedulingSkipped
+			}
+
+			return err
+		}
+
+		if currentPreemptor.UID != preemptor.UID || currentPreemptor.IsScheduled() {
+			return ErrVMSchedulingSkipped
+		}
+
+		statusMessage := fmt.Sprintf("VM was preempted by a higher-priority VM %s (priority %d)",
+			preemptor.Name, preemptor.Priority)
+
+		for _, victim := range candidate.victims {
+			currentVictim, err := txn.GetVM(victim.Namespace, victim.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					return ErrWorkerSchedulingSkipped
+				}
+
+				return err
+			}
+
+			if currentVictim.UID != victim.UID || currentVictim.Worker != candidate.worker.Name ||
+				!currentVictim.IsScheduled() || currentVictim.TerminalState() {
+				// The victim had changed, so we'll re-evaluate
+				// the preemption in the next scheduling loop iteration
+				return ErrWorkerSchedulingSkipped
+			}
+
+			currentVictim.Status = v1.VMStatusFailed
+			currentVictim.StatusMessage = statusMessage
+
+			if err := txn.SetVM(*currentVictim); err != nil {
+				return err
+			}
+
+			if err := txn.AppendEvents([]v1.Event{
+				{
+					Kind:      v1.EventKindPreempted,
+					Timestamp: time.Now().Unix(),
+					Payload:   statusMessage,
+				},
+			}, "vms", currentVictim.UID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
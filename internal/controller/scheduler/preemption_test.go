@@ -0,0 +1,96 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessVMsOrdersByPriority(t *testing.T) {
+	now := time.Now()
+
+	unscheduledVMs, _ := scheduler.ProcessVMs([]v1.VM{
+		{Meta: v1.Meta{Name: "old-low", CreatedAt: now.Add(-time.Hour)}},
+		{Meta: v1.Meta{Name: "new-high", CreatedAt: now}, Priority: 100},
+		{Meta: v1.Meta{Name: "old-high", CreatedAt: now.Add(-time.Minute)}, Priority: 100},
+		{Meta: v1.Meta{Name: "negative", CreatedAt: now.Add(-2 * time.Hour)}, Priority: -1},
+	})
+
+	require.Equal(t, []string{"old-high", "new-high", "old-low", "negative"},
+		lo.Map(unscheduledVMs, func(vm v1.VM, _ int) string {
+			return vm.Name
+		}))
+}
+
+func TestSelectVictims(t *testing.T) {
+	now := time.Now()
+
+	worker := v1.Worker{
+		Resources: v1.Resources{
+			v1.ResourceTartVMs: 3,
+		},
+	}
+
+	newVM := func(name string, priority int64, createdAt time.Time, tartVMs uint64) v1.VM {
+		return v1.VM{
+			Meta: v1.Meta{
+				Name:      name,
+				CreatedAt: createdAt,
+			},
+			Priority: priority,
+			Resources: v1.Resources{
+				v1.ResourceTartVMs: tartVMs,
+			},
+		}
+	}
+
+	victimNames := func(victims []v1.VM) []string {
+		return lo.Map(victims, func(vm v1.VM, _ int) string {
+			return vm.Name
+		})
+	}
+
+	// The lowest-priority and the newest VM is evicted first
+	victims, ok := scheduler.SelectVictims(newVM("preemptor", 10, now, 1), worker, []v1.VM{
+		newVM("low-old", 0, now.Add(-time.Hour), 1),
+		newVM("low-new", 0, now.Add(-time.Minute), 1),
+		newVM("medium", 5, now.Add(-2*time.Hour), 1),
+	})
+	require.True(t, ok)
+	require.Equal(t, []string{"low-new"}, victimNames(victims))
+
+	// VMs with the same or higher priority are never evicted
+	_, ok = scheduler.SelectVictims(newVM("preemptor", 10, now, 1), worker, []v1.VM{
+		newVM("same", 10, now, 1),
+		newVM("higher-1", 20, now, 1),
+		newVM("higher-2", 20, now, 1),
+	})
+	require.False(t, ok)
+
+	// Victims that turn out to be unnecessary are reprieved
+	victims, ok = scheduler.SelectVictims(newVM("preemptor", 10, now, 2), worker, []v1.VM{
+		newVM("small", 0, now, 1),
+		newVM("big", 1, now, 2),
+	})
+	require.True(t, ok)
+	require.Equal(t, []string{"big"}, victimNames(victims))
+
+	// VMs in the other namespaces are never evicted
+	otherNamespaceVM := newVM("other-namespace", 0, now, 3)
+	otherNamespaceVM.Namespace = "other"
+
+	_, ok = scheduler.SelectVictims(newVM("preemptor", 10, now, 1), worker, []v1.VM{otherNamespaceVM})
+	require.False(t, ok)
+
+	// No victims are needed when the failed VMs are about to release their resources
+	failedVM := newVM("failed", 0, now, 3)
+	failedVM.Status = v1.VMStatusFailed
+
+	victims, ok = scheduler.SelectVictims(newVM("preemptor", 10, now, 1), worker, []v1.VM{failedVM})
+	require.True(t, ok)
+	require.Empty(t, victims)
+}
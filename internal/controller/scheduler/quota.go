@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// QuotaUsage keeps track of the resources used in each quota
+// as the VMs are being assigned to the workers.
+type QuotaUsage struct {
+	quotas []v1.Quota
+	used   []v1.Resources
+}
+
+func NewQuotaUsage(quotas []v1.Quota, vms []v1.VM) *QuotaUsage {
+	quotaUsage := &QuotaUsage{
+		quotas: quotas,
+	}
+
+	for _, quota := range quotas {
+		quotaUsage.used = append(quotaUsage.used, quota.Usage(vms))
+	}
+
+	return quotaUsage
+}
+
+func (quotaUsage *QuotaUsage) Clone() *QuotaUsage {
+	result := &QuotaUsage{
+		quotas: quotaUsage.quotas,
+	}
+
+	for _, used := range quotaUsage.used {
+		result.used = append(result.used, used.Copy())
+	}
+
+	return result
+}
+
+// Admit returns an error if assigning the VM to the worker would exceed any of the quotas.
+//
+// The unscheduled VM is already accounted for in the quotas using its explicitly
+// requested CPU and memory, so only the worker's defaults need to be checked.
+func (quotaUsage *QuotaUsage) Admit(vm v1.VM, worker v1.Worker) error {
+	requested := assignmentUsage(vm, worker)
+
+	for i, quota := range quotaUsage.quotas {
+		if !quota.AppliesTo(vm) {
+			continue
+		}
+
+		if err := quota.Admit(quotaUsage.used[i], requested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Assign accounts for the VM being assigned to the worker.
+func (quotaUsage *QuotaUsage) Assign(vm v1.VM, worker v1.Worker) {
+	requested := assignmentUsage(vm, worker)
+
+	for i, quota := range quotaUsage.quotas {
+		if !quota.AppliesTo(vm) {
+			continue
+		}
+
+		for key := range quota.Hard {
+			quotaUsage.used[i][key] += requested[key]
+		}
+	}
+}
+
+func assignmentUsage(vm v1.VM, worker v1.Worker) v1.Resources {
+	return v1.Resources{
+		v1.QuotaResourceCPU:    assignedCPU(vm, worker) - vm.CPU,
+		v1.QuotaResourceMemory: assignedMemory(vm, worker) - vm.Memory,
+	}
+}
@@ -0,0 +1,43 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaUsageAccountsForDefaults(t *testing.T) {
+	quotas := []v1.Quota{
+		{
+			Scope: v1.QuotaScope{Namespace: "team-a"},
+			Hard:  v1.Resources{v1.QuotaResourceCPU: 6},
+		},
+	}
+
+	implicitCPU := v1.VM{Meta: v1.Meta{Name: "implicit", Namespace: "team-a"}}
+	explicitCPU := v1.VM{Meta: v1.Meta{Name: "explicit", Namespace: "team-a"}, CPU: 2}
+	otherNamespace := v1.VM{Meta: v1.Meta{Name: "other", Namespace: "team-b"}}
+
+	quotaUsage := scheduler.NewQuotaUsage(quotas, []v1.VM{implicitCPU, explicitCPU, otherNamespace})
+
+	smallWorker := v1.Worker{DefaultCPU: 4}
+	largeWorker := v1.Worker{DefaultCPU: 8}
+
+	// The explicitly requested CPU is already accounted for
+	require.NoError(t, quotaUsage.Admit(explicitCPU, largeWorker))
+
+	// The worker's defaults are checked against the quota
+	require.Error(t, quotaUsage.Admit(implicitCPU, largeWorker))
+	require.NoError(t, quotaUsage.Admit(implicitCPU, smallWorker))
+
+	// The simulated assignments don't affect the original usage
+	simulatedQuotaUsage := quotaUsage.Clone()
+	simulatedQuotaUsage.Assign(implicitCPU, smallWorker)
+	require.Error(t, simulatedQuotaUsage.Admit(implicitCPU, smallWorker))
+	require.NoError(t, quotaUsage.Admit(implicitCPU, smallWorker))
+
+	// VMs that the quota doesn't apply to are not limited
+	require.NoError(t, quotaUsage.Admit(otherNamespace, largeWorker))
+}
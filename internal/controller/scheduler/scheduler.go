@@ -4,10 +4,12 @@ import (
 	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"slices"
-	"sort"
+	"sync"
 	"time"
 
+	"github.com/cirruslabs/orchard/internal/controller/leaderelection"
 	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
 	"github.com/cirruslabs/orchard/internal/controller/notifier"
 	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
@@ -23,8 +25,6 @@ import (
 
 const (
 	schedulerInterval = 5 * time.Second
-
-	schedulerVMRestartDelay = 15 * time.Second
 )
 
 var (
@@ -35,6 +35,7 @@ var (
 type Scheduler struct {
 	store                storepkg.Store
 	notifier             *notifier.Notifier
+	leaderElection       leaderelection.LeaderElection
 	workerOfflineTimeout time.Duration
 	logger               *zap.SugaredLogger
 	schedulingRequested  chan bool
@@ -42,17 +43,27 @@ type Scheduler struct {
 	schedulingTimeHistogram metric.Float64Histogram
 	workerStatusGauge       metric.Int64ObservableGauge
 	vmStatusGauge           metric.Int64ObservableGauge
+	queuePendingVMsGauge    metric.Int64ObservableGauge
+
+	explanations     map[string]v1.SchedulingExplanation
+	explanationsLock sync.Mutex
+
+	fairShareUsage          FairShareUsage
+	fairShareUsageUpdatedAt time.Time
+	fairShareUsageLock      sync.Mutex
 }
 
 func NewScheduler(
 	store storepkg.Store,
 	notifier *notifier.Notifier,
+	leaderElection leaderelection.LeaderElection,
 	workerOfflineTimeout time.Duration,
 	logger *zap.SugaredLogger,
 ) (*Scheduler, error) {
 	scheduler := &Scheduler{
 		store:                store,
 		notifier:             notifier,
+		leaderElection:       leaderElection,
 		workerOfflineTimeout: workerOfflineTimeout,
 		logger:               logger,
 		schedulingRequested:  make(chan bool, 1),
@@ -77,6 +88,14 @@ func NewScheduler(
 		return nil, err
 	}
 
+	scheduler.queuePendingVMsGauge, err = opentelemetry.DefaultMeter.Int64ObservableGauge(
+		"org.cirruslabs.orchard.controller.scheduler.queue_pending_vms",
+		metric.WithInt64Callback(scheduler.observeQueuePendingVMs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	scheduler.schedulingTimeHistogram, err = opentelemetry.DefaultMeter.
 		Float64Histogram("org.cirruslabs.orchard.controller.scheduling_time")
 	if err != nil {
@@ -94,6 +113,12 @@ func (scheduler *Scheduler) Run() {
 		case <-time.After(schedulerInterval):
 		}
 
+		// Only the leader is allowed to health-check and schedule VMs,
+		// followers simply serve the API until they become a leader
+		if !scheduler.leaderElection.Status().IsLeader() {
+			continue
+		}
+
 		healthCheckingLoopIterationStart := time.Now()
 		numVMsHealth, err := scheduler.healthCheckingLoopIteration()
 		healthCheckingLoopIterationEnd := time.Now()
@@ -101,6 +126,27 @@ func (scheduler *Scheduler) Run() {
 			scheduler.logger.Errorf("Failed to health-check VMs: %v", err)
 		}
 
+		numVMsExpired, err := scheduler.garbageCollectionLoopIteration()
+		if err != nil {
+			scheduler.logger.Errorf("Failed to garbage-collect VMs: %v", err)
+		} else if numVMsExpired != 0 {
+			scheduler.logger.Debugf("Garbage collected %d expired VMs", numVMsExpired)
+		}
+
+		numVMsPooled, err := scheduler.poolLoopIteration()
+		if err != nil {
+			scheduler.logger.Errorf("Failed to replenish VM pools: %v", err)
+		} else if numVMsPooled != 0 {
+			scheduler.logger.Debugf("Created %d VMs to replenish VM pools", numVMsPooled)
+		}
+
+		numVMsSet, err := scheduler.vmSetLoopIteration()
+		if err != nil {
+			scheduler.logger.Errorf("Failed to reconcile VM sets: %v", err)
+		} else if numVMsSet != 0 {
+			scheduler.logger.Debugf("Created %d VMs to reconcile VM sets", numVMsSet)
+		}
+
 		schedulingLoopIterationStart := time.Now()
 		numWorkersScheduling, numVMsScheduling, err := scheduler.schedulingLoopIteration()
 		schedulingLoopIterationEnd := time.Now()
@@ -163,6 +209,26 @@ func (scheduler *Scheduler) observeVMStatus(_ context.Context, observer metric.I
 	})
 }
 
+func (scheduler *Scheduler) observeQueuePendingVMs(_ context.Context, observer metric.Int64Observer) error {
+	return scheduler.store.View(func(txn storepkg.Transaction) error {
+		clusterSettings, err := txn.GetClusterSettings()
+		if err != nil {
+			return err
+		}
+
+		vms, err := txn.ListVMs()
+		if err != nil {
+			return err
+		}
+
+		for queue, count := range PendingVMsPerQueue(vms, clusterSettings.FairShare) {
+			observer.Observe(count, metric.WithAttributes(attribute.String("queue", queue)))
+		}
+
+		return nil
+	})
+}
+
 func (scheduler *Scheduler) RequestScheduling() {
 	select {
 	case scheduler.schedulingRequested <- true:
@@ -172,23 +238,29 @@ func (scheduler *Scheduler) RequestScheduling() {
 	}
 }
 
-//nolint:gocognit,gocyclo // this logic could be seen as even more complex if split into multiple functions
 func (scheduler *Scheduler) schedulingLoopIteration() (int, int, error) {
-	affectedWorkers := mapset.NewSet[string]()
-
 	// Scheduler consistency model is based on the following:
 	//
 	// EXCLUSIVENESS:
 	//
 	// Only one scheduler might operate in a cluster at any given time.
 	//
-	// Currently, this is achieved automatically since we run in-process
-	// BadgerDB that runs in the Orchard Controller, and Orchard Controller
-	// in turn runs a single scheduler.
+	// When using an in-process BadgerDB, this is achieved automatically,
+	// since the Orchard Controller in turn runs a single scheduler.
+	//
+	// When using etcd, multiple Orchard Controllers might run at the same
+	// time, and this property is ensured by the leader election based on
+	// etcd leases[1]: only the leader runs the scheduling loop iterations.
 	//
-	// In the future, we might support etcd, and in that case leader
-	// election can be implemented to ensure this property, thanks to
-	// etcd leases[1].
+	// Note that the leadership is only checked at the beginning of each loop
+	// iteration and is not re-checked when committing the assignments, so
+	// there's a brief period of time during which the old leader might not
+	// yet know that it lost the leadership and keep scheduling alongside the
+	// new leader. This is tolerable because each assignment is made in a
+	// transaction that re-checks the stored VM and worker, so a VM is never
+	// assigned twice, and the worst that can happen is that both leaders
+	// place VMs on the same worker based on their own view of its resource
+	// usage, temporarily overcommitting it.
 	//
 	// [1]: https://medium.com/@ahadrana/understanding-etcd3-8784c4f61755
 	//
@@ -216,7 +288,10 @@ func (scheduler *Scheduler) schedulingLoopIteration() (int, int, error) {
 
 	var vms []v1.VM
 	var workers []v1.Worker
-	var schedulerProfile v1.SchedulerProfile
+	var vmGroupList []v1.VMGroup
+	var quotas []v1.Quota
+	var scoreWeights v1.SchedulerScoreWeights
+	var fairShare *v1.FairShare
 
 	if err := scheduler.store.View(func(txn storepkg.Transaction) error {
 		var err error
@@ -231,11 +306,22 @@ func (scheduler *Scheduler) schedulingLoopIteration() (int, int, error) {
 			return err
 		}
 
+		vmGroupList, err = txn.ListVMGroups()
+		if err != nil {
+			return err
+		}
+
+		quotas, err = txn.ListQuotas()
+		if err != nil {
+			return err
+		}
+
 		clusterSettings, err := txn.GetClusterSettings()
 		if err != nil {
 			return err
 		}
-		schedulerProfile = clusterSettings.SchedulerProfile
+		scoreWeights = clusterSettings.EffectiveSchedulerScoreWeights()
+		fairShare = clusterSettings.FairShare
 
 		return nil
 	}); err != nil {
@@ -243,166 +329,41 @@ func (scheduler *Scheduler) schedulingLoopIteration() (int, int, error) {
 	}
 
 	unscheduledVMs, workerInfos := ProcessVMs(vms)
+	framework := NewFramework(scheduler.workerOfflineTimeout, scoreWeights)
 
-NextVM:
-	for _, unscheduledVM := range unscheduledVMs {
-		// Order workers depending on the scheduler profile and
-		// our updated lagging resource usage for each worker
-		switch schedulerProfile {
-		case v1.SchedulerProfileDistributeLoad:
-			slices.SortFunc(workers, func(a, b v1.Worker) int {
-				// Sort by the number of running VMs, ascending order
-				return cmp.Compare(workerInfos[a.Name].NumRunningVMs,
-					workerInfos[b.Name].NumRunningVMs)
-			})
-		case v1.SchedulerProfileOptimizeUtilization:
-			fallthrough
-		default:
-			slices.SortFunc(workers, func(a, b v1.Worker) int {
-				// Sort by the number of running VMs, descending order
-				return cmp.Compare(workerInfos[b.Name].NumRunningVMs,
-					workerInfos[a.Name].NumRunningVMs)
-			})
-		}
-
-		// Iterate through sorted workers and find a worker that can run this VM
-	NextWorker:
-		for _, worker := range workers {
-			resourcesUsed := workerInfos.Get(worker.Name).ResourcesUsed
-			resourcesRemaining := worker.Resources.Subtracted(resourcesUsed)
-
-			if worker.Offline(scheduler.workerOfflineTimeout) ||
-				worker.SchedulingPaused ||
-				!compatibleArchAndRuntime(unscheduledVM, worker) ||
-				!resourcesRemaining.CanFit(unscheduledVM.Resources) ||
-				!worker.Labels.Contains(unscheduledVM.Labels) {
-				continue NextWorker
-			}
-
-			err := scheduler.store.Update(func(txn storepkg.Transaction) error {
-				currentUnscheduledVM, err := txn.GetVM(unscheduledVM.Name)
-				if err != nil {
-					if errors.Is(err, storepkg.ErrNotFound) {
-						// The unscheduled VM ceased to exist,
-						// so nothing to schedule
-						return ErrVMSchedulingSkipped
-					}
-
-					return err
-				}
-
-				if currentUnscheduledVM.UID != unscheduledVM.UID {
-					// The unscheduled VM had changed, so we'll re-evaluate a new
-					// version of it in the next scheduling loop iteration
-					return ErrVMSchedulingSkipped
-				}
-
-				if unscheduledVM.IsScheduled() {
-					// Unscheduled VM is not unscheduled anymore,
-					// so there's nothing to do
-					return ErrVMSchedulingSkipped
-				}
-
-				if unscheduledVM.TerminalState() {
-					// We don't support re-scheduling of VMs in terminal state at the moment
-					return ErrVMSchedulingSkipped
-				}
-
-				if unscheduledVM.PowerState.TerminalState() {
-					// We don't support re-scheduling of stopped/suspended VMs at the moment
-					return ErrVMSchedulingSkipped
-				}
-
-				currentWorker, err := txn.GetWorker(worker.Name)
-				if err != nil {
-					if errors.Is(err, storepkg.ErrNotFound) {
-						// The worker that we were planning to schedule
-						// this VM on has ceased to exist, so move on
-						return ErrWorkerSchedulingSkipped
-					}
-
-					return err
-				}
-
-				if currentWorker.Offline(scheduler.workerOfflineTimeout) ||
-					currentWorker.SchedulingPaused {
-					return ErrWorkerSchedulingSkipped
-				}
-
-				if !compatibleArchAndRuntime(unscheduledVM, *currentWorker) {
-					return ErrWorkerSchedulingSkipped
-				}
-
-				if currentWorker.MachineID != worker.MachineID ||
-					!currentWorker.Resources.Equal(worker.Resources) {
-					// Worker has changed
-					return ErrWorkerSchedulingSkipped
-				}
-
-				unscheduledVM.Worker = worker.Name
-				unscheduledVM.ScheduledAt = time.Now()
-				v1.ConditionsSet(&unscheduledVM.Conditions, v1.Condition{
-					Type:  v1.ConditionTypeScheduled,
-					State: v1.ConditionStateTrue,
-				})
-
-				// Fill out the actual CPU allocation
-				if unscheduledVM.CPU == 0 {
-					// Provide defaults for VMs with implicit CPU specification
-					if worker.DefaultCPU != 0 {
-						unscheduledVM.AssignedCPU = worker.DefaultCPU
-					} else {
-						unscheduledVM.AssignedCPU = 4
-					}
-				} else {
-					unscheduledVM.AssignedCPU = unscheduledVM.CPU
-				}
-
-				// Fill out the actual memory allocation
-				if unscheduledVM.Memory == 0 {
-					// Provide defaults for VMs with implicit memory specification
-					if worker.DefaultMemory != 0 {
-						unscheduledVM.AssignedMemory = worker.DefaultMemory
-					} else {
-						unscheduledVM.AssignedMemory = 8192
-					}
-				} else {
-					unscheduledVM.AssignedMemory = unscheduledVM.Memory
-				}
-
-				if err := txn.SetVM(unscheduledVM); err != nil {
-					return err
-				}
-
-				return nil
-			})
-			if err != nil {
-				if errors.Is(err, ErrVMSchedulingSkipped) {
-					continue NextVM
-				}
+	if fairShare != nil {
+		unscheduledVMs = FairShareOrder(unscheduledVMs, scheduler.updateFairShareUsage(vms, fairShare),
+			FairShareCapacity(workers), fairShare)
+	} else {
+		scheduler.resetFairShareUsage()
+	}
 
-				if errors.Is(err, ErrWorkerSchedulingSkipped) {
-					continue NextWorker
-				}
+	committer := &storeCommitter{
+		scheduler:       scheduler,
+		affectedWorkers: mapset.NewSet[string](),
+	}
 
-				return 0, 0, err
-			}
+	planner := newPlanner(vms, workers, vmGroupList, quotas, workerInfos, framework, committer,
+		func(vm v1.VM) string {
+			return vm.UID
+		})
 
-			// Update lagging resource usage
-			workerInfos.AddVM(worker.Name, unscheduledVM.Resources)
+	if err := planner.plan(unscheduledVMs); err != nil {
+		return 0, 0, err
+	}
 
-			// Ping the worker afterward for faster VM execution
-			affectedWorkers.Add(worker.Name)
+	if err := scheduler.updateGroupStatuses(vmGroupList, planner.groupMembers,
+		planner.gangStatusMessages); err != nil {
+		return 0, 0, err
+	}
 
-			// Update metrics
-			scheduler.schedulingTimeHistogram.Record(context.Background(),
-				time.Since(unscheduledVM.CreatedAt).Seconds())
+	scheduler.setExplanations(planner.explanations)
 
-			break
-		}
+	if err := scheduler.updatePendingStatusMessages(unscheduledVMs, planner.explanations); err != nil {
+		return 0, 0, err
 	}
 
-	for affectedWorker := range affectedWorkers.Iter() {
+	for affectedWorker := range committer.affectedWorkers.Iter() {
 		// It's fine to not treat the error as fatal here,
 		// since the worker will sync the VMs on the next
 		// scheduling iteration
@@ -430,14 +391,171 @@ func ProcessVMs(vms []v1.VM) ([]v1.VM, WorkerInfos) {
 		}
 	}
 
-	// Sort unscheduled VMs by the date of creation
-	sort.Slice(unscheduledVMs, func(i, j int) bool {
-		return unscheduledVMs[i].CreatedAt.Before(unscheduledVMs[j].CreatedAt)
+	// Sort unscheduled VMs by priority (highest first)
+	// and then by the date of creation (oldest first)
+	slices.SortStableFunc(unscheduledVMs, func(a, b v1.VM) int {
+		if result := cmp.Compare(b.Priority, a.Priority); result != 0 {
+			return result
+		}
+
+		return a.CreatedAt.Compare(b.CreatedAt)
 	})
 
 	return unscheduledVMs, workerToResources
 }
 
+// eligible returns true if the worker is able to run the VM, disregarding
+// the worker's remaining resources and the VM's affinity.
+func (scheduler *Scheduler) eligible(vm v1.VM, worker v1.Worker) bool {
+	return runFilters(eligibilityFilters(scheduler.workerOfflineTimeout), vm, worker, nil) == ""
+}
+
+// storeCommitter persists the planner's decisions in the store.
+type storeCommitter struct {
+	scheduler *Scheduler
+
+	// Workers to ping afterward for faster VM execution
+	affectedWorkers mapset.Set[string]
+}
+
+func (committer *storeCommitter) assign(vm *v1.VM, worker v1.Worker) error {
+	if err := committer.scheduler.store.Update(func(txn storepkg.Transaction) error {
+		return committer.scheduler.assignTxn(txn, vm, worker)
+	}); err != nil {
+		return err
+	}
+
+	committer.assigned(*vm, worker)
+
+	return nil
+}
+
+func (committer *storeCommitter) preempt(preemptor v1.VM, candidate preemptionCandidate) error {
+	if err := committer.scheduler.preempt(preemptor, candidate); err != nil {
+		return err
+	}
+
+	committer.scheduler.logger.Infof("preempted %d VM(s) on worker %s in favor of VM %s",
+		len(candidate.victims), candidate.worker.Name, preemptor.Name)
+
+	// Ping the worker afterward to stop the victims faster
+	committer.affectedWorkers.Add(candidate.worker.Name)
+
+	return nil
+}
+
+func (committer *storeCommitter) assigned(vm v1.VM, worker v1.Worker) {
+	// Ping the worker afterward for faster VM execution
+	committer.affectedWorkers.Add(worker.Name)
+
+	// Update metrics
+	committer.scheduler.schedulingTimeHistogram.Record(context.Background(),
+		time.Since(vm.CreatedAt).Seconds())
+}
+
+// assignTxn re-checks the VM and the worker within the transaction
+// and assigns the VM to the worker.
+func (scheduler *Scheduler) assignTxn(txn storepkg.Transaction, unscheduledVM *v1.VM, worker v1.Worker) error {
+	currentUnscheduledVM, err := txn.GetVM(unscheduledVM.Namespace, unscheduledVM.Name)
+	if err != nil {
+		if errors.Is(err, storepkg.ErrNotFound) {
+			// The unscheduled VM ceased to exist,
+			// so nothing to schedule
+			return ErrVMSchedulingSkipped
+		}
+
+		return err
+	}
+
+	if currentUnscheduledVM.UID != unscheduledVM.UID {
+		// The unscheduled VM had changed, so we'll re-evaluate a new
+		// version of it in the next scheduling loop iteration
+		return ErrVMSchedulingSkipped
+	}
+
+	if currentUnscheduledVM.IsScheduled() {
+		// Unscheduled VM is not unscheduled anymore,
+		// so there's nothing to do
+		return ErrVMSchedulingSkipped
+	}
+
+	if currentUnscheduledVM.TerminalState() {
+		// We don't support re-scheduling of VMs in terminal state at the moment
+		return ErrVMSchedulingSkipped
+	}
+
+	if currentUnscheduledVM.PowerState.TerminalState() {
+		// We don't support re-scheduling of stopped/suspended VMs at the moment
+		return ErrVMSchedulingSkipped
+	}
+
+	currentWorker, err := txn.GetWorker(worker.Name)
+	if err != nil {
+		if errors.Is(err, storepkg.ErrNotFound) {
+			// The worker that we were planning to schedule
+			// this VM on has ceased to exist, so move on
+			return ErrWorkerSchedulingSkipped
+		}
+
+		return err
+	}
+
+	if !scheduler.eligible(*unscheduledVM, *currentWorker) {
+		return ErrWorkerSchedulingSkipped
+	}
+
+	if currentWorker.MachineID != worker.MachineID ||
+		!currentWorker.Resources.Equal(worker.Resources) {
+		// Worker has changed
+		return ErrWorkerSchedulingSkipped
+	}
+
+	assignVM(unscheduledVM, worker, time.Now())
+
+	return txn.SetVM(*unscheduledVM)
+}
+
+// assignVM marks the VM as scheduled on the worker.
+func assignVM(vm *v1.VM, worker v1.Worker, now time.Time) {
+	vm.Worker = worker.Name
+	vm.ScheduledAt = now
+	vm.StatusMessage = ""
+	v1.ConditionsSet(&vm.Conditions, v1.Condition{
+		Type:  v1.ConditionTypeScheduled,
+		State: v1.ConditionStateTrue,
+	})
+
+	// Fill out the actual CPU and memory allocation
+	vm.AssignedCPU = assignedCPU(*vm, worker)
+	vm.AssignedMemory = assignedMemory(*vm, worker)
+}
+
+func assignedCPU(vm v1.VM, worker v1.Worker) uint64 {
+	if vm.CPU != 0 {
+		return vm.CPU
+	}
+
+	// Provide defaults for VMs with implicit CPU specification
+	if worker.DefaultCPU != 0 {
+		return worker.DefaultCPU
+	}
+
+	return 4
+}
+
+func assignedMemory(vm v1.VM, worker v1.Worker) uint64 {
+	if vm.Memory != 0 {
+		return vm.Memory
+	}
+
+	// Provide defaults for VMs with implicit memory specification
+	if worker.DefaultMemory != 0 {
+		return worker.DefaultMemory
+	}
+
+	return 8192
+}
+
 func compatibleArchAndRuntime(vm v1.VM, worker v1.Worker) bool {
 	return vm.Arch == worker.Arch && vm.Runtime == worker.Runtime
 }
@@ -465,10 +583,10 @@ func (scheduler *Scheduler) healthCheckingLoopIteration() (int, error) {
 
 	// Process each VM in a lagging list of VMs in an individual
 	// transaction, re-checking that the VM still exists
-	// and it is still scheduled
+	// and it is still scheduled or awaits a restart
 	for _, vm := range vms {
-		if !vm.IsScheduled() {
-			// Not a scheduled VM
+		if !vm.IsScheduled() && !vm.WillRestart() {
+			// Not a scheduled VM and not a VM to restart
 			//
 			// We'll re-check this below, but this allows us
 			// to avoid wasting cycles opening a transaction
@@ -477,7 +595,7 @@ func (scheduler *Scheduler) healthCheckingLoopIteration() (int, error) {
 		}
 
 		if err := scheduler.store.Update(func(txn storepkg.Transaction) error {
-			currentVM, err := txn.GetVM(vm.Name)
+			currentVM, err := txn.GetVM(vm.Namespace, vm.Name)
 			if err != nil {
 				if errors.Is(err, storepkg.ErrNotFound) {
 					// VM ceased to exist, nothing to do
@@ -487,8 +605,8 @@ func (scheduler *Scheduler) healthCheckingLoopIteration() (int, error) {
 				return err
 			}
 
-			if !vm.IsScheduled() {
-				// Not a scheduled VM, nothing to do
+			if !currentVM.IsScheduled() && !currentVM.WillRestart() {
+				// Not a scheduled VM and not a VM to restart, nothing to do
 				return nil
 			}
 
@@ -504,37 +622,38 @@ func (scheduler *Scheduler) healthCheckingLoopIteration() (int, error) {
 func (scheduler *Scheduler) healthCheckVM(txn storepkg.Transaction, vm v1.VM) error {
 	logger := scheduler.logger.With("vm_name", vm.Name, "vm_uid", vm.UID, "vm_restart_count", vm.RestartCount)
 
-	// Schedule a VM restart if the restart policy mandates it
-	needsRestart := vm.RestartPolicy == v1.RestartPolicyOnFailure &&
-		vm.Status == v1.VMStatusFailed &&
-		time.Since(vm.RestartedAt) > schedulerVMRestartDelay
-
-	if needsRestart {
-		logger.Debugf("restarting VM")
-
-		lifecycle.Report(&vm, "VM restarted", scheduler.logger)
-
-		vm.Status = v1.VMStatusPending
-		vm.StatusMessage = ""
-		vm.Worker = ""
-		vm.AssignedCPU = 0
-		vm.AssignedMemory = 0
-		vm.RestartedAt = time.Now()
-		vm.RestartCount++
-		vm.ScheduledAt = time.Time{}
-		vm.StartedAt = time.Time{}
-		vm.PowerState = v1.PowerStateRunning
-		vm.LocalName = ondiskname.New(vm.Name, vm.UID, vm.RestartCount).String()
-		//nolint:staticcheck // yes, this is deprecated, but we still maintain it for backward compatibility
-		vm.TartName = vm.LocalName
-		vm.Conditions = []v1.Condition{
-			{
-				Type:  v1.ConditionTypeScheduled,
-				State: v1.ConditionStateFalse,
-			},
+	// Restart the VM if the restart policy mandates it,
+	// backing off exponentially with each consecutive failure
+	if vm.WillRestart() {
+		if vm.NextRestartAt.IsZero() {
+			clusterSettings, err := txn.GetClusterSettings()
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+
+			if ranLongEnough(vm, clusterSettings.RestartBackoff, now) {
+				vm.ConsecutiveFailures = 0
+			}
+
+			vm.NextRestartAt = now.Add(clusterSettings.RestartBackoff.Delay(vm.ConsecutiveFailures))
+
+			logger.Debugf("VM will be restarted at %s", vm.NextRestartAt)
+
+			if err := txn.SetVM(vm); err != nil {
+				return err
+			}
 		}
 
-		return txn.SetVM(vm)
+		if !time.Now().Before(vm.NextRestartAt) {
+			return scheduler.restartVM(txn, logger, vm)
+		}
+
+		if !vm.IsScheduled() {
+			// VM was already de-scheduled and awaits the restart, nothing to do
+			return nil
+		}
 	}
 
 	worker, err := txn.GetWorker(vm.Worker)
@@ -558,6 +677,25 @@ func (scheduler *Scheduler) healthCheckVM(txn storepkg.Transaction, vm v1.VM) er
 		return txn.SetVM(vm)
 	}
 
+	if taints := v1.UntoleratedTaints(worker.Taints, vm.Tolerations, v1.TaintEffectNoExecute); len(taints) != 0 &&
+		!vm.TerminalState() {
+		vm.Status = v1.VMStatusFailed
+		vm.StatusMessage = fmt.Sprintf("VM was evicted from the worker %s due to the taint %s "+
+			"that the VM doesn't tolerate", worker.Name, taints[0])
+
+		if err := txn.AppendEvents([]v1.Event{
+			{
+				Kind:      v1.EventKindEvicted,
+				Timestamp: time.Now().Unix(),
+				Payload:   vm.StatusMessage,
+			},
+		}, "vms", vm.UID); err != nil {
+			return err
+		}
+
+		return txn.SetVM(vm)
+	}
+
 	if vm.PowerState.TerminalState() && v1.ConditionIsFalse(vm.Conditions, v1.ConditionTypeRunning) {
 		// VM has entered a terminal power state and stopped running,
 		// de-schedule it to free up resources
@@ -588,3 +726,49 @@ func (scheduler *Scheduler) healthCheckVM(txn storepkg.Transaction, vm v1.VM) er
 
 	return nil
 }
+
+// ranLongEnough returns true if the failed VM had been running long enough
+// for its failure to no longer be considered consecutive to the previous ones.
+func ranLongEnough(vm v1.VM, restartBackoff *v1.RestartBackoff, now time.Time) bool {
+	if vm.StartedAt.IsZero() {
+		return false
+	}
+
+	finishedAt := vm.FinishedAt
+	if finishedAt.IsZero() {
+		finishedAt = now
+	}
+
+	return finishedAt.Sub(vm.StartedAt) >= restartBackoff.ResetAfter()
+}
+
+func (scheduler *Scheduler) restartVM(txn storepkg.Transaction, logger *zap.SugaredLogger, vm v1.VM) error {
+	logger.Debugf("restarting VM")
+
+	lifecycle.Report(&vm, "VM restarted", scheduler.logger)
+
+	vm.Status = v1.VMStatusPending
+	vm.StatusMessage = ""
+	vm.Worker = ""
+	vm.AssignedCPU = 0
+	vm.AssignedMemory = 0
+	vm.RestartedAt = time.Now()
+	vm.RestartCount++
+	vm.NextRestartAt = time.Time{}
+	vm.ConsecutiveFailures++
+	vm.ScheduledAt = time.Time{}
+	vm.StartedAt = time.Time{}
+	vm.FinishedAt = time.Time{}
+	vm.PowerState = v1.PowerStateRunning
+	vm.LocalName = ondiskname.New(vm.Name, vm.UID, vm.RestartCount).String()
+	//nolint:staticcheck // yes, this is deprecated, but we still maintain it for backward compatibility
+	vm.TartName = vm.LocalName
+	vm.Conditions = []v1.Condition{
+		{
+			Type:  v1.ConditionTypeScheduled,
+			State: v1.ConditionStateFalse,
+		},
+	}
+
+	return txn.SetVM(vm)
+}
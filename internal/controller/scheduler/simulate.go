@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// Simulation describes the cluster state to run the scheduler against.
+type Simulation struct {
+	Workers              []v1.Worker
+	VMs                  []v1.VM
+	VMGroups             []v1.VMGroup
+	Quotas               []v1.Quota
+	ClusterSettings      v1.ClusterSettings
+	WorkerOfflineTimeout time.Duration
+
+	// FairShareUsage is the usage of the fair-share queues averaged over time,
+	// when nil, the usage of the VMs that are currently scheduled is used.
+	FairShareUsage FairShareUsage
+}
+
+type SimulationResult struct {
+	// VMs are the simulation's VMs, with the pending VMs that
+	// the scheduler was able to place assigned to the workers.
+	VMs []v1.VM
+
+	// Placed are the v1.NamespacedName() of the VMs that were placed by the simulation.
+	Placed []string
+
+	// Explanations of why the VMs remain pending, keyed by the VM's v1.NamespacedName().
+	Explanations map[string]v1.SchedulingExplanation
+
+	// WorkerInfos is the resource usage of the workers after the simulation.
+	WorkerInfos WorkerInfos
+}
+
+// Simulate runs a single scheduling loop iteration against the cluster
+// state in memory, without persisting anything.
+//
+// Unlike the actual scheduling loop iteration, the VMs with the
+// PreemptLowerPriority preemption policy don't preempt any VMs.
+func Simulate(simulation Simulation) SimulationResult {
+	result := SimulationResult{
+		VMs: slices.Clone(simulation.VMs),
+	}
+
+	vmIndices := map[string]int{}
+
+	for i, vm := range result.VMs {
+		vmIndices[v1.NamespacedName(vm.Namespace, vm.Name)] = i
+	}
+
+	unscheduledVMs, workerInfos := ProcessVMs(result.VMs)
+
+	if fairShare := simulation.ClusterSettings.FairShare; fairShare != nil {
+		usage := simulation.FairShareUsage
+		if usage == nil {
+			usage = NewFairShareUsage(result.VMs, fairShare)
+		}
+
+		unscheduledVMs = FairShareOrder(unscheduledVMs, usage, FairShareCapacity(simulation.Workers), fairShare)
+	}
+
+	framework := NewFramework(simulation.WorkerOfflineTimeout,
+		simulation.ClusterSettings.EffectiveSchedulerScoreWeights())
+
+	committer := &simulationCommitter{
+		result:    &result,
+		vmIndices: vmIndices,
+	}
+
+	planner := newPlanner(result.VMs, simulation.Workers, simulation.VMGroups, simulation.Quotas, workerInfos,
+		framework, committer, func(vm v1.VM) string {
+			return v1.NamespacedName(vm.Namespace, vm.Name)
+		})
+
+	// The simulation committer never fails
+	_ = planner.plan(unscheduledVMs)
+
+	result.Explanations = planner.explanations
+	result.WorkerInfos = workerInfos
+
+	return result
+}
+
+// simulationCommitter applies the planner's decisions to the simulation's VMs.
+type simulationCommitter struct {
+	result    *SimulationResult
+	vmIndices map[string]int
+}
+
+func (committer *simulationCommitter) assign(vm *v1.VM, worker v1.Worker) error {
+	// Don't modify the simulation's VMs in place
+	vm.Conditions = slices.Clone(vm.Conditions)
+
+	assignVM(vm, worker, time.Now())
+
+	key := v1.NamespacedName(vm.Namespace, vm.Name)
+	committer.result.VMs[committer.vmIndices[key]] = *vm
+	committer.result.Placed = append(committer.result.Placed, key)
+
+	return nil
+}
+
+func (committer *simulationCommitter) assignGang(
+	_ v1.VMGroup,
+	assignments []gangAssignment,
+) ([]gangAssignment, error) {
+	var result []gangAssignment
+
+	for _, assignment := range assignments {
+		vm := assignment.vm
+
+		if err := committer.assign(&vm, assignment.worker); err != nil {
+			return nil, err
+		}
+
+		result = append(result, gangAssignment{
+			vm:     vm,
+			worker: assignment.worker,
+		})
+	}
+
+	return result, nil
+}
+
+func (committer *simulationCommitter) preempt(_ v1.VM, _ preemptionCandidate) error {
+	// The simulation doesn't preempt any VMs
+	return ErrVMSchedulingSkipped
+}
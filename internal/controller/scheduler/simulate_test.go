@@ -0,0 +1,97 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/scheduler"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	newVM := func(name string, priority int64, createdAt time.Time) v1.VM {
+		vm := v1.VM{
+			Meta:      v1.Meta{Name: name, Namespace: v1.DefaultNamespace, CreatedAt: createdAt},
+			Priority:  priority,
+			Resources: v1.Resources{v1.ResourceTartVMs: 1},
+		}
+		vm.PowerState = v1.PowerStateRunning
+
+		return vm
+	}
+
+	now := time.Now()
+
+	scheduled := newVM("scheduled", 0, now)
+	scheduled.Worker = "worker-a"
+
+	vms := []v1.VM{
+		scheduled,
+		newVM("oldest", 0, now.Add(-time.Hour)),
+		newVM("newest", 0, now),
+		newVM("important", 10, now),
+	}
+
+	simulation := scheduler.Simulation{
+		Workers: []v1.Worker{
+			newFrameworkTestWorker("worker-a", 2, nil),
+			newFrameworkTestWorker("worker-b", 1, nil),
+		},
+		VMs:                  vms,
+		WorkerOfflineTimeout: time.Minute,
+	}
+
+	result := scheduler.Simulate(simulation)
+
+	// The VMs are placed in the order of priority and creation
+	require.Equal(t, []string{"default/important", "default/oldest"}, result.Placed)
+	require.Equal(t, "worker-a", result.VMs[3].Worker)
+	require.Equal(t, "worker-b", result.VMs[1].Worker)
+	require.Empty(t, result.VMs[2].Worker)
+	require.Equal(t, "0/2 workers are available: 2 insufficient org.cirruslabs.tart-vms",
+		result.Explanations["default/newest"].Message)
+	require.Equal(t, 2, result.WorkerInfos.Get("worker-a").NumRunningVMs)
+
+	// The placed VMs are assigned the same way as by the scheduler
+	require.True(t, result.VMs[1].IsScheduled())
+	require.False(t, result.VMs[1].ScheduledAt.IsZero())
+
+	// Nothing is modified in place
+	require.Empty(t, vms[1].Worker)
+	require.False(t, vms[1].IsScheduled())
+}
+
+func TestSimulateNamespaces(t *testing.T) {
+	newVM := func(namespace string, resources v1.Resources) v1.VM {
+		vm := v1.VM{
+			Meta:      v1.Meta{Name: "vm", Namespace: namespace},
+			Resources: resources,
+		}
+		vm.PowerState = v1.PowerStateRunning
+		vm.Conditions = []v1.Condition{
+			{
+				Type:  v1.ConditionTypeScheduled,
+				State: v1.ConditionStateFalse,
+			},
+		}
+
+		return vm
+	}
+
+	// VMs with the same name in different namespaces are simulated independently
+	result := scheduler.Simulate(scheduler.Simulation{
+		Workers: []v1.Worker{newFrameworkTestWorker("worker", 2, nil)},
+		VMs: []v1.VM{
+			newVM("team-a", v1.Resources{v1.ResourceTartVMs: 1}),
+			newVM("team-b", v1.Resources{v1.ResourceTartVMs: 1, "gpu": 1}),
+		},
+		WorkerOfflineTimeout: time.Minute,
+	})
+
+	require.Equal(t, []string{"team-a/vm"}, result.Placed)
+	require.Equal(t, "worker", result.VMs[0].Worker)
+	require.Empty(t, result.VMs[1].Worker)
+	require.Contains(t, result.Explanations, "team-b/vm")
+	require.NotContains(t, result.Explanations, "team-a/vm")
+}
@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/cirruslabs/orchard/rpc"
+	mapset "github.com/deckarep/golang-set/v2"
+)
+
+// garbageCollectionLoopIteration stamps the finished VMs with
+// the time they've finished at and deletes the VMs whose
+// time-to-live has expired.
+func (scheduler *Scheduler) garbageCollectionLoopIteration() (int, error) {
+	// Get a lagging view of VMs
+	var vms []v1.VM
+
+	if err := scheduler.store.View(func(txn storepkg.Transaction) error {
+		var err error
+
+		vms, err = txn.ListVMs()
+
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	affectedWorkers := mapset.NewSet[string]()
+	var numDeleted int
+
+	// Process each VM in a lagging list of VMs in an individual
+	// transaction, re-checking that the VM still exists
+	// and it is still the same VM
+	for _, vm := range vms {
+		if !needsGarbageCollection(vm) {
+			// We'll re-check this below, but this allows us
+			// to avoid wasting cycles opening a transaction
+			// for nothing.
+			continue
+		}
+
+		var deletedVM *v1.VM
+
+		if err := scheduler.store.Update(func(txn storepkg.Transaction) error {
+			// Reset the state in case the transaction is retried
+			deletedVM = nil
+
+			currentVM, err := txn.GetVM(vm.Namespace, vm.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					// VM ceased to exist, nothing to do
+					return nil
+				}
+
+				return err
+			}
+
+			if currentVM.UID != vm.UID {
+				// VM was re-created, we'll process it on the next iteration
+				return nil
+			}
+
+			deleted, err := garbageCollectVM(txn, currentVM, time.Now())
+			if err != nil {
+				return err
+			}
+			if deleted {
+				deletedVM = currentVM
+			}
+
+			return nil
+		}); err != nil {
+			return numDeleted, err
+		}
+
+		if deletedVM == nil {
+			continue
+		}
+
+		numDeleted++
+
+		lifecycle.Report(deletedVM, "VM expired", scheduler.logger)
+
+		if deletedVM.Worker != "" {
+			affectedWorkers.Add(deletedVM.Worker)
+		}
+	}
+
+	scheduler.syncWorkers(affectedWorkers)
+
+	return numDeleted, nil
+}
+
+// syncWorkers asks the workers to reactively sync their VMs,
+// e.g. to promptly stop the VMs that were deleted.
+func (scheduler *Scheduler) syncWorkers(workers mapset.Set[string]) {
+	for worker := range workers.Iter() {
+		// It's fine to not treat the error as fatal here,
+		// since the worker will sync the VMs periodically
+		notifyContext, notifyContextCancel := context.WithTimeout(context.Background(), time.Second)
+		if err := scheduler.notifier.Notify(notifyContext, worker, &rpc.WatchInstruction{
+			Action: &rpc.WatchInstruction_SyncVmsAction{},
+		}); err != nil {
+			scheduler.logger.Errorf("Failed to reactively sync VMs on worker %s: %v", worker, err)
+		}
+		notifyContextCancel()
+	}
+}
+
+func needsGarbageCollection(vm v1.VM) bool {
+	if _, ok := vm.ExpiresAt(); ok {
+		return true
+	}
+
+	// FinishedAt needs to be maintained for all VMs,
+	// and not only the ones with TTLSecondsAfterFinished
+	return vm.Finished() != !vm.FinishedAt.IsZero()
+}
+
+// garbageCollectVM maintains the VM's FinishedAt field and deletes
+// the VM if its time-to-live has expired, returning true in that case.
+func garbageCollectVM(txn storepkg.Transaction, vm *v1.VM, now time.Time) (bool, error) {
+	var finishedAtChanged bool
+
+	if finished := vm.Finished(); finished && vm.FinishedAt.IsZero() {
+		vm.FinishedAt = now
+		finishedAtChanged = true
+	} else if !finished && !vm.FinishedAt.IsZero() {
+		vm.FinishedAt = time.Time{}
+		finishedAtChanged = true
+	}
+
+	if expiresAt, ok := vm.ExpiresAt(); ok && !now.Before(expiresAt) {
+		if err := txn.DeleteVM(vm.Namespace, vm.Name); err != nil {
+			return false, err
+		}
+
+		if err := txn.DeleteEvents("vms", vm.UID); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	if finishedAtChanged {
+		return false, txn.SetVM(*vm)
+	}
+
+	return false, nil
+}
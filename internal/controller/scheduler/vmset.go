@@ -0,0 +1,254 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller/lifecycle"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/samber/lo"
+)
+
+// vmSetLoopIteration keeps each VM set at its desired number of VMs by replacing
+// the finished VMs and gradually rolls out the set's template changes, and returns
+// the number of VMs created.
+func (scheduler *Scheduler) vmSetLoopIteration() (int, error) {
+	// Get a lagging view of VM sets
+	var vmSets []v1.VMSet
+
+	if err := scheduler.store.View(func(txn storepkg.Transaction) error {
+		var err error
+
+		vmSets, err = txn.ListVMSets()
+
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	affectedWorkers := mapset.NewSet[string]()
+	var numCreated int
+
+	// Process each VM set in an individual transaction,
+	// re-checking that the VM set still exists
+	for _, vmSet := range vmSets {
+		var numCreatedSet int
+		var deletedVMs []v1.VM
+
+		if err := scheduler.store.Update(func(txn storepkg.Transaction) error {
+			// Reset the state in case the transaction is retried
+			numCreatedSet = 0
+			deletedVMs = nil
+
+			currentVMSet, err := txn.GetVMSet(vmSet.Namespace, vmSet.Name)
+			if err != nil {
+				if errors.Is(err, storepkg.ErrNotFound) {
+					// VM set ceased to exist, nothing to do
+					return nil
+				}
+
+				return err
+			}
+
+			numCreatedSet, deletedVMs, err = reconcileVMSetTxn(txn, *currentVMSet, time.Now())
+
+			return err
+		}); err != nil {
+			return numCreated, err
+		}
+
+		numCreated += numCreatedSet
+
+		for _, deletedVM := range deletedVMs {
+			lifecycle.Report(&deletedVM, "VM deleted from the set", scheduler.logger)
+
+			if deletedVM.Worker != "" {
+				affectedWorkers.Add(deletedVM.Worker)
+			}
+		}
+	}
+
+	scheduler.syncWorkers(affectedWorkers)
+
+	return numCreated, nil
+}
+
+// reconcileVMSetTxn creates and deletes the set's VMs to match the set's desired
+// number of VMs and template, and returns the number of VMs created along with
+// the VMs deleted.
+func reconcileVMSetTxn(txn storepkg.Transaction, vmSet v1.VMSet, now time.Time) (int, []v1.VM, error) {
+	vms, err := txn.ListVMs()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	quotas, err := txn.ListQuotas()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var deletedVMs []v1.VM
+
+	deleteVM := func(vm v1.VM) error {
+		if err := deleteVMTxn(txn, vm); err != nil {
+			return err
+		}
+
+		deletedVMs = append(deletedVMs, vm)
+
+		return nil
+	}
+
+	isRunning := func(vm v1.VM) bool {
+		return vm.Status == v1.VMStatusRunning
+	}
+
+	isUpdated := func(vm v1.VM) bool {
+		return vm.VMSetGeneration == vmSet.Generation
+	}
+
+	// Get rid of the set's VMs that have finished, the failed VMs
+	// that are going to be restarted are retained
+	var activeVMs []v1.VM
+
+	for _, vm := range vms {
+		if vm.Namespace != vmSet.Namespace || vm.VMSet != vmSet.Name {
+			continue
+		}
+
+		if vm.Finished() {
+			if err := deleteVM(vm); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		}
+
+		activeVMs = append(activeVMs, vm)
+	}
+
+	// Scale down by deleting the least useful VMs first, that is the outdated
+	// VMs, the VMs that are not running yet, and then the most recently created VMs
+	if uint64(len(activeVMs)) > vmSet.Replicas {
+		slices.SortStableFunc(activeVMs, func(a, b v1.VM) int {
+			if isUpdated(a) != isUpdated(b) {
+				if isUpdated(a) {
+					return -1
+				}
+
+				return 1
+			}
+
+			if isRunning(a) != isRunning(b) {
+				if isRunning(a) {
+					return -1
+				}
+
+				return 1
+			}
+
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		for _, vm := range activeVMs[vmSet.Replicas:] {
+			if err := deleteVM(vm); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		activeVMs = activeVMs[:vmSet.Replicas]
+	}
+
+	// Roll out the template changes by replacing the outdated VMs, the VMs
+	// that are not running are unavailable anyway and can be replaced right
+	// away, while the running VMs are only replaced as long as no more than
+	// MaxUnavailable VMs become unavailable
+	slices.SortStableFunc(activeVMs, func(a, b v1.VM) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	numRunning := uint64(lo.CountBy(activeVMs, isRunning))
+	numUnavailable := vmSet.Replicas - numRunning
+
+	var retainedVMs []v1.VM
+
+	for _, vm := range activeVMs {
+		if isUpdated(vm) {
+			retainedVMs = append(retainedVMs, vm)
+
+			continue
+		}
+
+		if isRunning(vm) {
+			if numUnavailable >= vmSet.EffectiveMaxUnavailable() {
+				retainedVMs = append(retainedVMs, vm)
+
+				continue
+			}
+
+			numUnavailable++
+		}
+
+		if err := deleteVM(vm); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	activeVMs = retainedVMs
+
+	// The deleted VMs no longer count towards the quotas
+	vms = lo.Reject(vms, func(vm v1.VM, _ int) bool {
+		return lo.ContainsBy(deletedVMs, func(deletedVM v1.VM) bool {
+			return deletedVM.UID == vm.UID
+		})
+	})
+
+	// Scale up, as long as the quotas permit
+	var numCreated int
+	var statusMessage string
+
+	for uint64(len(activeVMs)) < vmSet.Replicas {
+		vm, err := newTemplatedVMTxn(txn, vmSet.Template, vmSet.Name, vmSet.Namespace, now)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		vm.VMSet = vmSet.Name
+		vm.VMSetGeneration = vmSet.Generation
+
+		if err := admitQuotas(quotas, vms, vm); err != nil {
+			statusMessage = fmt.Sprintf("cannot create more VMs: %v", err)
+
+			break
+		}
+
+		if err := txn.SetVM(vm); err != nil {
+			return 0, nil, err
+		}
+
+		vms = append(vms, vm)
+		activeVMs = append(activeVMs, vm)
+		numCreated++
+	}
+
+	// Update the set's status
+	readyReplicas := uint64(lo.CountBy(activeVMs, isRunning))
+	updatedReplicas := uint64(lo.CountBy(activeVMs, isUpdated))
+
+	if vmSet.ReadyReplicas != readyReplicas || vmSet.UpdatedReplicas != updatedReplicas ||
+		vmSet.StatusMessage != statusMessage {
+		vmSet.ReadyReplicas = readyReplicas
+		vmSet.UpdatedReplicas = updatedReplicas
+		vmSet.StatusMessage = statusMessage
+
+		if err := txn.SetVMSet(vmSet); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return numCreated, deletedVMs, nil
+}
@@ -23,6 +23,20 @@ func (workerInfos WorkerInfos) AddVM(name string, resourcesUsed v1.Resources) {
 	workerInfos[name] = workerInfo
 }
 
+// Clone returns a deep copy of the worker infos.
+func (workerInfos WorkerInfos) Clone() WorkerInfos {
+	result := make(WorkerInfos, len(workerInfos))
+
+	for name, workerInfo := range workerInfos {
+		result[name] = WorkerInfo{
+			ResourcesUsed: workerInfo.ResourcesUsed.Copy(),
+			NumRunningVMs: workerInfo.NumRunningVMs,
+		}
+	}
+
+	return result
+}
+
 func (workerInfos WorkerInfos) Get(name string) WorkerInfo {
 	workerInfo, ok := workerInfos[name]
 	if !ok {
@@ -216,12 +216,18 @@ func (server *SSHServer) handleDirectTCPIP(ctx context.Context, newChannel ssh.N
 
 	server.logger.Debugf("proxying connection to %s:%d", payload.HostToConnect, payload.PortToConnect)
 
-	// Retrieve the VM object
+	// Retrieve the VM object, the host to connect is either "<name>"
+	// for the VMs in the default namespace or "<namespace>/<name>"
+	namespace, name, ok := strings.Cut(payload.HostToConnect, "/")
+	if !ok {
+		namespace, name = v1.DefaultNamespace, payload.HostToConnect
+	}
+
 	var vm *v1.VM
 	var err error
 
 	err = server.store.View(func(txn storepkg.Transaction) error {
-		vm, err = txn.GetVM(payload.HostToConnect)
+		vm, err = txn.GetVM(namespace, name)
 
 		return err
 	})
@@ -0,0 +1,43 @@
+package badger
+
+import (
+	"io"
+
+	"github.com/dgraph-io/badger/v3"
+	"go.uber.org/zap"
+)
+
+// maxPendingWrites is the number of pending writes
+// that Badger is allowed to buffer when loading a backup
+const maxPendingWrites = 256
+
+func (store *Store) Backup(w io.Writer) error {
+	// Badger's backups are consistent because they're
+	// performed using a read-only transaction
+	_, err := store.db.Backup(w, 0)
+
+	return mapErr(err)
+}
+
+// Restore loads the backup previously created with Store.Backup()
+// into the database at dbPath, which should not be in use.
+func Restore(dbPath string, r io.Reader, logger *zap.SugaredLogger) error {
+	opts := badger.DefaultOptions(dbPath).
+		WithLogger(newBadgerLogger(logger)).
+		WithLoggingLevel(badger.INFO)
+
+	opts.SyncWrites = true
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Load(r, maxPendingWrites); err != nil {
+		_ = db.Close()
+
+		return err
+	}
+
+	return db.Close()
+}
@@ -1,7 +1,6 @@
 package badger
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"path"
@@ -70,64 +69,24 @@ func (txn *Transaction) ListEventsPage(options storepkg.ListOptions, scope ...st
 	// return [] when no events are found
 	result.Items = []v1.Event{}
 
-	prefix := scopePrefix(scope)
-	itOptions := badger.DefaultIteratorOptions
-	itOptions.Prefix = prefix
-	if options.Order == storepkg.ListOrderDesc {
-		itOptions.Reverse = true
-	}
-
-	it := txn.badgerTxn.NewIterator(itOptions)
-	defer it.Close()
-
-	cursor := options.Cursor
-	if len(cursor) > 0 {
-		if !bytes.HasPrefix(cursor, prefix) {
-			seekKey := make([]byte, 0, len(prefix)+len(cursor))
-			seekKey = append(seekKey, prefix...)
-			seekKey = append(seekKey, cursor...)
-			cursor = seekKey
-		}
-		it.Seek(cursor)
-		if it.ValidForPrefix(prefix) && bytes.Equal(it.Item().Key(), cursor) {
-			it.Next()
-		}
-	} else if options.Order == storepkg.ListOrderDesc {
-		it.Seek(append(prefix, 0xFF))
-	} else {
-		it.Rewind()
-	}
-
-	for it.ValidForPrefix(prefix) {
-		item := it.Item()
-
+	result.NextCursor, err = iteratePage(txn, scopePrefix(scope), options, func(item *badger.Item) error {
 		eventBytes, err := item.ValueCopy(nil)
 		if err != nil {
-			return result, err
+			return err
 		}
 
 		var event v1.Event
 
 		if err := json.Unmarshal(eventBytes, &event); err != nil {
-			return result, err
+			return err
 		}
 
 		result.Items = append(result.Items, event)
 
-		if options.Limit > 0 && len(result.Items) >= options.Limit {
-			lastKey := item.KeyCopy(nil)
-			it.Next()
-			if it.ValidForPrefix(prefix) {
-				lastKey = bytes.TrimPrefix(lastKey, prefix)
-				result.NextCursor = lastKey
-			}
-			break
-		}
-
-		it.Next()
-	}
+		return nil
+	})
 
-	return result, nil
+	return result, err
 }
 
 func (txn *Transaction) DeleteEvents(scope ...string) (err error) {
@@ -1,8 +1,10 @@
 package badger
 
 import (
+	"bytes"
 	"encoding/json"
 
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/dgraph-io/badger/v3"
 )
 
@@ -88,6 +90,104 @@ func genericList[T any, PT interface {
 	return result, nil
 }
 
+func genericListPage[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](txn *Transaction, prefix string, options storepkg.ListOptions) (result storepkg.Page[T], err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	// Declare an empty, non-nil slice to
+	// return [] when no objects are found
+	result.Items = []T{}
+
+	result.NextCursor, err = iteratePage(txn, []byte(prefix), options, func(item *badger.Item) error {
+		valueBytes, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+
+		var obj T
+
+		if err := json.Unmarshal(valueBytes, &obj); err != nil {
+			return err
+		}
+
+		PT(&obj).SetVersion(item.Version())
+
+		result.Items = append(result.Items, obj)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// iteratePage calls fn for each of the items with the given prefix according
+// to the options and returns the cursor for the next page, if there's one.
+//
+// The cursor is the key of the last visited item with the prefix trimmed.
+func iteratePage(
+	txn *Transaction,
+	prefix []byte,
+	options storepkg.ListOptions,
+	fn func(item *badger.Item) error,
+) ([]byte, error) {
+	itOptions := badger.DefaultIteratorOptions
+	itOptions.Prefix = prefix
+	if options.Order == storepkg.ListOrderDesc {
+		itOptions.Reverse = true
+	}
+
+	it := txn.badgerTxn.NewIterator(itOptions)
+	defer it.Close()
+
+	cursor := options.Cursor
+	if len(cursor) > 0 {
+		if !bytes.HasPrefix(cursor, prefix) {
+			seekKey := make([]byte, 0, len(prefix)+len(cursor))
+			seekKey = append(seekKey, prefix...)
+			seekKey = append(seekKey, cursor...)
+			cursor = seekKey
+		}
+		it.Seek(cursor)
+		if it.ValidForPrefix(prefix) && bytes.Equal(it.Item().Key(), cursor) {
+			it.Next()
+		}
+	} else if options.Order == storepkg.ListOrderDesc {
+		it.Seek(append(bytes.Clone(prefix), 0xFF))
+	} else {
+		it.Rewind()
+	}
+
+	var visited int
+
+	for it.ValidForPrefix(prefix) {
+		item := it.Item()
+
+		if err := fn(item); err != nil {
+			return nil, err
+		}
+
+		visited++
+
+		if options.Limit > 0 && visited >= options.Limit {
+			lastKey := item.KeyCopy(nil)
+			it.Next()
+			if it.ValidForPrefix(prefix) {
+				return bytes.TrimPrefix(lastKey, prefix), nil
+			}
+
+			return nil, nil
+		}
+
+		it.Next()
+	}
+
+	return nil, nil
+}
+
 func genericDelete(txn *Transaction, key []byte) (err error) {
 	defer func() {
 		err = mapErr(err)
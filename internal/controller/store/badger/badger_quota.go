@@ -0,0 +1,29 @@
+package badger
+
+import (
+	"path"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceQuotas = "/quotas"
+
+func QuotaKey(name string) []byte {
+	return []byte(path.Join(SpaceQuotas, name))
+}
+
+func (txn *Transaction) GetQuota(name string) (*v1.Quota, error) {
+	return genericGet[v1.Quota](txn, QuotaKey(name))
+}
+
+func (txn *Transaction) SetQuota(quota v1.Quota) error {
+	return genericSet[v1.Quota](txn, QuotaKey(quota.Name), quota)
+}
+
+func (txn *Transaction) DeleteQuota(name string) error {
+	return genericDelete(txn, QuotaKey(name))
+}
+
+func (txn *Transaction) ListQuotas() ([]v1.Quota, error) {
+	return genericList[v1.Quota](txn, SpaceQuotas+"/")
+}
@@ -0,0 +1,38 @@
+package badger
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+var SchemaVersionKey = []byte("/schema-version")
+
+func (txn *Transaction) GetSchemaVersion() (_ uint64, err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	item, err := txn.badgerTxn.Get(SchemaVersionKey)
+	if err != nil {
+		// Databases created before the schema versioning
+		// was introduced have no schema version key
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	valueBytes, err := item.ValueCopy(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(string(valueBytes), 10, 64)
+}
+
+func (txn *Transaction) SetSchemaVersion(version uint64) error {
+	return mapErr(txn.badgerTxn.Set(SchemaVersionKey, []byte(strconv.FormatUint(version, 10))))
+}
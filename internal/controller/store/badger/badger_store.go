@@ -1,6 +1,7 @@
 package badger
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -13,7 +14,8 @@ import (
 )
 
 type Store struct {
-	db *badger.DB
+	db         *badger.DB
+	watchCache *watchCache
 	store.Store
 }
 
@@ -41,6 +43,19 @@ func NewBadgerStore(dbPath string, noCompression bool, logger *zap.SugaredLogger
 
 	store := &Store{
 		db: db,
+		watchCache: &watchCache{
+			subscribers: map[*watchSubscriber]struct{}{},
+		},
+	}
+
+	// Start the watch cache that powers the cluster-wide watches
+	go store.runWatchCache(context.Background(), logger)
+
+	waitCtx, waitCtxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer waitCtxCancel()
+
+	if err := store.waitWatchCache(waitCtx); err != nil {
+		return nil, fmt.Errorf("failed to start the watch cache: %w", err)
 	}
 
 	// Perform garbage collection periodically, as recommended in the documentation[1]
@@ -103,6 +118,10 @@ func mapErr(err error) error {
 			return store.ErrNotFound
 		}
 
+		if errors.Is(err, badger.ErrTxnTooBig) {
+			return fmt.Errorf("%w: %v", store.ErrTxnTooBig, err)
+		}
+
 		return fmt.Errorf("%w: %v", store.ErrStoreFailed, err)
 	}
 
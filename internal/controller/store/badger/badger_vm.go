@@ -4,27 +4,32 @@ package badger
 import (
 	"path"
 
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
 )
 
 const SpaceVMs = "/vms"
 
-func VMKey(name string) []byte {
-	return []byte(path.Join(SpaceVMs, name))
+func VMKey(namespace string, name string) []byte {
+	return []byte(path.Join(SpaceVMs, namespace, name))
 }
 
-func (txn *Transaction) GetVM(name string) (*v1.VM, error) {
-	return genericGet[v1.VM](txn, VMKey(name))
+func (txn *Transaction) GetVM(namespace string, name string) (*v1.VM, error) {
+	return genericGet[v1.VM](txn, VMKey(namespace, name))
 }
 
 func (txn *Transaction) SetVM(vm v1.VM) error {
-	return genericSet[v1.VM](txn, VMKey(vm.Name), vm)
+	return genericSet[v1.VM](txn, VMKey(vm.Namespace, vm.Name), vm)
 }
 
-func (txn *Transaction) DeleteVM(name string) error {
-	return genericDelete(txn, VMKey(name))
+func (txn *Transaction) DeleteVM(namespace string, name string) error {
+	return genericDelete(txn, VMKey(namespace, name))
 }
 
 func (txn *Transaction) ListVMs() ([]v1.VM, error) {
 	return genericList[v1.VM](txn, SpaceVMs)
 }
+
+func (txn *Transaction) ListVMsPage(options storepkg.ListOptions) (storepkg.Page[v1.VM], error) {
+	return genericListPage[v1.VM](txn, SpaceVMs+"/", options)
+}
@@ -0,0 +1,29 @@
+package badger
+
+import (
+	"path"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceVMGroups = "/vm-groups"
+
+func VMGroupKey(namespace string, name string) []byte {
+	return []byte(path.Join(SpaceVMGroups, namespace, name))
+}
+
+func (txn *Transaction) GetVMGroup(namespace string, name string) (*v1.VMGroup, error) {
+	return genericGet[v1.VMGroup](txn, VMGroupKey(namespace, name))
+}
+
+func (txn *Transaction) SetVMGroup(vmGroup v1.VMGroup) error {
+	return genericSet[v1.VMGroup](txn, VMGroupKey(vmGroup.Namespace, vmGroup.Name), vmGroup)
+}
+
+func (txn *Transaction) DeleteVMGroup(namespace string, name string) error {
+	return genericDelete(txn, VMGroupKey(namespace, name))
+}
+
+func (txn *Transaction) ListVMGroups() ([]v1.VMGroup, error) {
+	return genericList[v1.VMGroup](txn, SpaceVMGroups+"/")
+}
@@ -0,0 +1,29 @@
+package badger
+
+import (
+	"path"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceVMSets = "/vm-sets"
+
+func VMSetKey(namespace string, name string) []byte {
+	return []byte(path.Join(SpaceVMSets, namespace, name))
+}
+
+func (txn *Transaction) GetVMSet(namespace string, name string) (*v1.VMSet, error) {
+	return genericGet[v1.VMSet](txn, VMSetKey(namespace, name))
+}
+
+func (txn *Transaction) SetVMSet(vmSet v1.VMSet) error {
+	return genericSet[v1.VMSet](txn, VMSetKey(vmSet.Namespace, vmSet.Name), vmSet)
+}
+
+func (txn *Transaction) DeleteVMSet(namespace string, name string) error {
+	return genericDelete(txn, VMSetKey(namespace, name))
+}
+
+func (txn *Transaction) ListVMSets() ([]v1.VMSet, error) {
+	return genericList[v1.VMSet](txn, SpaceVMSets+"/")
+}
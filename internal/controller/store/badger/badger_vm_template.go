@@ -0,0 +1,29 @@
+package badger
+
+import (
+	"path"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceVMTemplates = "/vm-templates"
+
+func VMTemplateKey(name string) []byte {
+	return []byte(path.Join(SpaceVMTemplates, name))
+}
+
+func (txn *Transaction) GetVMTemplate(name string) (*v1.VMTemplate, error) {
+	return genericGet[v1.VMTemplate](txn, VMTemplateKey(name))
+}
+
+func (txn *Transaction) SetVMTemplate(vmTemplate v1.VMTemplate) error {
+	return genericSet[v1.VMTemplate](txn, VMTemplateKey(vmTemplate.Name), vmTemplate)
+}
+
+func (txn *Transaction) DeleteVMTemplate(name string) error {
+	return genericDelete(txn, VMTemplateKey(name))
+}
+
+func (txn *Transaction) ListVMTemplates() ([]v1.VMTemplate, error) {
+	return genericList[v1.VMTemplate](txn, SpaceVMTemplates+"/")
+}
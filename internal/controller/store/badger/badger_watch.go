@@ -13,7 +13,11 @@ import (
 	"github.com/dgraph-io/badger/v3/pb"
 )
 
-func (store *Store) WatchVM(ctx context.Context, vmName string) (chan storepkg.WatchMessage[v1.VM], chan error, error) {
+func (store *Store) WatchVM(
+	ctx context.Context,
+	namespace string,
+	vmName string,
+) (chan storepkg.WatchMessage[v1.VM], chan error, error) {
 	readyCh := make(chan struct{}, 1)
 	watchCh := make(chan storepkg.WatchMessage[v1.VM], 1)
 	errCh := make(chan error, 1)
@@ -42,7 +46,7 @@ func (store *Store) WatchVM(ctx context.Context, vmName string) (chan storepkg.W
 				err := store.View(func(txn storepkg.Transaction) error {
 					var err error
 
-					initialVM, err = txn.GetVM(vmName)
+					initialVM, err = txn.GetVM(namespace, vmName)
 
 					return err
 				})
@@ -70,7 +74,7 @@ func (store *Store) WatchVM(ctx context.Context, vmName string) (chan storepkg.W
 				case bytes.Equal(kv.GetKey(), WatchBarrierKey()):
 					// We only need watch barriers so that the Subscribe()'s callback
 					// is called at least once, thus we can simply do nothing here
-				case bytes.Equal(kv.GetKey(), VMKey(vmName)):
+				case bytes.Equal(kv.GetKey(), VMKey(namespace, vmName)):
 					// Skip all KVs with versions before or equal
 					// to the initial VM's version, if any
 					if initialVM != nil && kv.GetVersion() <= initialVM.Version {
@@ -138,7 +142,7 @@ func (store *Store) WatchVM(ctx context.Context, vmName string) (chan storepkg.W
 				Prefix: WatchBarrierKey(),
 			},
 			{
-				Prefix: VMKey(vmName),
+				Prefix: VMKey(namespace, vmName),
 			},
 		}); err != nil {
 			errCh <- err
@@ -0,0 +1,285 @@
+package badger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/badger/v3/pb"
+	"go.uber.org/zap"
+)
+
+const (
+	// watchCacheSize is the number of the most recent changes kept
+	// in memory to be able to resume watches from a resource version
+	watchCacheSize = 16384
+
+	// watchSubscriberBufferSize is the number of changes that can be
+	// queued for a single watcher before it's considered to be too slow
+	watchSubscriberBufferSize = 1024
+)
+
+// watchCachePrefixes are the key prefixes of
+// resources that can be watched cluster-wide
+var watchCachePrefixes = []string{
+	SpaceVMs + "/",
+	SpaceWorkers + "/",
+	SpaceServiceAccounts + "/",
+}
+
+type watchEvent struct {
+	Type    storepkg.WatchMessageType
+	Key     string
+	Value   []byte
+	Version uint64
+
+	// PrevValue is the last known value of a deleted key
+	PrevValue []byte
+}
+
+type watchSubscriber struct {
+	prefix string
+	ch     chan watchEvent
+}
+
+// watchCache implements cluster-wide watches on top of a single Badger
+// subscription, keeping the most recent changes in memory.
+//
+// Badger doesn't retain the history of changes (particularly the deletions),
+// so this is what allows resuming the watches from a resource version.
+type watchCache struct {
+	mtx sync.Mutex
+
+	// startVersion is the version after which all of the changes
+	// are either in the events or were evicted, in which case it
+	// is bumped to the version of the latest evicted event
+	startVersion uint64
+	ready        bool
+	events       []watchEvent
+	subscribers  map[*watchSubscriber]struct{}
+
+	// values are the current values of the existing keys, which
+	// lets us distinguish between the created and modified resources
+	// in the Badger's subscription and to provide the last known
+	// state of the deleted resources
+	values map[string][]byte
+}
+
+func (store *Store) runWatchCache(ctx context.Context, logger *zap.SugaredLogger) {
+	matches := []pb.Match{
+		{
+			Prefix: WatchBarrierKey(),
+		},
+	}
+
+	for _, prefix := range watchCachePrefixes {
+		matches = append(matches, pb.Match{
+			Prefix: []byte(prefix),
+		})
+	}
+
+	for {
+		err := store.db.Subscribe(ctx, func(kvList *badger.KVList) error {
+			return store.watchCache.process(store, kvList)
+		}, matches)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Errorf("watch cache subscription failed, re-subscribing: %v", err)
+
+		store.watchCache.reset()
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// waitWatchCache blocks until the watch cache's subscription is established.
+func (store *Store) waitWatchCache(ctx context.Context) error {
+	for {
+		if store.watchCache.isReady() {
+			return nil
+		}
+
+		// Trigger the watch barrier so that Subscribe() callback gets invoked
+		if err := store.notifyWatchBarrier(); err != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (cache *watchCache) isReady() bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	return cache.ready
+}
+
+func (cache *watchCache) reset() {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	cache.ready = false
+	cache.events = nil
+	cache.values = nil
+
+	// Terminate all watches as they might've missed some changes
+	for subscriber := range cache.subscribers {
+		close(subscriber.ch)
+		delete(cache.subscribers, subscriber)
+	}
+}
+
+func (cache *watchCache) process(store *Store, kvList *badger.KVList) error {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if !cache.ready {
+		// Now that the subscription has started, take a snapshot of the existing
+		// keys, all the changes after it are guaranteed to be observed
+		if err := store.db.View(func(txn *badger.Txn) error {
+			cache.startVersion = txn.ReadTs()
+			cache.values = map[string][]byte{}
+
+			it := txn.NewIterator(badger.IteratorOptions{})
+			defer it.Close()
+
+			for _, prefix := range watchCachePrefixes {
+				for it.Seek([]byte(prefix)); it.ValidForPrefix([]byte(prefix)); it.Next() {
+					value, err := it.Item().ValueCopy(nil)
+					if err != nil {
+						return err
+					}
+
+					cache.values[string(it.Item().KeyCopy(nil))] = value
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		cache.ready = true
+	}
+
+	for _, kv := range kvList.GetKv() {
+		// We only need watch barriers so that the Subscribe()'s
+		// callback is called at least once, so ignore them
+		if bytes.Equal(kv.GetKey(), WatchBarrierKey()) {
+			continue
+		}
+
+		// Ignore the changes already reflected in the snapshot
+		if kv.GetVersion() <= cache.startVersion {
+			continue
+		}
+
+		key := string(kv.GetKey())
+		prevValue, exists := cache.values[key]
+
+		event := watchEvent{
+			Key:     key,
+			Value:   kv.GetValue(),
+			Version: kv.GetVersion(),
+		}
+
+		switch {
+		case event.Value == nil && !exists:
+			// Already deleted
+			continue
+		case event.Value == nil:
+			event.Type = storepkg.WatchMessageTypeDeleted
+			event.PrevValue = prevValue
+			delete(cache.values, key)
+		case exists:
+			event.Type = storepkg.WatchMessageTypeModified
+			cache.values[key] = event.Value
+		default:
+			event.Type = storepkg.WatchMessageTypeAdded
+			cache.values[key] = event.Value
+		}
+
+		cache.append(event)
+	}
+
+	return nil
+}
+
+func (cache *watchCache) append(event watchEvent) {
+	if len(cache.events) == watchCacheSize {
+		cache.startVersion = cache.events[0].Version
+		cache.events = cache.events[1:]
+	}
+
+	cache.events = append(cache.events, event)
+
+	for subscriber := range cache.subscribers {
+		if !strings.HasPrefix(event.Key, subscriber.prefix) {
+			continue
+		}
+
+		select {
+		case subscriber.ch <- event:
+		default:
+			// Subscriber is too slow, terminate its watch
+			// instead of blocking all other subscribers
+			close(subscriber.ch)
+			delete(cache.subscribers, subscriber)
+		}
+	}
+}
+
+// subscribe returns the cached changes to the keys with the given prefix
+// that have happened after the resourceVersion and subscribes to the new ones.
+func (cache *watchCache) subscribe(prefix string, resourceVersion uint64) ([]watchEvent, *watchSubscriber, error) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if !cache.ready || resourceVersion < cache.startVersion {
+		return nil, nil, storepkg.ErrWatchExpired
+	}
+
+	var backlog []watchEvent
+
+	for _, event := range cache.events {
+		if event.Version > resourceVersion && strings.HasPrefix(event.Key, prefix) {
+			backlog = append(backlog, event)
+		}
+	}
+
+	subscriber := &watchSubscriber{
+		prefix: prefix,
+		ch:     make(chan watchEvent, watchSubscriberBufferSize),
+	}
+
+	cache.subscribers[subscriber] = struct{}{}
+
+	return backlog, subscriber, nil
+}
+
+func (cache *watchCache) unsubscribe(subscriber *watchSubscriber) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if _, ok := cache.subscribers[subscriber]; !ok {
+		return
+	}
+
+	close(subscriber.ch)
+	delete(cache.subscribers, subscriber)
+}
@@ -0,0 +1,166 @@
+package badger
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/dgraph-io/badger/v3"
+)
+
+func (store *Store) WatchVMs(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.VM], chan error, error) {
+	return watchPrefix[v1.VM](ctx, store, SpaceVMs+"/", resourceVersion, (*Transaction).ListVMs)
+}
+
+func (store *Store) WatchWorkers(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.Worker], chan error, error) {
+	return watchPrefix[v1.Worker](ctx, store, SpaceWorkers+"/", resourceVersion, (*Transaction).ListWorkers)
+}
+
+func (store *Store) WatchServiceAccounts(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.ServiceAccount], chan error, error) {
+	return watchPrefix[v1.ServiceAccount](ctx, store, SpaceServiceAccounts+"/", resourceVersion,
+		(*Transaction).ListServiceAccounts)
+}
+
+func watchPrefix[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](
+	ctx context.Context,
+	store *Store,
+	prefix string,
+	resourceVersion uint64,
+	list func(txn *Transaction) ([]T, error),
+) (chan storepkg.WatchMessage[T], chan error, error) {
+	var initialObjects []T
+
+	// Start with the current objects when not resuming
+	if resourceVersion == 0 {
+		if err := store.db.View(func(badgerTxn *badger.Txn) error {
+			var err error
+
+			initialObjects, err = list(&Transaction{badgerTxn: badgerTxn})
+			resourceVersion = badgerTxn.ReadTs()
+
+			return err
+		}); err != nil {
+			return nil, nil, mapErr(err)
+		}
+	}
+
+	backlog, subscriber, err := store.watchCache.subscribe(prefix, resourceVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watchCh := make(chan storepkg.WatchMessage[T], 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(watchCh)
+		defer store.watchCache.unsubscribe(subscriber)
+
+		send := func(message storepkg.WatchMessage[T]) bool {
+			select {
+			case watchCh <- message:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		sendEvent := func(event watchEvent) bool {
+			message, err := watchEventToMessage[T, PT](prefix, event)
+			if err != nil {
+				errCh <- err
+
+				return false
+			}
+
+			return send(message)
+		}
+
+		for _, obj := range initialObjects {
+			if !send(storepkg.WatchMessage[T]{Type: storepkg.WatchMessageTypeAdded, Object: obj}) {
+				return
+			}
+		}
+
+		for _, event := range backlog {
+			if !sendEvent(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-subscriber.ch:
+				if !ok {
+					// Watch cache has terminated our subscription
+					errCh <- storepkg.ErrWatchExpired
+
+					return
+				}
+
+				// Skip changes already observed by the caller
+				if event.Version <= resourceVersion {
+					continue
+				}
+
+				if !sendEvent(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return watchCh, errCh, nil
+}
+
+func watchEventToMessage[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](prefix string, event watchEvent) (storepkg.WatchMessage[T], error) {
+	message := storepkg.WatchMessage[T]{
+		Type: event.Type,
+	}
+
+	value := event.Value
+
+	if event.Type == storepkg.WatchMessageTypeDeleted {
+		if event.PrevValue == nil {
+			obj, err := storepkg.DeletedObject[T, PT](strings.TrimPrefix(event.Key, prefix), event.Version)
+			if err != nil {
+				return message, err
+			}
+
+			message.Object = obj
+
+			return message, nil
+		}
+
+		// Provide the last known state of the deleted object
+		value = event.PrevValue
+	}
+
+	if err := json.Unmarshal(value, &message.Object); err != nil {
+		return message, err
+	}
+
+	PT(&message.Object).SetVersion(event.Version)
+
+	return message, nil
+}
@@ -4,6 +4,7 @@ package badger
 import (
 	"path"
 
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
 )
 
@@ -28,3 +29,7 @@ func (txn *Transaction) DeleteWorker(name string) error {
 func (txn *Transaction) ListWorkers() ([]v1.Worker, error) {
 	return genericList[v1.Worker](txn, SpaceWorkers)
 }
+
+func (txn *Transaction) ListWorkersPage(options storepkg.ListOptions) (storepkg.Page[v1.Worker], error) {
+	return genericListPage[v1.Worker](txn, SpaceWorkers+"/", options)
+}
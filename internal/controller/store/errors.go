@@ -5,7 +5,9 @@ import (
 )
 
 var (
-	ErrNotFound    = errors.New("store entry not found")
-	ErrConflict    = errors.New("store conflict")
-	ErrStoreFailed = errors.New("store failed")
+	ErrNotFound     = errors.New("store entry not found")
+	ErrConflict     = errors.New("store conflict")
+	ErrStoreFailed  = errors.New("store failed")
+	ErrWatchExpired = errors.New("watch expired: requested resource version is too old")
+	ErrTxnTooBig    = errors.New("store transaction is too big")
 )
@@ -0,0 +1,90 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceEvents = "/events"
+
+func scopePrefix(scope []string) string {
+	keyParts := []string{SpaceEvents}
+	keyParts = append(keyParts, scope...)
+	return path.Join(keyParts...)
+}
+
+func (txn *Transaction) AppendEvents(events []v1.Event, scope ...string) (err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	injectionTime := time.Now().UnixNano()
+
+	for index, event := range events {
+		valueBytes, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		eventUID := fmt.Sprintf("/%d-%d-%06d",
+			event.Timestamp,
+			injectionTime, // to preserve order in case two sequential batches have events with the same timestamp
+			index,         // to preserve order in case a batch of events has some events with the same timestamp
+		)
+
+		txn.put(scopePrefix(scope)+eventUID, valueBytes)
+	}
+
+	return nil
+}
+
+func (txn *Transaction) ListEvents(scope ...string) (_ []v1.Event, err error) {
+	page, err := txn.ListEventsPage(storepkg.ListOptions{}, scope...)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Items, nil
+}
+
+func (txn *Transaction) ListEventsPage(options storepkg.ListOptions, scope ...string) (
+	result storepkg.Page[v1.Event],
+	err error,
+) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	// Declare an empty, non-nil slice to
+	// return [] when no events are found
+	result.Items = []v1.Event{}
+
+	kvs, nextCursor, err := txn.listPage(scopePrefix(scope), options)
+	if err != nil {
+		return result, err
+	}
+
+	for _, kv := range kvs {
+		var event v1.Event
+
+		if err := json.Unmarshal(kv.Value, &event); err != nil {
+			return result, err
+		}
+
+		result.Items = append(result.Items, event)
+	}
+
+	result.NextCursor = nextCursor
+
+	return result, nil
+}
+
+func (txn *Transaction) DeleteEvents(scope ...string) error {
+	txn.deletePrefix(scopePrefix(scope))
+
+	return nil
+}
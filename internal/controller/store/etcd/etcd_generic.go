@@ -0,0 +1,119 @@
+package etcd
+
+import (
+	"encoding/json"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+)
+
+func genericSet[T any](txn *Transaction, key string, obj T) (err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	valueBytes, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	txn.put(key, valueBytes)
+
+	return nil
+}
+
+func genericGet[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](txn *Transaction, key string) (_ *T, err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	kv, err := txn.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj T
+
+	err = json.Unmarshal(kv.Value, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	PT(&obj).SetVersion(uint64(kv.ModRevision))
+
+	return &obj, nil
+}
+
+func genericList[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](txn *Transaction, prefix string) (_ []T, err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	// Declare an empty, non-nil slice to
+	// return [] when no objects are found
+	result := []T{}
+
+	kvs, err := txn.listWithWrites(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kv := range kvs {
+		var obj T
+
+		if err := json.Unmarshal(kv.Value, &obj); err != nil {
+			return nil, err
+		}
+
+		PT(&obj).SetVersion(uint64(kv.ModRevision))
+
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+func genericListPage[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](txn *Transaction, prefix string, options storepkg.ListOptions) (result storepkg.Page[T], err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	// Declare an empty, non-nil slice to
+	// return [] when no objects are found
+	result.Items = []T{}
+
+	kvs, nextCursor, err := txn.listPage(prefix, options)
+	if err != nil {
+		return result, err
+	}
+
+	for _, kv := range kvs {
+		var obj T
+
+		if err := json.Unmarshal(kv.Value, &obj); err != nil {
+			return result, err
+		}
+
+		PT(&obj).SetVersion(uint64(kv.ModRevision))
+
+		result.Items = append(result.Items, obj)
+	}
+
+	result.NextCursor = nextCursor
+
+	return result, nil
+}
+
+func genericDelete(txn *Transaction, key string) error {
+	txn.delete(key)
+
+	return nil
+}
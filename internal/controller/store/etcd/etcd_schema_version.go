@@ -0,0 +1,35 @@
+package etcd
+
+import (
+	"errors"
+	"strconv"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+)
+
+const SchemaVersionKey = "/schema-version"
+
+func (txn *Transaction) GetSchemaVersion() (_ uint64, err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	kv, err := txn.get(SchemaVersionKey)
+	if err != nil {
+		// Databases created before the schema versioning
+		// was introduced have no schema version key
+		if errors.Is(err, storepkg.ErrNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return strconv.ParseUint(string(kv.Value), 10, 64)
+}
+
+func (txn *Transaction) SetSchemaVersion(version uint64) error {
+	txn.put(SchemaVersionKey, []byte(strconv.FormatUint(version, 10)))
+
+	return nil
+}
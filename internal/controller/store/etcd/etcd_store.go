@@ -0,0 +1,371 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const transactionTimeout = 30 * time.Second
+
+type Store struct {
+	client *clientv3.Client
+	logger *zap.SugaredLogger
+	storepkg.Store
+}
+
+// Transaction implements optimistic concurrency control on top of etcd.
+//
+// All reads are performed against a single revision of the key-value store
+// (the one observed by the first read) and all writes are buffered
+// and committed atomically when the transaction callback returns.
+//
+// When committing, the transaction verifies that none of the keys that
+// were read had been modified since that revision, otherwise the whole
+// transaction is retried by the Store.
+type Transaction struct {
+	ctx      context.Context
+	client   *clientv3.Client
+	readOnly bool
+
+	revision int64
+	compares []clientv3.Cmp
+
+	// writes and deletedPrefixes are buffered until the commit and are also
+	// used to provide read-your-writes semantics, a nil value in writes
+	// means that the key was deleted
+	writes          map[string][]byte
+	deletedPrefixes []string
+
+	storepkg.Transaction
+}
+
+func NewEtcdStore(client *clientv3.Client, logger *zap.SugaredLogger) (storepkg.Store, error) {
+	// Make sure that the etcd cluster is reachable
+	ctx, cancel := context.WithTimeout(context.Background(), transactionTimeout)
+	defer cancel()
+
+	if _, err := client.Get(ctx, ClusterSettingsKey); err != nil {
+		return nil, fmt.Errorf("%w: failed to connect to etcd: %v", storepkg.ErrStoreFailed, err)
+	}
+
+	return &Store{
+		client: client,
+		logger: logger.With("component", "etcd"),
+	}, nil
+}
+
+func (store *Store) View(cb func(txn storepkg.Transaction) error) error {
+	return store.transaction(true, cb)
+}
+
+func (store *Store) Update(cb func(txn storepkg.Transaction) error) error {
+	return retry.Do(func() error {
+		return store.transaction(false, cb)
+	}, retry.RetryIf(func(err error) bool {
+		return errors.Is(err, storepkg.ErrConflict)
+	}), retry.Attempts(3), retry.LastErrorOnly(true))
+}
+
+func (store *Store) transaction(readOnly bool, cb func(txn storepkg.Transaction) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), transactionTimeout)
+	defer cancel()
+
+	txn := &Transaction{
+		ctx:      ctx,
+		client:   store.client,
+		readOnly: readOnly,
+		writes:   map[string][]byte{},
+	}
+
+	if err := cb(txn); err != nil {
+		return err
+	}
+
+	return txn.commit()
+}
+
+func (txn *Transaction) commit() (err error) {
+	defer func() {
+		err = mapErr(err)
+	}()
+
+	if txn.readOnly || (len(txn.writes) == 0 && len(txn.deletedPrefixes) == 0) {
+		return nil
+	}
+
+	var ops []clientv3.Op
+
+	for _, prefix := range txn.deletedPrefixes {
+		ops = append(ops, clientv3.OpDelete(prefix, clientv3.WithPrefix()))
+	}
+
+	for key, value := range txn.writes {
+		if value == nil {
+			ops = append(ops, clientv3.OpDelete(key))
+		} else {
+			ops = append(ops, clientv3.OpPut(key, string(value)))
+		}
+	}
+
+	response, err := txn.client.Txn(txn.ctx).If(txn.compares...).Then(ops...).Commit()
+	if err != nil {
+		return err
+	}
+
+	if !response.Succeeded {
+		return storepkg.ErrConflict
+	}
+
+	return nil
+}
+
+func (txn *Transaction) readOptions(opts ...clientv3.OpOption) []clientv3.OpOption {
+	if txn.revision != 0 {
+		opts = append(opts, clientv3.WithRev(txn.revision))
+	}
+
+	return opts
+}
+
+func (txn *Transaction) rememberRevision(response *clientv3.GetResponse) {
+	if txn.revision == 0 {
+		txn.revision = response.Header.Revision
+	}
+}
+
+func (txn *Transaction) get(key string) (*mvccpb.KeyValue, error) {
+	// Read-your-writes
+	if value, ok := txn.writes[key]; ok {
+		if value == nil {
+			return nil, storepkg.ErrNotFound
+		}
+
+		return &mvccpb.KeyValue{
+			Key:   []byte(key),
+			Value: value,
+		}, nil
+	}
+	if txn.deletedByPrefix(key) {
+		return nil, storepkg.ErrNotFound
+	}
+
+	response, err := txn.client.Get(txn.ctx, key, txn.readOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	txn.rememberRevision(response)
+
+	var modRevision int64
+
+	if len(response.Kvs) != 0 {
+		modRevision = response.Kvs[0].ModRevision
+	}
+
+	// Make sure that the key is not modified
+	// (or created, if it doesn't exist yet)
+	// by the time we commit
+	txn.compares = append(txn.compares, clientv3.Compare(clientv3.ModRevision(key), "=", modRevision))
+
+	if len(response.Kvs) == 0 {
+		return nil, storepkg.ErrNotFound
+	}
+
+	return response.Kvs[0], nil
+}
+
+func (txn *Transaction) list(prefix string, opts ...clientv3.OpOption) ([]*mvccpb.KeyValue, error) {
+	opts = append(opts, clientv3.WithPrefix())
+
+	response, err := txn.client.Get(txn.ctx, prefix, txn.readOptions(opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	txn.rememberRevision(response)
+
+	// Make sure that no keys are created or modified
+	// under this prefix by the time we commit
+	//
+	// Note that this won't detect deletions, similarly
+	// to how Badger doesn't detect phantom reads.
+	txn.compares = append(txn.compares, clientv3.Compare(clientv3.ModRevision(prefix).WithPrefix(),
+		"<", txn.revision+1))
+
+	return response.Kvs, nil
+}
+
+// listPage lists the keys with the given prefix according to the options
+// and returns the cursor for the next page, if there's one.
+//
+// The cursor is the last returned key with the prefix trimmed.
+func (txn *Transaction) listPage(
+	prefix string,
+	options storepkg.ListOptions,
+) ([]*mvccpb.KeyValue, []byte, error) {
+	start := prefix
+	end := clientv3.GetPrefixRangeEnd(prefix)
+
+	cursor := string(options.Cursor)
+	if cursor != "" && !strings.HasPrefix(cursor, prefix) {
+		cursor = prefix + cursor
+	}
+
+	opts := []clientv3.OpOption{}
+
+	if options.Order == storepkg.ListOrderDesc {
+		if cursor != "" {
+			// Range end is exclusive
+			end = cursor
+		}
+
+		opts = append(opts, clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend))
+	} else {
+		if cursor != "" {
+			// Range start is inclusive, so start
+			// with the next key after the cursor
+			start = cursor + "\x00"
+		}
+
+		opts = append(opts, clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	}
+
+	opts = append(opts, clientv3.WithRange(end))
+
+	if options.Limit > 0 {
+		// Request one more key to figure out
+		// whether there's a next page or not
+		opts = append(opts, clientv3.WithLimit(int64(options.Limit)+1))
+	}
+
+	response, err := txn.client.Get(txn.ctx, start, txn.readOptions(opts...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	txn.rememberRevision(response)
+
+	kvs := response.Kvs
+
+	if options.Limit > 0 && len(kvs) > options.Limit {
+		kvs = kvs[:options.Limit]
+		lastKey := string(kvs[len(kvs)-1].Key)
+
+		return kvs, []byte(strings.TrimPrefix(lastKey, prefix)), nil
+	}
+
+	return kvs, nil, nil
+}
+
+func (txn *Transaction) listWithWrites(prefix string) ([]*mvccpb.KeyValue, error) {
+	kvs, err := txn.list(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txn.writes) == 0 && len(txn.deletedPrefixes) == 0 {
+		return kvs, nil
+	}
+
+	// Read-your-writes
+	var result []*mvccpb.KeyValue
+
+	seen := map[string]struct{}{}
+
+	for _, kv := range kvs {
+		seen[string(kv.Key)] = struct{}{}
+
+		value, ok := txn.writes[string(kv.Key)]
+		if !ok {
+			if !txn.deletedByPrefix(string(kv.Key)) {
+				result = append(result, kv)
+			}
+
+			continue
+		}
+
+		if value == nil {
+			continue
+		}
+
+		result = append(result, &mvccpb.KeyValue{
+			Key:   kv.Key,
+			Value: value,
+		})
+	}
+
+	for key, value := range txn.writes {
+		if _, ok := seen[key]; ok || value == nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		result = append(result, &mvccpb.KeyValue{
+			Key:   []byte(key),
+			Value: value,
+		})
+	}
+
+	// Preserve the key order, just like etcd does
+	slices.SortFunc(result, func(a, b *mvccpb.KeyValue) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+
+	return result, nil
+}
+
+func (txn *Transaction) put(key string, value []byte) {
+	txn.writes[key] = value
+}
+
+func (txn *Transaction) delete(key string) {
+	txn.writes[key] = nil
+}
+
+func (txn *Transaction) deletePrefix(prefix string) {
+	// etcd doesn't allow a key to be both put and deleted
+	// in the same transaction, so forget about the writes
+	// that will be deleted anyway
+	for key := range txn.writes {
+		if strings.HasPrefix(key, prefix) {
+			delete(txn.writes, key)
+		}
+	}
+
+	txn.deletedPrefixes = append(txn.deletedPrefixes, prefix)
+}
+
+func (txn *Transaction) deletedByPrefix(key string) bool {
+	for _, prefix := range txn.deletedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mapErr(err error) error {
+	if err != nil {
+		if errors.Is(err, storepkg.ErrNotFound) || errors.Is(err, storepkg.ErrConflict) ||
+			errors.Is(err, storepkg.ErrStoreFailed) {
+			return err
+		}
+
+		if errors.Is(err, rpctypes.ErrTooManyOps) || errors.Is(err, rpctypes.ErrRequestTooLarge) {
+			return fmt.Errorf("%w: %v", storepkg.ErrTxnTooBig, err)
+		}
+
+		return fmt.Errorf("%w: %v", storepkg.ErrStoreFailed, err)
+	}
+
+	return err
+}
@@ -0,0 +1,35 @@
+//nolint:dupl // maybe we'll figure out how to make DB resource accessors generic in the future
+package etcd
+
+import (
+	"path"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceVMs = "/vms"
+
+func VMKey(namespace string, name string) string {
+	return path.Join(SpaceVMs, namespace, name)
+}
+
+func (txn *Transaction) GetVM(namespace string, name string) (*v1.VM, error) {
+	return genericGet[v1.VM](txn, VMKey(namespace, name))
+}
+
+func (txn *Transaction) SetVM(vm v1.VM) error {
+	return genericSet[v1.VM](txn, VMKey(vm.Namespace, vm.Name), vm)
+}
+
+func (txn *Transaction) DeleteVM(namespace string, name string) error {
+	return genericDelete(txn, VMKey(namespace, name))
+}
+
+func (txn *Transaction) ListVMs() ([]v1.VM, error) {
+	return genericList[v1.VM](txn, SpaceVMs+"/")
+}
+
+func (txn *Transaction) ListVMsPage(options storepkg.ListOptions) (storepkg.Page[v1.VM], error) {
+	return genericListPage[v1.VM](txn, SpaceVMs+"/", options)
+}
@@ -0,0 +1,29 @@
+package etcd
+
+import (
+	"path"
+
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+const SpaceVMPools = "/pools"
+
+func VMPoolKey(namespace string, name string) string {
+	return path.Join(SpaceVMPools, namespace, name)
+}
+
+func (txn *Transaction) GetVMPool(namespace string, name string) (*v1.VMPool, error) {
+	return genericGet[v1.VMPool](txn, VMPoolKey(namespace, name))
+}
+
+func (txn *Transaction) SetVMPool(vmPool v1.VMPool) error {
+	return genericSet[v1.VMPool](txn, VMPoolKey(vmPool.Namespace, vmPool.Name), vmPool)
+}
+
+func (txn *Transaction) DeleteVMPool(namespace string, name string) error {
+	return genericDelete(txn, VMPoolKey(namespace, name))
+}
+
+func (txn *Transaction) ListVMPools() ([]v1.VMPool, error) {
+	return genericList[v1.VMPool](txn, SpaceVMPools+"/")
+}
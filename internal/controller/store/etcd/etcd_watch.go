@@ -0,0 +1,125 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (store *Store) WatchVM(
+	ctx context.Context,
+	namespace string,
+	vmName string,
+) (chan storepkg.WatchMessage[v1.VM], chan error, error) {
+	// Retrieve the initial VM, if any, and remember the revision
+	// at which it was retrieved to start watching right after it
+	response, err := store.client.Get(ctx, VMKey(namespace, vmName))
+	if err != nil {
+		return nil, nil, mapErr(err)
+	}
+
+	var initialVM *v1.VM
+
+	if len(response.Kvs) != 0 {
+		var vm v1.VM
+
+		if err := json.Unmarshal(response.Kvs[0].Value, &vm); err != nil {
+			return nil, nil, err
+		}
+
+		vm.Version = uint64(response.Kvs[0].ModRevision)
+
+		initialVM = &vm
+	}
+
+	watchCh := make(chan storepkg.WatchMessage[v1.VM], 1)
+	errCh := make(chan error, 1)
+	subCtx, subCtxCancel := context.WithCancel(ctx)
+
+	// Require leader to avoid hanging forever on a partitioned etcd member
+	etcdWatchCh := store.client.Watch(clientv3.WithRequireLeader(subCtx), VMKey(namespace, vmName),
+		clientv3.WithRev(response.Header.Revision+1))
+
+	go func() {
+		defer subCtxCancel()
+		defer close(watchCh)
+		defer close(errCh)
+
+		send := func(notification storepkg.WatchMessage[v1.VM]) bool {
+			select {
+			case watchCh <- notification:
+				return true
+			case <-subCtx.Done():
+				return false
+			}
+		}
+
+		if initialVM != nil {
+			if !send(storepkg.WatchMessage[v1.VM]{
+				Type:   storepkg.WatchMessageTypeAdded,
+				Object: *initialVM,
+			}) {
+				return
+			}
+		}
+
+		for watchResponse := range etcdWatchCh {
+			if err := watchResponse.Err(); err != nil {
+				errCh <- mapErr(err)
+
+				return
+			}
+
+			for _, event := range watchResponse.Events {
+				if event.Type == clientv3.EventTypeDelete {
+					if initialVM == nil {
+						// VM is already deleted
+						continue
+					}
+
+					// VM was deleted
+					if !send(storepkg.WatchMessage[v1.VM]{
+						Type: storepkg.WatchMessageTypeDeleted,
+					}) {
+						return
+					}
+
+					initialVM = nil
+
+					continue
+				}
+
+				// VM was created or modified
+				var vm v1.VM
+
+				if err := json.Unmarshal(event.Kv.Value, &vm); err != nil {
+					errCh <- err
+
+					return
+				}
+
+				vm.Version = uint64(event.Kv.ModRevision)
+
+				watchMessageType := storepkg.WatchMessageTypeModified
+
+				if initialVM == nil {
+					watchMessageType = storepkg.WatchMessageTypeAdded
+
+					initialVM = &vm
+				}
+
+				if !send(storepkg.WatchMessage[v1.VM]{
+					Type:   watchMessageType,
+					Object: vm,
+				}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return watchCh, errCh, nil
+}
@@ -0,0 +1,168 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (store *Store) WatchVMs(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.VM], chan error, error) {
+	return watchPrefix[v1.VM](ctx, store, SpaceVMs+"/", resourceVersion, (*Transaction).ListVMs)
+}
+
+func (store *Store) WatchWorkers(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.Worker], chan error, error) {
+	return watchPrefix[v1.Worker](ctx, store, SpaceWorkers+"/", resourceVersion, (*Transaction).ListWorkers)
+}
+
+func (store *Store) WatchServiceAccounts(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan storepkg.WatchMessage[v1.ServiceAccount], chan error, error) {
+	return watchPrefix[v1.ServiceAccount](ctx, store, SpaceServiceAccounts+"/", resourceVersion,
+		(*Transaction).ListServiceAccounts)
+}
+
+func watchPrefix[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](
+	ctx context.Context,
+	store *Store,
+	prefix string,
+	resourceVersion uint64,
+	list func(txn *Transaction) ([]T, error),
+) (chan storepkg.WatchMessage[T], chan error, error) {
+	var initialObjects []T
+
+	// Start with the current objects when not resuming
+	// and remember the revision at which they were retrieved
+	// to start watching right after it
+	if resourceVersion == 0 {
+		txn := &Transaction{
+			ctx:      ctx,
+			client:   store.client,
+			readOnly: true,
+			writes:   map[string][]byte{},
+		}
+
+		var err error
+
+		initialObjects, err = list(txn)
+		if err != nil {
+			return nil, nil, mapErr(err)
+		}
+
+		resourceVersion = uint64(txn.revision)
+	}
+
+	watchCh := make(chan storepkg.WatchMessage[T], 1)
+	errCh := make(chan error, 1)
+	subCtx, subCtxCancel := context.WithCancel(ctx)
+
+	// Require leader to avoid hanging forever on a partitioned etcd member
+	etcdWatchCh := store.client.Watch(clientv3.WithRequireLeader(subCtx), prefix, clientv3.WithPrefix(),
+		clientv3.WithRev(int64(resourceVersion)+1), clientv3.WithPrevKV())
+
+	go func() {
+		defer subCtxCancel()
+		defer close(watchCh)
+		defer close(errCh)
+
+		send := func(message storepkg.WatchMessage[T]) bool {
+			select {
+			case watchCh <- message:
+				return true
+			case <-subCtx.Done():
+				return false
+			}
+		}
+
+		for _, obj := range initialObjects {
+			if !send(storepkg.WatchMessage[T]{Type: storepkg.WatchMessageTypeAdded, Object: obj}) {
+				return
+			}
+		}
+
+		for watchResponse := range etcdWatchCh {
+			if err := watchResponse.Err(); err != nil {
+				// The requested revision was compacted
+				if errors.Is(err, rpctypes.ErrCompacted) || watchResponse.CompactRevision != 0 {
+					errCh <- storepkg.ErrWatchExpired
+				} else {
+					errCh <- mapErr(err)
+				}
+
+				return
+			}
+
+			for _, event := range watchResponse.Events {
+				message, err := watchEventToMessage[T, PT](prefix, event)
+				if err != nil {
+					errCh <- err
+
+					return
+				}
+
+				if !send(message) {
+					return
+				}
+			}
+		}
+	}()
+
+	return watchCh, errCh, nil
+}
+
+func watchEventToMessage[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](prefix string, event *clientv3.Event) (storepkg.WatchMessage[T], error) {
+	var message storepkg.WatchMessage[T]
+
+	version := uint64(event.Kv.ModRevision)
+
+	value := event.Kv.Value
+
+	switch {
+	case event.Type == clientv3.EventTypeDelete:
+		message.Type = storepkg.WatchMessageTypeDeleted
+
+		if event.PrevKv == nil {
+			obj, err := storepkg.DeletedObject[T, PT](strings.TrimPrefix(string(event.Kv.Key), prefix), version)
+			if err != nil {
+				return message, err
+			}
+
+			message.Object = obj
+
+			return message, nil
+		}
+
+		// Provide the last known state of the deleted object
+		value = event.PrevKv.Value
+	case event.IsCreate():
+		message.Type = storepkg.WatchMessageTypeAdded
+	default:
+		message.Type = storepkg.WatchMessageTypeModified
+	}
+
+	if err := json.Unmarshal(value, &message.Object); err != nil {
+		return message, err
+	}
+
+	PT(&message.Object).SetVersion(version)
+
+	return message, nil
+}
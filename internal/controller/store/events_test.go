@@ -5,15 +5,46 @@ import (
 
 	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	"github.com/cirruslabs/orchard/internal/controller/store/etcd"
+	"github.com/cirruslabs/orchard/internal/tests/embeddedetcd"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
 func TestListEventsPage(t *testing.T) {
-	logger := zap.NewNop().Sugar()
-	store, err := badger.NewBadgerStore(t.TempDir(), true, logger)
-	require.NoError(t, err)
+	logger := zap.NewNop()
+
+	storeImpls := []struct {
+		Name string
+		Init func() (storepkg.Store, error)
+	}{
+		{
+			Name: "badger",
+			Init: func() (storepkg.Store, error) {
+				return badger.NewBadgerStore(t.TempDir(), true, logger.Sugar())
+			},
+		},
+		{
+			Name: "etcd",
+			Init: func() (storepkg.Store, error) {
+				return etcd.NewEtcdStore(embeddedetcd.Start(t), logger.Sugar())
+			},
+		},
+	}
+
+	for _, storeImpl := range storeImpls {
+		t.Run(storeImpl.Name, func(t *testing.T) {
+			store, err := storeImpl.Init()
+			require.NoError(t, err)
+
+			testListEventsPage(t, store)
+		})
+	}
+}
+
+func testListEventsPage(t *testing.T, store storepkg.Store) {
+	var err error
 
 	events := []v1.Event{
 		{Kind: v1.EventKindLogLine, Timestamp: 1, Payload: "one"},
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// migrationBatchSize is the maximum number of resources that a migration
+// modifies in a single transaction, which keeps the transactions well below
+// both the Badger's transaction size limit and the etcd's default limit
+// of 128 operations per transaction (--max-txn-ops).
+const migrationBatchSize = 50
+
+var (
+	ErrSchemaTooNew = errors.New("database schema is newer than this binary supports")
+
+	errMigrationBatchFull = errors.New("migration batch is full")
+)
+
+// Migration is a single step of upgrading the stored resources
+// from one schema version to the next one.
+//
+// Migrations should be idempotent because databases created before
+// the schema versioning was introduced are migrated from scratch.
+//
+// Migrations are applied in batches: a migration calls batch.Take() before
+// modifying each resource and returns the error as is once the batch is full,
+// after which the resources modified so far are committed and the migration
+// is run again in a new transaction. This means that a migration must skip
+// the resources that it has already migrated, otherwise it will never complete.
+type Migration struct {
+	Description string
+	Migrate     func(txn Transaction, batch *MigrationBatch) error
+}
+
+// MigrationBatch limits the number of resources modified by a migration in a single transaction.
+type MigrationBatch struct {
+	remaining int
+}
+
+// Take reserves room for modifying a single resource in the batch
+// and returns an error when the batch is full.
+func (batch *MigrationBatch) Take() error {
+	if batch.remaining == 0 {
+		return errMigrationBatchFull
+	}
+
+	batch.remaining--
+
+	return nil
+}
+
+// Migrate brings the store's schema up-to-date by running each of the
+// migrations that haven't been applied yet, in order. The store's schema
+// version is the number of migrations applied.
+//
+// The schema version is only bumped in the same transaction that
+// completes the migration, so it's safe to call Migrate from multiple
+// controller replicas at once and an interrupted migration is simply
+// resumed by the next call.
+func Migrate(store Store, migrations []Migration, logger *zap.SugaredLogger) error {
+	latestVersion := uint64(len(migrations))
+
+	for {
+		var currentVersion uint64
+		var appliedMigration *Migration
+		var completed bool
+
+		if err := store.Update(func(txn Transaction) error {
+			appliedMigration = nil
+			completed = false
+
+			var err error
+
+			currentVersion, err = txn.GetSchemaVersion()
+			if err != nil {
+				return err
+			}
+
+			if currentVersion > latestVersion {
+				return fmt.Errorf("%w: database schema version is %d, but the latest "+
+					"supported schema version is %d, please upgrade the controller",
+					ErrSchemaTooNew, currentVersion, latestVersion)
+			}
+
+			if currentVersion == latestVersion {
+				return nil
+			}
+
+			migration := migrations[currentVersion]
+			appliedMigration = &migration
+
+			if err := migration.Migrate(txn, &MigrationBatch{remaining: migrationBatchSize}); err != nil {
+				if errors.Is(err, errMigrationBatchFull) {
+					// Commit the batch, the migration
+					// will continue in a new transaction
+					return nil
+				}
+
+				if errors.Is(err, ErrTxnTooBig) {
+					return err
+				}
+
+				return fmt.Errorf("failed to migrate the database schema from version %d to %d (%s): %w",
+					currentVersion, currentVersion+1, migration.Description, err)
+			}
+
+			completed = true
+
+			return txn.SetSchemaVersion(currentVersion + 1)
+		}); err != nil {
+			if errors.Is(err, ErrTxnTooBig) && appliedMigration != nil {
+				return fmt.Errorf("failed to migrate the database schema from version %d to %d (%s): "+
+					"a batch of %d resources does not fit into a single transaction: %w",
+					currentVersion, currentVersion+1, appliedMigration.Description, migrationBatchSize, err)
+			}
+
+			return err
+		}
+
+		if appliedMigration == nil {
+			return nil
+		}
+
+		if !completed {
+			logger.Infof("applied a batch of %d resources of the database schema migration: %s",
+				migrationBatchSize, appliedMigration.Description)
+
+			continue
+		}
+
+		logger.Infof("applied database schema migration: %s", appliedMigration.Description)
+	}
+}
@@ -0,0 +1,170 @@
+package store_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
+	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	"github.com/cirruslabs/orchard/internal/controller/store/etcd"
+	"github.com/cirruslabs/orchard/internal/tests/embeddedetcd"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMigrate(t *testing.T) {
+	logger := zap.NewNop()
+
+	storeImpls := []struct {
+		Name string
+		Init func() (storepkg.Store, error)
+	}{
+		{
+			Name: "badger",
+			Init: func() (storepkg.Store, error) {
+				return badger.NewBadgerStore(t.TempDir(), true, logger.Sugar())
+			},
+		},
+		{
+			Name: "etcd",
+			Init: func() (storepkg.Store, error) {
+				return etcd.NewEtcdStore(embeddedetcd.Start(t), logger.Sugar())
+			},
+		},
+	}
+
+	for _, storeImpl := range storeImpls {
+		t.Run(storeImpl.Name, func(t *testing.T) {
+			store, err := storeImpl.Init()
+			require.NoError(t, err)
+
+			testMigrate(t, store, logger.Sugar())
+		})
+
+		t.Run(storeImpl.Name+"-batched", func(t *testing.T) {
+			store, err := storeImpl.Init()
+			require.NoError(t, err)
+
+			testMigrateBatched(t, store, logger.Sugar())
+		})
+	}
+}
+
+func testMigrate(t *testing.T, store storepkg.Store, logger *zap.SugaredLogger) {
+	// Simulate a database created before the schema versioning was introduced
+	require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+		if err := txn.SetVM(v1.VM{
+			Meta:           v1.Meta{Name: "legacy"},
+			VMSpecReadOnly: v1.VMSpecReadOnly{TartName: "orchard-legacy"},
+			VMSpec:         v1.VMSpec{NetSoftnetDeprecated: true},
+		}); err != nil {
+			return err
+		}
+
+		// VM groups were stored without the namespace in the key,
+		// despite having the namespace populated
+		if err := txn.SetVMGroup(v1.VMGroup{Meta: v1.Meta{Name: "legacy"}}); err != nil {
+			return err
+		}
+
+		return txn.SetWorker(v1.Worker{Meta: v1.Meta{Name: "legacy"}})
+	}))
+
+	require.NoError(t, storepkg.Migrate(store, storepkg.Migrations, logger))
+
+	require.NoError(t, store.View(func(txn storepkg.Transaction) error {
+		schemaVersion, err := txn.GetSchemaVersion()
+		require.NoError(t, err)
+		require.EqualValues(t, len(storepkg.Migrations), schemaVersion)
+
+		vm, err := txn.GetVM(v1.DefaultNamespace, "legacy")
+		require.NoError(t, err)
+		require.Equal(t, v1.OSDarwin, vm.OS)
+		require.Equal(t, v1.ArchitectureARM64, vm.Arch)
+		require.Equal(t, v1.RuntimeTart, vm.Runtime)
+		require.Equal(t, "orchard-legacy", vm.LocalName)
+		require.True(t, vm.NetSoftnet)
+		require.Equal(t, v1.DefaultNamespace, vm.Namespace)
+
+		vms, err := txn.ListVMs()
+		require.NoError(t, err)
+		require.Len(t, vms, 1)
+
+		vmGroup, err := txn.GetVMGroup(v1.DefaultNamespace, "legacy")
+		require.NoError(t, err)
+		require.Equal(t, v1.DefaultNamespace, vmGroup.Namespace)
+
+		_, err = txn.GetVMGroup("", "legacy")
+		require.ErrorIs(t, err, storepkg.ErrNotFound)
+
+		worker, err := txn.GetWorker("legacy")
+		require.NoError(t, err)
+		require.Equal(t, v1.ArchitectureARM64, worker.Arch)
+		require.Equal(t, v1.RuntimeTart, worker.Runtime)
+
+		return nil
+	}))
+
+	// Migrations that were already applied are not run again
+	migrations := append(slices.Clone(storepkg.Migrations), storepkg.Migration{
+		Description: "test migration",
+		Migrate: func(txn storepkg.Transaction, _ *storepkg.MigrationBatch) error {
+			return txn.DeleteVM(v1.DefaultNamespace, "legacy")
+		},
+	})
+
+	require.NoError(t, storepkg.Migrate(store, migrations, logger))
+
+	require.NoError(t, store.View(func(txn storepkg.Transaction) error {
+		schemaVersion, err := txn.GetSchemaVersion()
+		require.NoError(t, err)
+		require.EqualValues(t, len(migrations), schemaVersion)
+
+		_, err = txn.GetVM(v1.DefaultNamespace, "legacy")
+		require.ErrorIs(t, err, storepkg.ErrNotFound)
+
+		_, err = txn.GetWorker("legacy")
+		require.NoError(t, err)
+
+		return nil
+	}))
+
+	// Database that is newer than the binary is refused
+	require.ErrorIs(t, storepkg.Migrate(store, storepkg.Migrations, logger), storepkg.ErrSchemaTooNew)
+}
+
+func testMigrateBatched(t *testing.T, store storepkg.Store, logger *zap.SugaredLogger) {
+	// Simulate a database with more resources than fit into a single migration batch
+	const numVMs = 120
+
+	for i := range numVMs {
+		require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+			return txn.SetVM(v1.VM{
+				Meta:           v1.Meta{Name: fmt.Sprintf("legacy-%d", i)},
+				VMSpecReadOnly: v1.VMSpecReadOnly{TartName: fmt.Sprintf("orchard-legacy-%d", i)},
+			})
+		}))
+	}
+
+	require.NoError(t, storepkg.Migrate(store, storepkg.Migrations, logger))
+
+	require.NoError(t, store.View(func(txn storepkg.Transaction) error {
+		schemaVersion, err := txn.GetSchemaVersion()
+		require.NoError(t, err)
+		require.EqualValues(t, len(storepkg.Migrations), schemaVersion)
+
+		vms, err := txn.ListVMs()
+		require.NoError(t, err)
+		require.Len(t, vms, numVMs)
+
+		for _, vm := range vms {
+			require.Equal(t, v1.DefaultNamespace, vm.Namespace)
+			require.Equal(t, v1.OSDarwin, vm.OS)
+			require.Equal(t, vm.TartName, vm.LocalName)
+		}
+
+		return nil
+	}))
+}
@@ -0,0 +1,218 @@
+package store
+
+import (
+	"errors"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// Migrations is the ordered list of database schema migrations.
+//
+// Only append new migrations to the end of this list and never
+// modify or remove the existing ones, otherwise the schema versions
+// recorded in the existing databases will become meaningless.
+var Migrations = []Migration{
+	{
+		Description: "populate the platform fields of VMs created before these fields were introduced",
+		Migrate: func(txn Transaction, batch *MigrationBatch) error {
+			return migrateVMs(txn, batch, func(vm *v1.VM) bool {
+				updated := false
+
+				if vm.OS == "" {
+					vm.OS = v1.OSDarwin
+					updated = true
+				}
+				if vm.Arch == "" {
+					vm.Arch = v1.ArchitectureARM64
+					updated = true
+				}
+				if vm.Runtime == "" {
+					vm.Runtime = v1.RuntimeTart
+					updated = true
+				}
+
+				return updated
+			})
+		},
+	},
+	{
+		Description: "populate the platform fields of workers created before these fields were introduced",
+		Migrate: func(txn Transaction, batch *MigrationBatch) error {
+			return migrateWorkers(txn, batch, func(worker *v1.Worker) bool {
+				updated := false
+
+				if worker.Arch == "" {
+					worker.Arch = v1.ArchitectureARM64
+					updated = true
+				}
+				if worker.Runtime == "" {
+					worker.Runtime = v1.RuntimeTart
+					updated = true
+				}
+
+				return updated
+			})
+		},
+	},
+	{
+		Description: "populate VM's \"localName\" from the deprecated \"tartName\" and vice versa",
+		Migrate: func(txn Transaction, batch *MigrationBatch) error {
+			return migrateVMs(txn, batch, func(vm *v1.VM) bool {
+				if vm.LocalName == vm.TartName {
+					return false
+				}
+
+				if vm.LocalName == "" {
+					vm.LocalName = vm.TartName
+				} else {
+					vm.TartName = vm.LocalName
+				}
+
+				return true
+			})
+		},
+	},
+	{
+		Description: "propagate VM's deprecated \"net-softnet\" and \"netSoftnet\" into each other",
+		Migrate: func(txn Transaction, batch *MigrationBatch) error {
+			return migrateVMs(txn, batch, func(vm *v1.VM) bool {
+				if vm.NetSoftnetDeprecated == vm.NetSoftnet {
+					return false
+				}
+
+				vm.NetSoftnetDeprecated = true
+				vm.NetSoftnet = true
+
+				return true
+			})
+		},
+	},
+	{
+		Description: "place VMs created before the namespaces were introduced into the default namespace",
+		Migrate: func(_ Transaction, _ *MigrationBatch) error {
+			// Superseded by the next migration, which places the VMs into the default
+			// namespace while moving them to the keys that include their namespace,
+			// whereas setting the namespace here would leave the VMs under their old
+			// keys and thus would never complete when migrating in batches
+			return nil
+		},
+	},
+	{
+		Description: "move VMs, VM groups, VM pools and VM sets to the keys that include their namespace",
+		Migrate: func(txn Transaction, batch *MigrationBatch) error {
+			if err := rekeyNamespaced(batch, txn.ListVMs, txn.GetVM, txn.SetVM, txn.DeleteVM,
+				func(vm *v1.VM) *v1.Meta { return &vm.Meta }); err != nil {
+				return err
+			}
+
+			if err := rekeyNamespaced(batch, txn.ListVMGroups, txn.GetVMGroup, txn.SetVMGroup, txn.DeleteVMGroup,
+				func(vmGroup *v1.VMGroup) *v1.Meta { return &vmGroup.Meta }); err != nil {
+				return err
+			}
+
+			if err := rekeyNamespaced(batch, txn.ListVMPools, txn.GetVMPool, txn.SetVMPool, txn.DeleteVMPool,
+				func(vmPool *v1.VMPool) *v1.Meta { return &vmPool.Meta }); err != nil {
+				return err
+			}
+
+			return rekeyNamespaced(batch, txn.ListVMSets, txn.GetVMSet, txn.SetVMSet, txn.DeleteVMSet,
+				func(vmSet *v1.VMSet) *v1.Meta { return &vmSet.Meta })
+		},
+	},
+}
+
+func migrateVMs(txn Transaction, batch *MigrationBatch, migrate func(vm *v1.VM) bool) error {
+	vms, err := txn.ListVMs()
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if !migrate(&vm) {
+			continue
+		}
+
+		if err := batch.Take(); err != nil {
+			return err
+		}
+
+		if err := txn.SetVM(vm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateWorkers(txn Transaction, batch *MigrationBatch, migrate func(worker *v1.Worker) bool) error {
+	workers, err := txn.ListWorkers()
+	if err != nil {
+		return err
+	}
+
+	for _, worker := range workers {
+		if !migrate(&worker) {
+			continue
+		}
+
+		if err := batch.Take(); err != nil {
+			return err
+		}
+
+		if err := txn.SetWorker(worker); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rekeyNamespaced moves the namespaced resources stored under the "/<space>/<name>" keys
+// to the "/<space>/<namespace>/<name>" keys, placing the resources that have no namespace
+// into the default namespace.
+func rekeyNamespaced[T any](
+	batch *MigrationBatch,
+	list func() ([]T, error),
+	get func(namespace string, name string) (*T, error),
+	set func(obj T) error,
+	del func(namespace string, name string) error,
+	metaOf func(obj *T) *v1.Meta,
+) error {
+	objs, err := list()
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		meta := metaOf(&obj)
+
+		if meta.Namespace != "" {
+			// Listing returns the resources stored under both kinds
+			// of keys, so skip the resources that were already moved
+			_, err := get(meta.Namespace, meta.Name)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		} else {
+			meta.Namespace = v1.DefaultNamespace
+		}
+
+		if err := batch.Take(); err != nil {
+			return err
+		}
+
+		// An empty namespace addresses the key without the namespace
+		if err := del("", meta.Name); err != nil {
+			return err
+		}
+
+		if err := set(obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
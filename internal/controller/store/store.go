@@ -2,45 +2,93 @@ package store
 
 import (
 	"context"
+	"io"
 
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 )
 
-type WatchMessageType string
+type WatchMessageType = v1.WatchMessageType
 
 const (
-	WatchMessageTypeAdded    WatchMessageType = "ADDED"
-	WatchMessageTypeModified WatchMessageType = "MODIFIED"
-	WatchMessageTypeDeleted  WatchMessageType = "DELETED"
+	WatchMessageTypeAdded    = v1.WatchMessageTypeAdded
+	WatchMessageTypeModified = v1.WatchMessageTypeModified
+	WatchMessageTypeDeleted  = v1.WatchMessageTypeDeleted
 )
 
-type WatchMessage[T any] struct {
-	Type   WatchMessageType `json:"type,omitempty"`
-	Object T                `json:"object,omitempty"`
-}
+type WatchMessage[T any] = v1.WatchMessage[T]
 
 type Store interface {
 	View(cb func(txn Transaction) error) error
 	Update(cb func(txn Transaction) error) error
-	WatchVM(ctx context.Context, vmName string) (chan WatchMessage[v1.VM], chan error, error)
+	WatchVM(ctx context.Context, namespace string, vmName string) (chan WatchMessage[v1.VM], chan error, error)
+
+	// WatchVMs, WatchWorkers and WatchServiceAccounts stream the changes to all
+	// resources of the corresponding kind that have happened after the resourceVersion.
+	//
+	// When resourceVersion is zero, the current resources are streamed first
+	// as WatchMessageTypeAdded messages.
+	//
+	// ErrWatchExpired is returned when the changes after resourceVersion
+	// are no longer available, in which case the caller should re-list
+	// the resources and watch again with a zero resourceVersion.
+	WatchVMs(ctx context.Context, resourceVersion uint64) (chan WatchMessage[v1.VM], chan error, error)
+	WatchWorkers(ctx context.Context, resourceVersion uint64) (chan WatchMessage[v1.Worker], chan error, error)
+	WatchServiceAccounts(ctx context.Context, resourceVersion uint64) (chan WatchMessage[v1.ServiceAccount],
+		chan error, error)
+}
+
+// Backuper is implemented by the stores that
+// support taking consistent online backups.
+type Backuper interface {
+	Backup(w io.Writer) error
 }
 
 type Transaction interface {
-	GetVM(name string) (result *v1.VM, err error)
+	// VMs, VM groups, VM pools and VM sets are namespaced resources, their names
+	// are only unique within a namespace. Passing an empty namespace addresses
+	// the resources stored before the namespaces were introduced.
+	GetVM(namespace string, name string) (result *v1.VM, err error)
 	SetVM(vm v1.VM) (err error)
-	DeleteVM(name string) (err error)
+	DeleteVM(namespace string, name string) (err error)
 	ListVMs() (result []v1.VM, err error)
+	ListVMsPage(options ListOptions) (result Page[v1.VM], err error)
 
 	GetWorker(name string) (result *v1.Worker, err error)
 	SetWorker(worker v1.Worker) (err error)
 	DeleteWorker(name string) (err error)
 	ListWorkers() (result []v1.Worker, err error)
+	ListWorkersPage(options ListOptions) (result Page[v1.Worker], err error)
 
 	GetServiceAccount(name string) (result *v1.ServiceAccount, err error)
 	SetServiceAccount(serviceAccount *v1.ServiceAccount) (err error)
 	DeleteServiceAccount(name string) (err error)
 	ListServiceAccounts() (result []v1.ServiceAccount, err error)
 
+	GetVMGroup(namespace string, name string) (result *v1.VMGroup, err error)
+	SetVMGroup(vmGroup v1.VMGroup) (err error)
+	DeleteVMGroup(namespace string, name string) (err error)
+	ListVMGroups() (result []v1.VMGroup, err error)
+
+	GetVMPool(namespace string, name string) (result *v1.VMPool, err error)
+	SetVMPool(vmPool v1.VMPool) (err error)
+	DeleteVMPool(namespace string, name string) (err error)
+	ListVMPools() (result []v1.VMPool, err error)
+
+	GetVMSet(namespace string, name string) (result *v1.VMSet, err error)
+	SetVMSet(vmSet v1.VMSet) (err error)
+	DeleteVMSet(namespace string, name string) (err error)
+	ListVMSets() (result []v1.VMSet, err error)
+
+	GetVMTemplate(name string) (result *v1.VMTemplate, err error)
+	SetVMTemplate(vmTemplate v1.VMTemplate) (err error)
+	DeleteVMTemplate(name string) (err error)
+	ListVMTemplates() (result []v1.VMTemplate, err error)
+
+	GetQuota(name string) (result *v1.Quota, err error)
+	SetQuota(quota v1.Quota) (err error)
+	DeleteQuota(name string) (err error)
+	ListQuotas() (result []v1.Quota, err error)
+
 	AppendEvents(event []v1.Event, scope ...string) (err error)
 	ListEvents(scope ...string) (result []v1.Event, err error)
 	ListEventsPage(options ListOptions, scope ...string) (result Page[v1.Event], err error)
@@ -48,6 +96,9 @@ type Transaction interface {
 
 	GetClusterSettings() (*v1.ClusterSettings, error)
 	SetClusterSettings(clusterSettings v1.ClusterSettings) error
+
+	GetSchemaVersion() (uint64, error)
+	SetSchemaVersion(version uint64) error
 }
 
 type ListOptions struct {
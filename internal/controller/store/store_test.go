@@ -8,6 +8,8 @@ import (
 
 	storepkg "github.com/cirruslabs/orchard/internal/controller/store"
 	"github.com/cirruslabs/orchard/internal/controller/store/badger"
+	"github.com/cirruslabs/orchard/internal/controller/store/etcd"
+	"github.com/cirruslabs/orchard/internal/tests/embeddedetcd"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -28,7 +30,8 @@ func TestWatchVM(t *testing.T) {
 
 				vm := v1.VM{
 					Meta: v1.Meta{
-						Name: vmName,
+						Name:      vmName,
+						Namespace: v1.DefaultNamespace,
 					},
 				}
 
@@ -41,7 +44,7 @@ func TestWatchVM(t *testing.T) {
 				ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
 				defer cancel()
 
-				watchCh, errCh, err := store.WatchVM(ctx, vmName)
+				watchCh, errCh, err := store.WatchVM(ctx, v1.DefaultNamespace, vmName)
 				require.NoError(t, err)
 
 				// Ensure that a synthetic VM creation event is emitted
@@ -71,7 +74,7 @@ func TestWatchVM(t *testing.T) {
 
 				// Delete the VM and ensure that a deletion event is emitted
 				err = store.Update(func(txn storepkg.Transaction) error {
-					return txn.DeleteVM(vmName)
+					return txn.DeleteVM(v1.DefaultNamespace, vmName)
 				})
 				require.NoError(t, err)
 
@@ -94,13 +97,14 @@ func TestWatchVM(t *testing.T) {
 				ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
 				defer cancel()
 
-				watchCh, errCh, err := store.WatchVM(ctx, vmName)
+				watchCh, errCh, err := store.WatchVM(ctx, v1.DefaultNamespace, vmName)
 				require.NoError(t, err)
 
 				// Create a VM
 				vm := v1.VM{
 					Meta: v1.Meta{
-						Name: vmName,
+						Name:      vmName,
+						Namespace: v1.DefaultNamespace,
 					},
 				}
 
@@ -136,7 +140,7 @@ func TestWatchVM(t *testing.T) {
 
 				// Delete the VM and ensure that a deletion event is emitted
 				err = store.Update(func(txn storepkg.Transaction) error {
-					return txn.DeleteVM(vmName)
+					return txn.DeleteVM(v1.DefaultNamespace, vmName)
 				})
 				require.NoError(t, err)
 
@@ -162,6 +166,12 @@ func TestWatchVM(t *testing.T) {
 				return badger.NewBadgerStore(t.TempDir(), true, logger.Sugar())
 			},
 		},
+		{
+			Name: "etcd",
+			Init: func() (storepkg.Store, error) {
+				return etcd.NewEtcdStore(embeddedetcd.Start(t), logger.Sugar())
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -177,3 +187,207 @@ func TestWatchVM(t *testing.T) {
 		}
 	}
 }
+
+func TestWatchVMs(t *testing.T) {
+	logger := zap.Must(zap.NewDevelopment())
+
+	storeImpls := []struct {
+		Name string
+		Init func() (storepkg.Store, error)
+	}{
+		{
+			Name: "badger",
+			Init: func() (storepkg.Store, error) {
+				return badger.NewBadgerStore(t.TempDir(), true, logger.Sugar())
+			},
+		},
+		{
+			Name: "etcd",
+			Init: func() (storepkg.Store, error) {
+				return etcd.NewEtcdStore(embeddedetcd.Start(t), logger.Sugar())
+			},
+		},
+	}
+
+	for _, storeImpl := range storeImpls {
+		t.Run(storeImpl.Name, func(t *testing.T) {
+			store, err := storeImpl.Init()
+			require.NoError(t, err)
+
+			testWatchVMs(t, store)
+		})
+	}
+}
+
+func testWatchVMs(t *testing.T, store storepkg.Store) {
+	setVM := func(name string) {
+		require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+			return txn.SetVM(v1.VM{Meta: v1.Meta{Name: name, Namespace: "team-a"}})
+		}))
+	}
+
+	deleteVM := func(name string) {
+		require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+			return txn.DeleteVM("team-a", name)
+		}))
+	}
+
+	receive := func(
+		ctx context.Context,
+		watchCh chan storepkg.WatchMessage[v1.VM],
+		errCh chan error,
+	) storepkg.WatchMessage[v1.VM] {
+		select {
+		case item := <-watchCh:
+			return item
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for watch event")
+		}
+
+		return storepkg.WatchMessage[v1.VM]{}
+	}
+
+	setVM("existing")
+
+	// Watch without a resource version and ensure
+	// that the existing VMs are streamed first
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+
+	watchCh, errCh, err := store.WatchVMs(ctx, 0)
+	require.NoError(t, err)
+
+	item := receive(ctx, watchCh, errCh)
+	require.Equal(t, storepkg.WatchMessageTypeAdded, item.Type)
+	require.Equal(t, "existing", item.Object.Name)
+
+	setVM("new")
+
+	item = receive(ctx, watchCh, errCh)
+	require.Equal(t, storepkg.WatchMessageTypeAdded, item.Type)
+	require.Equal(t, "new", item.Object.Name)
+
+	resourceVersion := item.Object.Version
+	require.NotZero(t, resourceVersion)
+
+	cancel()
+
+	// Make some changes while not watching
+	setVM("existing")
+	deleteVM("new")
+
+	// Resume watching and ensure that the changes are not lost
+	ctx, cancel = context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	watchCh, errCh, err = store.WatchVMs(ctx, resourceVersion)
+	require.NoError(t, err)
+
+	item = receive(ctx, watchCh, errCh)
+	require.Equal(t, storepkg.WatchMessageTypeModified, item.Type)
+	require.Equal(t, "existing", item.Object.Name)
+	require.Greater(t, item.Object.Version, resourceVersion)
+
+	item = receive(ctx, watchCh, errCh)
+	require.Equal(t, storepkg.WatchMessageTypeDeleted, item.Type)
+	require.Equal(t, "new", item.Object.Name)
+	require.Equal(t, "team-a", item.Object.Namespace)
+
+	// Changes to other resources are not streamed
+	require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+		return txn.SetWorker(v1.Worker{Meta: v1.Meta{Name: "worker"}})
+	}))
+	deleteVM("existing")
+
+	item = receive(ctx, watchCh, errCh)
+	require.Equal(t, storepkg.WatchMessageTypeDeleted, item.Type)
+	require.Equal(t, "existing", item.Object.Name)
+
+	// Deleted objects retain their last known state
+	require.Equal(t, "team-a", item.Object.Namespace)
+}
+
+func TestListVMsPage(t *testing.T) {
+	logger := zap.Must(zap.NewDevelopment())
+
+	storeImpls := []struct {
+		Name string
+		Init func() (storepkg.Store, error)
+	}{
+		{
+			Name: "badger",
+			Init: func() (storepkg.Store, error) {
+				return badger.NewBadgerStore(t.TempDir(), true, logger.Sugar())
+			},
+		},
+		{
+			Name: "etcd",
+			Init: func() (storepkg.Store, error) {
+				return etcd.NewEtcdStore(embeddedetcd.Start(t), logger.Sugar())
+			},
+		},
+	}
+
+	for _, storeImpl := range storeImpls {
+		t.Run(storeImpl.Name, func(t *testing.T) {
+			store, err := storeImpl.Init()
+			require.NoError(t, err)
+
+			testListVMsPage(t, store)
+		})
+	}
+}
+
+func testListVMsPage(t *testing.T, store storepkg.Store) {
+	require.NoError(t, store.Update(func(txn storepkg.Transaction) error {
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			if err := txn.SetVM(v1.VM{Meta: v1.Meta{Name: name, Namespace: v1.DefaultNamespace}}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	listAll := func(options storepkg.ListOptions) [][]string {
+		var pages [][]string
+
+		for {
+			var page storepkg.Page[v1.VM]
+
+			require.NoError(t, store.View(func(txn storepkg.Transaction) (err error) {
+				page, err = txn.ListVMsPage(options)
+
+				return err
+			}))
+
+			var names []string
+
+			for _, vm := range page.Items {
+				require.NotZero(t, vm.Version)
+				names = append(names, vm.Name)
+			}
+
+			pages = append(pages, names)
+
+			if len(page.NextCursor) == 0 {
+				return pages
+			}
+
+			options.Cursor = page.NextCursor
+		}
+	}
+
+	require.Equal(t, [][]string{{"a", "b", "c", "d", "e"}}, listAll(storepkg.ListOptions{}))
+	require.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, listAll(storepkg.ListOptions{
+		Limit: 2,
+	}))
+	require.Equal(t, [][]string{{"e", "d", "c"}, {"b", "a"}}, listAll(storepkg.ListOptions{
+		Limit: 3,
+		Order: storepkg.ListOrderDesc,
+	}))
+	require.Equal(t, [][]string{{"a", "b", "c", "d", "e"}}, listAll(storepkg.ListOptions{
+		Limit: 5,
+	}))
+}
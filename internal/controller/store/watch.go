@@ -0,0 +1,43 @@
+package store
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DeletedObject returns an object suitable for the WatchMessageTypeDeleted
+// message, with only the Name, the Namespace (for namespaced resources)
+// and the Version fields populated.
+//
+// The relativeKey is the object's key with the resource space trimmed,
+// which is either "<name>" or "<namespace>/<name>".
+func DeletedObject[T any, PT interface {
+	SetVersion(uint64)
+	*T
+}](relativeKey string, version uint64) (T, error) {
+	var obj T
+
+	meta := map[string]string{
+		"name": relativeKey,
+	}
+
+	if namespace, name, ok := strings.Cut(relativeKey, "/"); ok {
+		meta["namespace"] = namespace
+		meta["name"] = name
+	}
+
+	// All resources embed v1.Meta, so we can
+	// populate the name without reflection
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return obj, err
+	}
+
+	if err := json.Unmarshal(metaBytes, &obj); err != nil {
+		return obj, err
+	}
+
+	PT(&obj).SetVersion(version)
+
+	return obj, nil
+}
@@ -0,0 +1,72 @@
+// Package mergepatch implements JSON Merge Patch as described in RFC 7386.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Apply applies the JSON Merge Patch to the original JSON document
+// and returns the resulting document.
+//
+// Objects in the patch are merged recursively into the original document,
+// null values remove the corresponding keys and any other values
+// (including arrays) replace the original values as a whole.
+func Apply(original []byte, patch []byte) ([]byte, error) {
+	originalValue, err := unmarshal(original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the original document: %w", err)
+	}
+
+	patchValue, err := unmarshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the patch: %w", err)
+	}
+
+	return json.Marshal(merge(originalValue, patchValue))
+}
+
+func unmarshal(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// Preserve large integers, such as resource versions,
+	// that would otherwise lose precision as float64
+	decoder.UseNumber()
+
+	var value any
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the top-level value")
+	}
+
+	return value, nil
+}
+
+func merge(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+
+			continue
+		}
+
+		targetObject[key] = merge(targetObject[key], value)
+	}
+
+	return targetObject
+}
@@ -0,0 +1,50 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/mergepatch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	// Test cases from the RFC 7386's Appendix A
+	testCases := []struct {
+		Original string
+		Patch    string
+		Expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		// Large integers should not lose precision
+		{`{"version":18446744073709551615}`, `{"a":1}`, `{"a":1,"version":18446744073709551615}`},
+	}
+
+	for _, testCase := range testCases {
+		result, err := mergepatch.Apply([]byte(testCase.Original), []byte(testCase.Patch))
+		require.NoError(t, err)
+		require.JSONEq(t, testCase.Expected, string(result), "original: %s, patch: %s",
+			testCase.Original, testCase.Patch)
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	_, err := mergepatch.Apply([]byte(`{}`), []byte(`{"a":`))
+	require.Error(t, err)
+
+	_, err = mergepatch.Apply([]byte(`{}`), []byte(`{} {}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package tests_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffinity(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	// Pack as many VMs as possible on each worker, so that
+	// the anti-affinity is the only reason to spread the VMs
+	clusterSettings, err := devClient.ClusterSettings().Get(ctx)
+	require.NoError(t, err)
+	clusterSettings.SchedulerProfile = v1.SchedulerProfileOptimizeUtilization
+	require.NoError(t, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	for _, name := range []string{"worker-a", "worker-b", "worker-gpu"} {
+		labels := v1.Labels{}
+		if name == "worker-gpu" {
+			labels["gpu"] = "true"
+		}
+
+		_, err := devClient.Workers().Create(ctx, v1.Worker{
+			Meta: v1.Meta{
+				Name: name,
+			},
+			Resources: map[string]uint64{
+				v1.ResourceTartVMs: 3,
+			},
+			Labels: labels,
+		})
+		require.NoError(t, err)
+	}
+
+	// VM that requires a GPU worker
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "gpu",
+		},
+		Image: "example.com/doesnt/matter:latest",
+		Affinity: &v1.Affinity{
+			WorkerAffinity: &v1.WorkerAffinity{
+				Required: "labels.gpu=true",
+			},
+		},
+	}))
+	ensureAssignment(t, devClient, "gpu", "worker-gpu")
+
+	// Replicas that avoid each other and the GPU worker
+	workers := map[string]struct{}{}
+
+	for i := range 2 {
+		name := fmt.Sprintf("replica-%d", i)
+
+		require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+			Meta: v1.Meta{
+				Name:   name,
+				Labels: map[string]string{"app": "replica"},
+			},
+			Image: "example.com/doesnt/matter:latest",
+			Affinity: &v1.Affinity{
+				WorkerAffinity: &v1.WorkerAffinity{
+					Required: "!labels.gpu",
+				},
+				VMAntiAffinity: &v1.VMAntiAffinity{
+					Required: "metadataLabels.app=replica",
+				},
+			},
+		}))
+
+		workers[waitForWorker(t, devClient, name)] = struct{}{}
+	}
+
+	require.Equal(t, map[string]struct{}{"worker-a": {}, "worker-b": {}}, workers)
+
+	// Invalid affinity is rejected
+	err = devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+		Image: "example.com/doesnt/matter:latest",
+		Affinity: &v1.Affinity{
+			WorkerAffinity: &v1.WorkerAffinity{
+				Required: "labels.rack in (a",
+			},
+		},
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+}
+
+func waitForWorker(t *testing.T, devClient *client.Client, vmName string) string {
+	var workerName string
+
+	require.True(t, wait.Wait(2*time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(context.Background(), vmName)
+		require.NoError(t, err)
+
+		t.Logf("Waiting for the VM %s to be assigned to a worker", vmName)
+
+		workerName = vm.Worker
+
+		return workerName != ""
+	}), "VM %s was not assigned to any worker", vmName)
+
+	return workerName
+}
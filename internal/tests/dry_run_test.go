@@ -0,0 +1,71 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateVMDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 1,
+		},
+		DefaultCPU: 4,
+	})
+	require.NoError(t, err)
+
+	newVM := func(name string) *v1.VM {
+		return &v1.VM{
+			Meta: v1.Meta{
+				Name: name,
+			},
+			Image: "example.com/doesnt/matter:latest",
+		}
+	}
+
+	// The VM is validated as usual
+	_, err = devClient.VMs().CreateDryRun(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	// The simulated placement is returned, but nothing is persisted
+	simulatedVM, err := devClient.VMs().CreateDryRun(ctx, newVM("test-vm"))
+	require.NoError(t, err)
+	require.Equal(t, "worker", simulatedVM.Worker)
+	require.EqualValues(t, 4, simulatedVM.AssignedCPU)
+
+	_, err = devClient.VMs().Get(ctx, "test-vm")
+	requireStatusCode(t, http.StatusNotFound, err)
+
+	// The VMs that don't fit are explained
+	require.NoError(t, devClient.VMs().Create(ctx, newVM("test-vm")))
+	ensureAssignment(t, devClient, "test-vm", "worker")
+
+	simulatedVM, err = devClient.VMs().CreateDryRun(ctx, newVM("another-test-vm"))
+	require.NoError(t, err)
+	require.Empty(t, simulatedVM.Worker)
+	require.Equal(t, "0/1 workers are available: 1 insufficient org.cirruslabs.tart-vms",
+		simulatedVM.StatusMessage)
+
+	// The conflicts are detected
+	_, err = devClient.VMs().CreateDryRun(ctx, newVM("test-vm"))
+	requireStatusCode(t, http.StatusConflict, err)
+}
@@ -0,0 +1,46 @@
+package embeddedetcd
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.uber.org/zap"
+)
+
+// Start starts an embedded single-node etcd server
+// and returns a client connected to it.
+func Start(t *testing.T) *clientv3.Client {
+	localhostURL, err := url.Parse("http://127.0.0.1:0")
+	require.NoError(t, err)
+
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	cfg.ListenClientUrls = []url.URL{*localhostURL}
+	cfg.ListenPeerUrls = []url.URL{*localhostURL}
+
+	etcdServer, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(etcdServer.Close)
+
+	select {
+	case <-etcdServer.Server.ReadyNotify():
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for the embedded etcd server to start")
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints: []string{etcdServer.Clients[0].Addr().String()},
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
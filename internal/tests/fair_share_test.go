@@ -0,0 +1,68 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShare(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	clusterSettings, err := devClient.ClusterSettings().Get(ctx)
+	require.NoError(t, err)
+
+	// Zero weights are rejected
+	clusterSettings.FairShare = &v1.FairShare{
+		Label:   "team",
+		Weights: map[string]uint64{"a": 0},
+	}
+	requireStatusCode(t, http.StatusBadRequest, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	clusterSettings.FairShare.Weights = nil
+	require.NoError(t, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	// Team A submits its VMs first
+	for _, name := range []string{"a1", "a2", "b1"} {
+		require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+			Meta: v1.Meta{
+				Name: name,
+				Labels: map[string]string{
+					"team": name[:1],
+				},
+			},
+			Image: "example.com/doesnt/matter:latest",
+		}))
+	}
+
+	// Only two VMs fit on the worker, and instead of scheduling the
+	// team A's VMs first, the scheduler interleaves the teams
+	_, err = devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 2,
+		},
+	})
+	require.NoError(t, err)
+
+	ensureAssignment(t, devClient, "a1", "worker")
+	ensureAssignment(t, devClient, "b1", "worker")
+
+	time.Sleep(10 * time.Second)
+
+	vm, err := devClient.VMs().Get(ctx, "a2")
+	require.NoError(t, err)
+	require.Empty(t, vm.Worker)
+}
@@ -0,0 +1,74 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageLocality(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	const cachedImage = "ghcr.io/cirruslabs/macos-sequoia-base:latest"
+
+	for _, worker := range []v1.Worker{
+		{
+			Meta: v1.Meta{
+				Name: "worker-a",
+			},
+			Resources: map[string]uint64{
+				v1.ResourceTartVMs: 2,
+			},
+		},
+		{
+			Meta: v1.Meta{
+				Name: "worker-b",
+			},
+			Resources: map[string]uint64{
+				v1.ResourceTartVMs: 2,
+			},
+			CachedImages: []string{cachedImage},
+		},
+	} {
+		_, err := devClient.Workers().Create(ctx, worker)
+		require.NoError(t, err)
+	}
+
+	// The busiest worker would've been preferred
+	// if not for the cached image on the other one
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "uncached",
+		},
+		Image: "example.com/doesnt/matter:latest",
+	}))
+	ensureAssignment(t, devClient, "uncached", "worker-a")
+
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "cached",
+		},
+		Image: cachedImage,
+	}))
+	ensureAssignment(t, devClient, "cached", "worker-b")
+
+	// Workers report the cached images along with their heartbeat
+	worker, err := devClient.Workers().Get(ctx, "worker-b")
+	require.NoError(t, err)
+	require.Equal(t, []string{cachedImage}, worker.CachedImages)
+
+	worker.LastSeen = time.Now()
+	worker.CachedImages = nil
+	worker, err = devClient.Workers().Update(ctx, *worker)
+	require.NoError(t, err)
+	require.Empty(t, worker.CachedImages)
+}
@@ -0,0 +1,90 @@
+package tests_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListVMsPagination(t *testing.T) {
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	ctx := context.Background()
+
+	var expectedNames []string
+
+	for i := range 5 {
+		vm := platformdependent.VM(fmt.Sprintf("test-vm-%d", i))
+		require.NoError(t, devClient.VMs().Create(ctx, vm))
+
+		expectedNames = append(expectedNames, vm.Name)
+	}
+
+	// Retrieve the pages manually
+	page, cursor, err := devClient.VMs().ListPage(ctx, client.WithListLimit(2))
+	require.NoError(t, err)
+	require.Equal(t, expectedNames[:2], vmNames(page))
+	require.NotEmpty(t, cursor)
+
+	page, cursor, err = devClient.VMs().ListPage(ctx, client.WithListLimit(3),
+		client.WithListCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, expectedNames[2:], vmNames(page))
+	require.Empty(t, cursor)
+
+	// Iterate over all VMs
+	var names []string
+
+	for vm, err := range devClient.VMs().All(ctx, 2) {
+		require.NoError(t, err)
+
+		names = append(names, vm.Name)
+	}
+
+	require.Equal(t, expectedNames, names)
+
+	// Iterate over the VMs matching the selector, the pages
+	// should be filled despite the VMs being filtered out
+	selector, err := v1.ParseSelector("name in (test-vm-0,test-vm-3,test-vm-4)")
+	require.NoError(t, err)
+
+	page, cursor, err = devClient.VMs().ListPage(ctx, client.WithListLimit(2),
+		client.WithListSelector(selector))
+	require.NoError(t, err)
+	require.Equal(t, []string{"test-vm-0", "test-vm-3"}, vmNames(page))
+	require.NotEmpty(t, cursor)
+
+	names = nil
+
+	for vm, err := range devClient.VMs().All(ctx, 2, client.WithListSelector(selector)) {
+		require.NoError(t, err)
+
+		names = append(names, vm.Name)
+	}
+
+	require.Equal(t, []string{"test-vm-0", "test-vm-3", "test-vm-4"}, names)
+
+	// Unpaginated listing still returns everything
+	vms, err := devClient.VMs().List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expectedNames, vmNames(vms))
+}
+
+func vmNames(vms []v1.VM) []string {
+	var names []string
+
+	for _, vm := range vms {
+		names = append(names, vm.Name)
+	}
+
+	return names
+}
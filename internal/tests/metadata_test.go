@@ -0,0 +1,112 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataLabelsAndAnnotations(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 2,
+		},
+	})
+	require.NoError(t, err)
+
+	// Metadata labels should not be taken into account when scheduling
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name:        "team-vm",
+			Labels:      map[string]string{"team": "ci", "example.com/job-id": "42"},
+			Annotations: map[string]string{"job-url": "https://example.com/job/42"},
+		},
+		Image: "example.com/doesnt/matter:latest",
+	}))
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "other-vm",
+		},
+		Image: "example.com/doesnt/matter:latest",
+	}))
+
+	require.True(t, wait.Wait(30*time.Second, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "team-vm")
+		require.NoError(t, err)
+
+		return vm.Worker != ""
+	}), "VM with metadata labels was not scheduled")
+
+	vm, err := devClient.VMs().Get(ctx, "team-vm")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "ci", "example.com/job-id": "42"}, vm.Meta.Labels)
+	require.Equal(t, map[string]string{"job-url": "https://example.com/job/42"}, vm.Annotations)
+	require.Empty(t, vm.Labels)
+
+	// Metadata labels can be used in the selectors
+	selector, err := v1.ParseSelector("metadataLabels.example.com/job-id=42")
+	require.NoError(t, err)
+
+	vms, err := devClient.VMs().List(ctx, client.WithListSelector(selector))
+	require.NoError(t, err)
+	require.Len(t, vms, 1)
+	require.Equal(t, "team-vm", vms[0].Name)
+
+	// Metadata labels can be changed later without bumping the generation
+	vm.Meta.Labels = map[string]string{"team": "qa"}
+
+	updatedVM, err := devClient.VMs().Update(ctx, *vm)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "qa"}, updatedVM.Meta.Labels)
+	require.Equal(t, vm.Generation, updatedVM.Generation)
+
+	// Omitting metadata labels and annotations leaves them intact
+	vm.Meta.Labels = nil
+	vm.Annotations = nil
+
+	updatedVM, err = devClient.VMs().Update(ctx, *vm)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "qa"}, updatedVM.Meta.Labels)
+	require.Equal(t, map[string]string{"job-url": "https://example.com/job/42"}, updatedVM.Annotations)
+
+	// Workers can be labeled too
+	worker, err := devClient.Workers().Get(ctx, "worker")
+	require.NoError(t, err)
+
+	worker.Meta.Labels = map[string]string{"rack": "a1"}
+
+	_, err = devClient.Workers().Update(ctx, *worker)
+	require.NoError(t, err)
+
+	selector, err = v1.ParseSelector("metadataLabels.rack=a1")
+	require.NoError(t, err)
+
+	workers, err := devClient.Workers().List(ctx, client.WithListSelector(selector))
+	require.NoError(t, err)
+	require.Len(t, workers, 1)
+
+	// Keys that cannot be referenced in a selector are rejected
+	require.Error(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name:   "invalid-vm",
+			Labels: map[string]string{"team=ci": "qa"},
+		},
+		Image: "example.com/doesnt/matter:latest",
+	}))
+}
@@ -0,0 +1,84 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaces(t *testing.T) {
+	devClient, devController, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	ctx := context.Background()
+
+	teamClient, err := client.New(client.WithAddress(devController.Address()), client.WithNamespace("team-a"))
+	require.NoError(t, err)
+
+	// VMs are created in the default namespace unless specified otherwise
+	require.NoError(t, devClient.VMs().Create(ctx, platformdependent.VM("default-vm")))
+	require.NoError(t, teamClient.VMs().Create(ctx, platformdependent.VM("team-vm")))
+
+	vm, err := devClient.VMs().Get(ctx, "default-vm")
+	require.NoError(t, err)
+	require.Equal(t, v1.DefaultNamespace, vm.Namespace)
+
+	vm, err = devClient.VMs().InNamespace("team-a").Get(ctx, "team-vm")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", vm.Namespace)
+
+	// Names are only unique within a namespace
+	require.NoError(t, teamClient.VMs().Create(ctx, platformdependent.VM("default-vm")))
+
+	vm, err = teamClient.VMs().Get(ctx, "default-vm")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", vm.Namespace)
+
+	requireStatusCode(t, http.StatusConflict, devClient.VMs().Create(ctx, platformdependent.VM("default-vm")))
+
+	// Conflicting namespaces are rejected
+	conflictingVM := platformdependent.VM("conflicting-vm")
+	conflictingVM.Namespace = "team-b"
+	requireStatusCode(t, http.StatusPreconditionFailed, teamClient.VMs().Create(ctx, conflictingVM))
+
+	// Listing is restricted to the client's namespace
+	vms, err := teamClient.VMs().List(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"default-vm", "team-vm"}, vmNames(vms))
+
+	vms, err = teamClient.VMs().List(ctx, client.WithListAllNamespaces())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"default-vm", "default-vm", "team-vm"}, vmNames(vms))
+
+	// VMs in other namespaces are not found
+	_, err = devClient.VMs().Get(ctx, "team-vm")
+	requireStatusCode(t, http.StatusNotFound, err)
+	requireStatusCode(t, http.StatusNotFound, devClient.VMs().Delete(ctx, "team-vm"))
+
+	// VMs cannot be moved between namespaces
+	_, err = teamClient.VMs().Patch(ctx, "team-vm", map[string]any{"namespace": "team-b"})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	require.NoError(t, teamClient.VMs().Delete(ctx, "team-vm"))
+	require.NoError(t, teamClient.VMs().Delete(ctx, "default-vm"))
+
+	_, err = devClient.VMs().Get(ctx, "default-vm")
+	require.NoError(t, err)
+}
+
+func requireStatusCode(t *testing.T, expected int, err error) {
+	t.Helper()
+
+	var apiError *client.APIError
+	require.True(t, errors.As(err, &apiError), "expected an API error, got %v", err)
+	require.Equal(t, expected, apiError.StatusCode)
+}
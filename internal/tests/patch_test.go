@@ -0,0 +1,116 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchVM(t *testing.T) {
+	devClient, devController, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	ctx := context.Background()
+
+	require.NoError(t, devClient.VMs().Create(ctx, platformdependent.VM("test-vm")))
+
+	vm, err := devClient.VMs().Get(ctx, "test-vm")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, vm.Generation)
+
+	// Patch the VM's specification, this bumps the generation
+	patchedVM, err := devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"powerState":     "stopped",
+		"metadataLabels": map[string]string{"team": "ci"},
+	}, client.WithResourceVersion(vm.Version))
+	require.NoError(t, err)
+	require.Equal(t, v1.PowerStateStopped, patchedVM.PowerState)
+	require.Equal(t, map[string]string{"team": "ci"}, patchedVM.Meta.Labels)
+	require.EqualValues(t, 1, patchedVM.Generation)
+	require.NotEqual(t, vm.Version, patchedVM.Version)
+
+	// Patching or updating using a stale resource version results in a conflict
+	_, err = devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"annotations": map[string]string{"owner": "qa"},
+	}, client.WithResourceVersion(vm.Version))
+	require.ErrorIs(t, err, client.ErrConflict)
+
+	_, err = devClient.VMs().Update(ctx, *vm, client.WithResourceVersion(vm.Version))
+	require.ErrorIs(t, err, client.ErrConflict)
+
+	// Same for the stale generation
+	_, err = devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"annotations": map[string]string{"owner": "qa"},
+	}, client.WithGeneration(0))
+	require.ErrorIs(t, err, client.ErrConflict)
+
+	// Metadata changes don't bump the generation and are
+	// allowed in a terminal state, null removes the key
+	patchedVM, err = devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"metadataLabels": map[string]any{"team": nil},
+		"annotations":    map[string]any{"owner": "qa"},
+	}, client.WithGeneration(1))
+	require.NoError(t, err)
+	require.Empty(t, patchedVM.Meta.Labels)
+	require.Equal(t, map[string]string{"owner": "qa"}, patchedVM.Annotations)
+	require.EqualValues(t, 1, patchedVM.Generation)
+
+	// The "If-Match" header can be used instead of the "resourceVersion" query parameter
+	require.Equal(t, http.StatusConflict, patchVMRaw(t, devController.Address(), vm.Name,
+		"application/merge-patch+json", strconv.Quote(strconv.FormatUint(vm.Version, 10)),
+		`{"annotations":{"owner":"ci"}}`))
+	require.Equal(t, http.StatusOK, patchVMRaw(t, devController.Address(), vm.Name,
+		"application/merge-patch+json", strconv.Quote(strconv.FormatUint(patchedVM.Version, 10)),
+		`{"annotations":{"owner":"ci"}}`))
+
+	vm, err = devClient.VMs().Get(ctx, vm.Name)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"owner": "ci"}, vm.Annotations)
+
+	// The specification cannot be changed once in a terminal power state
+	_, err = devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"powerState": "running",
+	})
+	require.Error(t, err)
+
+	// JSON Patch (RFC 6902) is not supported
+	require.Equal(t, http.StatusUnsupportedMediaType, patchVMRaw(t, devController.Address(), vm.Name,
+		"application/json-patch+json", "", `[{"op":"replace","path":"/powerState","value":"running"}]`))
+
+	// Name cannot be changed
+	_, err = devClient.VMs().Patch(ctx, vm.Name, map[string]any{
+		"name": "other-vm",
+	})
+	require.Error(t, err)
+}
+
+func patchVMRaw(t *testing.T, baseURL, name, contentType, ifMatch, patch string) int {
+	t.Helper()
+
+	endpoint, err := url.JoinPath(baseURL, "v1", "vms", name)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPatch, endpoint, bytes.NewReader([]byte(patch)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
@@ -0,0 +1,105 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityPreemption(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	// Create a worker that is only able to run a single VM
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "low",
+		},
+		Image:  "example.com/doesnt/matter:latest",
+		CPU:    4,
+		Memory: 8 * 1024,
+		Status: v1.VMStatusPending,
+	}))
+	ensureAssignment(t, devClient, "low", "worker")
+
+	// A higher-priority VM that doesn't preempt should just wait
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "high-polite",
+		},
+		Image:    "example.com/doesnt/matter:latest",
+		CPU:      4,
+		Memory:   8 * 1024,
+		Status:   v1.VMStatusPending,
+		Priority: 100,
+	}))
+
+	vm, err := devClient.VMs().Get(ctx, "high-polite")
+	require.NoError(t, err)
+	require.Equal(t, v1.PreemptionPolicyNever, vm.PreemptionPolicy)
+	require.NoError(t, devClient.VMs().Delete(ctx, "high-polite"))
+
+	// A higher-priority VM that preempts should evict the "low" VM
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "high",
+		},
+		Image:            "example.com/doesnt/matter:latest",
+		CPU:              4,
+		Memory:           8 * 1024,
+		Status:           v1.VMStatusPending,
+		Priority:         100,
+		PreemptionPolicy: v1.PreemptionPolicyPreemptLowerPriority,
+	}))
+	ensureAssignment(t, devClient, "high", "worker")
+
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "low")
+		require.NoError(t, err)
+
+		return vm.Status == v1.VMStatusFailed && strings.Contains(vm.StatusMessage, "preempted")
+	}))
+
+	events, _, err := devClient.VMs().EventsPage(ctx, "low", client.EventsPageOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	require.Equal(t, v1.EventKindPreempted, events[len(events)-1].Kind)
+}
+
+func TestInvalidPreemptionPolicy(t *testing.T) {
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	err := devClient.VMs().Create(context.Background(), &v1.VM{
+		Meta: v1.Meta{
+			Name: "test-vm",
+		},
+		Image:            "example.com/doesnt/matter:latest",
+		PreemptionPolicy: "Sometimes",
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+}
@@ -0,0 +1,97 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotas(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, devController, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	teamClient, err := client.New(client.WithAddress(devController.Address()), client.WithNamespace("team-a"))
+	require.NoError(t, err)
+
+	_, err = devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 3,
+		},
+		DefaultCPU: 4,
+	})
+	require.NoError(t, err)
+
+	// Quotas need a scope and at least one limit
+	requireStatusCode(t, http.StatusPreconditionFailed, devClient.Quotas().Create(ctx, &v1.Quota{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+		Hard: v1.Resources{v1.QuotaResourceVMs: 1},
+	}))
+
+	require.NoError(t, devClient.Quotas().Create(ctx, &v1.Quota{
+		Meta: v1.Meta{
+			Name: "team-a",
+		},
+		Scope: v1.QuotaScope{Namespace: "team-a"},
+		Hard: v1.Resources{
+			v1.QuotaResourceVMs: 2,
+			v1.QuotaResourceCPU: 6,
+		},
+	}))
+
+	newVM := func(name string) *v1.VM {
+		return &v1.VM{
+			Meta: v1.Meta{
+				Name: name,
+			},
+			Image: "example.com/doesnt/matter:latest",
+		}
+	}
+
+	// Both VMs are admitted, but only the first one is scheduled, because
+	// the worker's default CPU would make the second one exceed the quota
+	require.NoError(t, teamClient.VMs().Create(ctx, newVM("first")))
+	ensureAssignment(t, teamClient, "first", "worker")
+
+	require.NoError(t, teamClient.VMs().Create(ctx, newVM("second")))
+
+	// The VM count limit is enforced when creating the VMs
+	requireStatusCode(t, http.StatusForbidden, teamClient.VMs().Create(ctx, newVM("third")))
+
+	// VMs in other namespaces are not limited
+	require.NoError(t, devClient.VMs().Create(ctx, newVM("unlimited")))
+	ensureAssignment(t, devClient, "unlimited", "worker")
+
+	time.Sleep(10 * time.Second)
+
+	vm, err := teamClient.VMs().Get(ctx, "second")
+	require.NoError(t, err)
+	require.Empty(t, vm.Worker)
+
+	quota, err := devClient.Quotas().Get(ctx, "team-a")
+	require.NoError(t, err)
+	require.Equal(t, v1.Resources{
+		v1.QuotaResourceVMs: 2,
+		v1.QuotaResourceCPU: 4,
+	}, quota.Used)
+
+	// Raising the limit allows the pending VM to be scheduled
+	quota.Hard[v1.QuotaResourceCPU] = 8
+	require.NoError(t, devClient.Quotas().Update(ctx, quota))
+
+	ensureAssignment(t, teamClient, "second", "worker")
+}
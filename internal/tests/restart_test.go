@@ -0,0 +1,202 @@
+package tests_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	ctx := t.Context()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	clusterSettings, err := devClient.ClusterSettings().Get(ctx)
+	require.NoError(t, err)
+	clusterSettings.RestartBackoff = &v1.RestartBackoff{
+		InitialDelaySeconds: 5,
+		MaxDelaySeconds:     10,
+	}
+	require.NoError(t, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	// Restart limit only makes sense for the OnFailure restart policy
+	vm := platformdependent.VM("restarting")
+	vm.RestartPolicy = v1.RestartPolicyNever
+	vm.MaxRestarts = 1
+	requireStatusCode(t, http.StatusPreconditionFailed, devClient.VMs().Create(ctx, vm))
+
+	// Create a dummy worker that the VM is going to be scheduled on
+	_, err = devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "dummy-worker",
+		},
+		LastSeen:  time.Now(),
+		MachineID: uuid.New().String(),
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 1,
+		},
+		Arch:    vm.Arch,
+		Runtime: vm.Runtime,
+	})
+	require.NoError(t, err)
+
+	vm.RestartPolicy = v1.RestartPolicyOnFailure
+	require.NoError(t, devClient.VMs().Create(ctx, vm))
+
+	// Fails the VM on behalf of the dummy worker once it's scheduled
+	failVM := func(restartCount uint64) {
+		var scheduledVM *v1.VM
+
+		require.True(t, wait.Wait(2*time.Minute, func() bool {
+			scheduledVM, err = devClient.VMs().Get(ctx, "restarting")
+			require.NoError(t, err)
+
+			return scheduledVM.RestartCount == restartCount && scheduledVM.Status == v1.VMStatusPending &&
+				scheduledVM.Worker == "dummy-worker"
+		}))
+
+		scheduledVM.Status = v1.VMStatusFailed
+		scheduledVM.StatusMessage = "VM exited unexpectedly"
+
+		_, err := devClient.VMs().UpdateState(ctx, *scheduledVM)
+		require.NoError(t, err)
+	}
+
+	// The failed VM is restarted once the backoff delay passes
+	failVM(0)
+
+	require.True(t, wait.Wait(2*time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "restarting")
+		require.NoError(t, err)
+
+		return vm.Status == v1.VMStatusFailed && !vm.NextRestartAt.IsZero()
+	}))
+
+	require.True(t, wait.Wait(2*time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "restarting")
+		require.NoError(t, err)
+
+		return vm.RestartCount == 1
+	}))
+
+	restartedVM, err := devClient.VMs().Get(ctx, "restarting")
+	require.NoError(t, err)
+	require.Zero(t, restartedVM.NextRestartAt)
+
+	// The VM stays failed once it reaches its restart limit
+	failVM(1)
+
+	require.True(t, wait.Wait(2*time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "restarting")
+		require.NoError(t, err)
+
+		return !vm.FinishedAt.IsZero()
+	}))
+
+	failedVM, err := devClient.VMs().Get(ctx, "restarting")
+	require.NoError(t, err)
+	require.Equal(t, v1.VMStatusFailed, failedVM.Status)
+	require.EqualValues(t, 1, failedVM.RestartCount)
+	require.Zero(t, failedVM.NextRestartAt)
+}
+
+func TestRestartBackoffReset(t *testing.T) {
+	ctx := t.Context()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	clusterSettings, err := devClient.ClusterSettings().Get(ctx)
+	require.NoError(t, err)
+	clusterSettings.RestartBackoff = &v1.RestartBackoff{
+		InitialDelaySeconds: 1,
+		MaxDelaySeconds:     60,
+		ResetAfterSeconds:   3,
+	}
+	require.NoError(t, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	vm := platformdependent.VM("restarting")
+	vm.RestartPolicy = v1.RestartPolicyOnFailure
+
+	// Create a dummy worker that the VM is going to be scheduled on
+	_, err = devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "dummy-worker",
+		},
+		LastSeen:  time.Now(),
+		MachineID: uuid.New().String(),
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 1,
+		},
+		Arch:    vm.Arch,
+		Runtime: vm.Runtime,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, devClient.VMs().Create(ctx, vm))
+
+	// Runs the VM on behalf of the dummy worker for the specified
+	// amount of time once it's scheduled and then fails it
+	failVM := func(restartCount uint64, runFor time.Duration) {
+		var scheduledVM *v1.VM
+
+		require.True(t, wait.Wait(2*time.Minute, func() bool {
+			scheduledVM, err = devClient.VMs().Get(ctx, "restarting")
+			require.NoError(t, err)
+
+			return scheduledVM.RestartCount == restartCount && scheduledVM.Status == v1.VMStatusPending &&
+				scheduledVM.Worker == "dummy-worker"
+		}))
+
+		if runFor != 0 {
+			scheduledVM.Status = v1.VMStatusRunning
+
+			scheduledVM, err = devClient.VMs().UpdateState(ctx, *scheduledVM)
+			require.NoError(t, err)
+
+			time.Sleep(runFor)
+		}
+
+		scheduledVM.Status = v1.VMStatusFailed
+		scheduledVM.StatusMessage = "VM exited unexpectedly"
+
+		_, err := devClient.VMs().UpdateState(ctx, *scheduledVM)
+		require.NoError(t, err)
+	}
+
+	waitRestarted := func(restartCount uint64) *v1.VM {
+		var restartedVM *v1.VM
+
+		require.True(t, wait.Wait(2*time.Minute, func() bool {
+			restartedVM, err = devClient.VMs().Get(ctx, "restarting")
+			require.NoError(t, err)
+
+			return restartedVM.RestartCount == restartCount
+		}))
+
+		return restartedVM
+	}
+
+	// Failures that follow each other count as consecutive
+	failVM(0, 0)
+	require.EqualValues(t, 1, waitRestarted(1).ConsecutiveFailures)
+
+	failVM(1, 0)
+	require.EqualValues(t, 2, waitRestarted(2).ConsecutiveFailures)
+
+	// Once the VM runs long enough, its failures are no longer consecutive
+	failVM(2, 4*time.Second)
+	require.EqualValues(t, 1, waitRestarted(3).ConsecutiveFailures)
+}
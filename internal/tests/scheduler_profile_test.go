@@ -7,6 +7,7 @@ import (
 	"github.com/cirruslabs/orchard/pkg/client"
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
 	"github.com/stretchr/testify/require"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -57,6 +58,37 @@ func TestSchedulerProfileDistributeLoad(t *testing.T) {
 	ensureAssignment(t, devClient, "test-vm-3", "worker-c")
 }
 
+func TestSchedulerScoreWeights(t *testing.T) {
+	ctx := context.Background()
+
+	// Create a development environment
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	clusterSettings, err := devClient.ClusterSettings().Get(ctx)
+	require.NoError(t, err)
+
+	// Unknown score plugins are rejected
+	clusterSettings.SchedulerScoreWeights = v1.SchedulerScoreWeights{"unknown": 1}
+	requireStatusCode(t, http.StatusBadRequest, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	// Score weights take precedence over the scheduler profile
+	clusterSettings.SchedulerProfile = v1.SchedulerProfileOptimizeUtilization
+	clusterSettings.SchedulerScoreWeights = v1.SchedulerScoreWeights{
+		v1.SchedulerScorePluginSpread: 1,
+	}
+	require.NoError(t, devClient.ClusterSettings().Set(ctx, clusterSettings))
+
+	// Create three workers and three VMs
+	threeWorkersThreeVMsScenario(t, devClient)
+
+	ensureAssignment(t, devClient, "test-vm-1", "worker-a")
+	ensureAssignment(t, devClient, "test-vm-2", "worker-b")
+	ensureAssignment(t, devClient, "test-vm-3", "worker-c")
+}
+
 func threeWorkersThreeVMsScenario(t *testing.T, devClient *client.Client) {
 	ctx := context.Background()
 
@@ -0,0 +1,87 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulingExplanation(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	for _, worker := range []v1.Worker{
+		{
+			Meta: v1.Meta{
+				Name: "ci",
+			},
+			Resources: map[string]uint64{
+				v1.ResourceTartVMs: 1,
+			},
+			Labels: v1.Labels{"pool": "ci"},
+		},
+		{
+			Meta: v1.Meta{
+				Name: "other",
+			},
+			Resources: map[string]uint64{
+				v1.ResourceTartVMs: 2,
+			},
+		},
+	} {
+		_, err := devClient.Workers().Create(ctx, worker)
+		require.NoError(t, err)
+	}
+
+	newVM := func(name string) *v1.VM {
+		return &v1.VM{
+			Meta: v1.Meta{
+				Name: name,
+			},
+			Image:  "example.com/doesnt/matter:latest",
+			Labels: v1.Labels{"pool": "ci"},
+		}
+	}
+
+	require.NoError(t, devClient.VMs().Create(ctx, newVM("first")))
+	ensureAssignment(t, devClient, "first", "ci")
+
+	explanation, err := devClient.VMs().Scheduling(ctx, "first")
+	require.NoError(t, err)
+	require.Equal(t, "VM is scheduled on worker ci", explanation.Message)
+
+	// The second VM doesn't fit on the only worker with the matching label
+	require.NoError(t, devClient.VMs().Create(ctx, newVM("second")))
+
+	const expectedMessage = "0/2 workers are available: 1 insufficient org.cirruslabs.tart-vms, 1 label mismatch"
+
+	require.True(t, wait.Wait(30*time.Second, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "second")
+		require.NoError(t, err)
+
+		return vm.StatusMessage == "no workers are available (most common reason: "+
+			"insufficient org.cirruslabs.tart-vms)"
+	}), "the pending VM's status message was not updated")
+
+	explanation, err = devClient.VMs().Scheduling(ctx, "second")
+	require.NoError(t, err)
+	require.Equal(t, expectedMessage, explanation.Message)
+	require.ElementsMatch(t, []v1.WorkerRejection{
+		{Worker: "ci", Reason: "insufficient org.cirruslabs.tart-vms"},
+		{Worker: "other", Reason: "label mismatch"},
+	}, explanation.Workers)
+	require.False(t, explanation.EvaluatedAt.IsZero())
+
+	vm, err := devClient.VMs().Get(ctx, "second")
+	require.NoError(t, err)
+	require.Empty(t, vm.Worker)
+}
@@ -0,0 +1,86 @@
+package tests_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaintsAndTolerations(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	signingTaint := v1.Taint{Key: "dedicated", Value: "signing", Effect: v1.TaintEffectNoSchedule}
+
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 2,
+		},
+		Taints: []v1.Taint{signingTaint},
+	})
+	require.NoError(t, err)
+
+	// Updates that don't specify the taints should leave them intact
+	worker, err := devClient.Workers().Get(ctx, "worker")
+	require.NoError(t, err)
+	worker.Taints = nil
+	worker.SchedulingPaused = false
+	_, err = devClient.Workers().Update(ctx, *worker)
+	require.NoError(t, err)
+
+	worker, err = devClient.Workers().Get(ctx, "worker")
+	require.NoError(t, err)
+	require.Equal(t, []v1.Taint{signingTaint}, worker.Taints)
+
+	// A VM that doesn't tolerate the taint is not scheduled,
+	// while a VM that tolerates it is
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "intolerant",
+		},
+		Image: "example.com/doesnt/matter:latest",
+	}))
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "signing",
+		},
+		Image: "example.com/doesnt/matter:latest",
+		Tolerations: []v1.Toleration{
+			{Key: "dedicated", Value: "signing"},
+		},
+	}))
+	ensureAssignment(t, devClient, "signing", "worker")
+
+	vm, err := devClient.VMs().Get(ctx, "intolerant")
+	require.NoError(t, err)
+	require.Empty(t, vm.Worker)
+
+	// A NoExecute taint evicts the VMs that don't tolerate it
+	_, err = devClient.Workers().Patch(ctx, "worker", map[string]any{
+		"taints": []v1.Taint{
+			signingTaint,
+			{Key: "flaky", Effect: v1.TaintEffectNoExecute},
+		},
+	})
+	require.NoError(t, err)
+
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vm, err := devClient.VMs().Get(ctx, "signing")
+		require.NoError(t, err)
+
+		return vm.Status == v1.VMStatusFailed && strings.Contains(vm.StatusMessage, "flaky:NoExecute")
+	}))
+}
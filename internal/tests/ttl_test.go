@@ -0,0 +1,85 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMTTL(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 2,
+		},
+	})
+	require.NoError(t, err)
+
+	vmDeleted := func(name string) func() bool {
+		return func() bool {
+			_, err := devClient.VMs().Get(ctx, name)
+			if err == nil {
+				return false
+			}
+
+			var apiError *client.APIError
+			require.True(t, errors.As(err, &apiError), "expected an API error, got %v", err)
+
+			return apiError.StatusCode == http.StatusNotFound
+		}
+	}
+
+	// A VM with a TTL after creation is deleted regardless of its state
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "short-lived",
+		},
+		Image:                   "example.com/doesnt/matter:latest",
+		TTLSecondsAfterCreation: lo.ToPtr[uint64](1),
+	}))
+	require.True(t, wait.Wait(time.Minute, vmDeleted("short-lived")))
+
+	// A VM with a TTL after finished is only deleted once it has finished
+	require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "batch",
+		},
+		Image:                   "example.com/doesnt/matter:latest",
+		TTLSecondsAfterFinished: lo.ToPtr[uint64](1),
+	}))
+	ensureAssignment(t, devClient, "batch", "worker")
+
+	time.Sleep(10 * time.Second)
+
+	vm, err := devClient.VMs().Get(ctx, "batch")
+	require.NoError(t, err)
+	require.True(t, vm.FinishedAt.IsZero())
+
+	// Evict the VM to make it fail
+	_, err = devClient.Workers().Patch(ctx, "worker", map[string]any{
+		"taints": []v1.Taint{
+			{Key: "flaky", Effect: v1.TaintEffectNoExecute},
+		},
+	})
+	require.NoError(t, err)
+
+	require.True(t, wait.Wait(time.Minute, vmDeleted("batch")))
+}
@@ -0,0 +1,108 @@
+package tests_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMGroupGangScheduling(t *testing.T) {
+	ctx := context.Background()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	_, err := devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 2,
+		},
+	})
+	require.NoError(t, err)
+
+	// Groups need at least one member
+	requireStatusCode(t, http.StatusPreconditionFailed, devClient.VMGroups().Create(ctx, &v1.VMGroup{
+		Meta: v1.Meta{
+			Name: "empty",
+		},
+	}))
+
+	require.NoError(t, devClient.VMGroups().Create(ctx, &v1.VMGroup{
+		Meta: v1.Meta{
+			Name: "trio",
+		},
+		MinMember: 3,
+	}))
+
+	// VMs can only refer to the existing groups
+	requireStatusCode(t, http.StatusPreconditionFailed, devClient.VMs().Create(ctx, &v1.VM{
+		Meta: v1.Meta{
+			Name: "orphan",
+		},
+		Image: "example.com/doesnt/matter:latest",
+		Group: "nonexistent",
+	}))
+
+	for i := range 3 {
+		require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+			Meta: v1.Meta{
+				Name: fmt.Sprintf("member-%d", i),
+			},
+			Image: "example.com/doesnt/matter:latest",
+			Group: "trio",
+		}))
+	}
+
+	// Only two out of three members fit on the worker,
+	// so none of them should be scheduled
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vmGroup, err := devClient.VMGroups().Get(ctx, "trio")
+		require.NoError(t, err)
+
+		t.Logf("Waiting for the VM group to report that it cannot be scheduled, current status message: %q",
+			vmGroup.StatusMessage)
+
+		return vmGroup.StatusMessage == "only 2 of the minimum 3 members can be scheduled"
+	}))
+
+	for i := range 3 {
+		vm, err := devClient.VMs().Get(ctx, fmt.Sprintf("member-%d", i))
+		require.NoError(t, err)
+		require.Empty(t, vm.Worker)
+	}
+
+	// Once there's enough capacity, all members are scheduled
+	_, err = devClient.Workers().Create(ctx, v1.Worker{
+		Meta: v1.Meta{
+			Name: "another-worker",
+		},
+		Resources: map[string]uint64{
+			v1.ResourceTartVMs: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	for i := range 3 {
+		waitForWorker(t, devClient, fmt.Sprintf("member-%d", i))
+	}
+
+	vmGroup, err := devClient.VMGroups().Get(ctx, "trio")
+	require.NoError(t, err)
+	require.Equal(t, v1.VMGroupStatusScheduled, vmGroup.Status)
+	require.Empty(t, vmGroup.StatusMessage)
+	require.False(t, vmGroup.ScheduledAt.IsZero())
+
+	// Groups with VMs cannot be deleted
+	requireStatusCode(t, http.StatusPreconditionFailed, devClient.VMGroups().Delete(ctx, "trio"))
+}
@@ -0,0 +1,188 @@
+package tests_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller"
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/internal/worker"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMPool(t *testing.T) {
+	ctx := t.Context()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, []controller.Option{controller.WithSynthetic()},
+		false, []worker.Option{worker.WithSynthetic()},
+	)
+
+	template := platformdependent.VM("")
+	template.CPU = 1
+	template.Memory = 512
+
+	// Pools without an image are rejected
+	err := devClient.VMPools().Create(ctx, &v1.VMPool{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+		Replicas: 1,
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	require.NoError(t, devClient.VMPools().Create(ctx, &v1.VMPool{
+		Meta: v1.Meta{
+			Name: "ci",
+		},
+		Replicas: 1,
+		Template: *template,
+	}))
+
+	poolVMs := func() []v1.VM {
+		vms, err := devClient.VMs().List(ctx)
+		require.NoError(t, err)
+
+		return lo.Filter(vms, func(vm v1.VM, _ int) bool {
+			return vm.Pool == "ci"
+		})
+	}
+
+	// The pool creates a warm VM
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vmPool, err := devClient.VMPools().Get(ctx, "ci")
+		require.NoError(t, err)
+
+		return vmPool.ReadyReplicas == 1
+	}))
+
+	warmVMs := poolVMs()
+	require.Len(t, warmVMs, 1)
+
+	// Claiming hands out the warm VM and re-labels it
+	claimedVM, err := devClient.VMPools().Claim(ctx, "ci", &v1.VMPoolClaim{
+		Labels: map[string]string{"job": "42"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, warmVMs[0].Name, claimedVM.Name)
+	require.Equal(t, warmVMs[0].UID, claimedVM.UID)
+	require.Empty(t, claimedVM.Pool)
+	require.Equal(t, "42", claimedVM.Meta.Labels["job"])
+	require.Equal(t, v1.VMStatusRunning, claimedVM.Status)
+
+	// The pool replaces the claimed VM
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vms := poolVMs()
+
+		return len(vms) == 1 && vms[0].Name != claimedVM.Name
+	}))
+
+	// The claimed VM keeps running
+	vm, err := devClient.VMs().Get(ctx, claimedVM.Name)
+	require.NoError(t, err)
+	require.Equal(t, v1.VMStatusRunning, vm.Status)
+
+	// Claiming from an empty pool is a miss
+	require.NoError(t, devClient.VMPools().Create(ctx, &v1.VMPool{
+		Meta: v1.Meta{
+			Name: "empty",
+		},
+		Template: *template,
+	}))
+
+	_, err = devClient.VMPools().Claim(ctx, "empty", nil)
+	requireStatusCode(t, http.StatusConflict, err)
+
+	vmPool, err := devClient.VMPools().Get(ctx, "empty")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, vmPool.Hits)
+	require.EqualValues(t, 1, vmPool.Misses)
+
+	vmPool, err = devClient.VMPools().Get(ctx, "ci")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, vmPool.Hits)
+	require.EqualValues(t, 0, vmPool.Misses)
+
+	// Deleting the pool deletes its unclaimed VMs, but not the claimed one
+	require.NoError(t, devClient.VMPools().Delete(ctx, "ci"))
+	require.Empty(t, poolVMs())
+
+	_, err = devClient.VMs().Get(ctx, claimedVM.Name)
+	require.NoError(t, err)
+}
+
+func TestVMPoolScaleDown(t *testing.T) {
+	ctx := t.Context()
+
+	// The worker only has room for two of the pool's VMs
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, []controller.Option{controller.WithSynthetic()},
+		false, []worker.Option{worker.WithSynthetic(), worker.WithResources(v1.Resources{
+			"pool-slot": 2,
+		})},
+	)
+
+	template := platformdependent.VM("")
+	template.CPU = 1
+	template.Memory = 512
+	template.Resources = v1.Resources{
+		"pool-slot": 1,
+	}
+
+	require.NoError(t, devClient.VMPools().Create(ctx, &v1.VMPool{
+		Meta: v1.Meta{
+			Name: "ci",
+		},
+		Replicas: 3,
+		Template: *template,
+	}))
+
+	poolVMs := func() []v1.VM {
+		vms, err := devClient.VMs().List(ctx)
+		require.NoError(t, err)
+
+		return lo.Filter(vms, func(vm v1.VM, _ int) bool {
+			return vm.Pool == "ci"
+		})
+	}
+
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		vmPool, err := devClient.VMPools().Get(ctx, "ci")
+		require.NoError(t, err)
+
+		return vmPool.ReadyReplicas == 2 && len(poolVMs()) == 3
+	}))
+
+	runningVMs := lo.Filter(poolVMs(), func(vm v1.VM, _ int) bool {
+		return vm.Status == v1.VMStatusRunning
+	})
+	require.Len(t, runningVMs, 2)
+
+	// Scaling down deletes the VM that is not running yet
+	vmPool, err := devClient.VMPools().Scale(ctx, "ci", 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, vmPool.Replicas)
+
+	require.True(t, wait.Wait(time.Minute, func() bool {
+		return len(poolVMs()) == 2
+	}))
+
+	require.ElementsMatch(t, lo.Map(runningVMs, func(vm v1.VM, _ int) string {
+		return vm.UID
+	}), lo.Map(poolVMs(), func(vm v1.VM, _ int) string {
+		return vm.UID
+	}))
+
+	// The pool's template cannot be invalidated
+	_, err = devClient.VMPools().Patch(ctx, "ci", map[string]any{
+		"template": map[string]any{
+			"image": "",
+		},
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+}
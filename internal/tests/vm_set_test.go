@@ -0,0 +1,119 @@
+package tests_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/controller"
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/internal/tests/platformdependent"
+	"github.com/cirruslabs/orchard/internal/tests/wait"
+	"github.com/cirruslabs/orchard/internal/worker"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMSet(t *testing.T) {
+	ctx := t.Context()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, []controller.Option{controller.WithSynthetic()},
+		false, []worker.Option{worker.WithSynthetic()},
+	)
+
+	template := platformdependent.VM("")
+	template.CPU = 1
+	template.Memory = 512
+
+	// Sets without an image are rejected
+	err := devClient.VMSets().Create(ctx, &v1.VMSet{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+		Replicas: 1,
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	require.NoError(t, devClient.VMSets().Create(ctx, &v1.VMSet{
+		Meta: v1.Meta{
+			Name: "agents",
+		},
+		Replicas: 2,
+		Template: *template,
+	}))
+
+	setVMs := func() []v1.VM {
+		vms, err := devClient.VMs().List(ctx)
+		require.NoError(t, err)
+
+		return lo.Filter(vms, func(vm v1.VM, _ int) bool {
+			return vm.VMSet == "agents"
+		})
+	}
+
+	waitRolledOut := func(replicas uint64, generation uint64) {
+		require.True(t, wait.Wait(2*time.Minute, func() bool {
+			vmSet, err := devClient.VMSets().Get(ctx, "agents")
+			require.NoError(t, err)
+
+			return vmSet.Generation == generation && vmSet.Replicas == replicas && vmSet.RolledOut() &&
+				uint64(len(setVMs())) == replicas
+		}))
+	}
+
+	// The set creates the VMs
+	waitRolledOut(2, 0)
+
+	// The deleted VMs are re-created
+	deletedVM := setVMs()[0]
+	require.NoError(t, devClient.VMs().Delete(ctx, deletedVM.Name))
+
+	require.True(t, wait.Wait(2*time.Minute, func() bool {
+		vms := setVMs()
+
+		return len(vms) == 2 && !lo.ContainsBy(vms, func(vm v1.VM) bool {
+			return vm.UID == deletedVM.UID
+		})
+	}))
+	waitRolledOut(2, 0)
+
+	// Scaling up creates more VMs
+	vmSet, err := devClient.VMSets().Scale(ctx, "agents", 3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, vmSet.Replicas)
+	require.EqualValues(t, 0, vmSet.Generation)
+
+	waitRolledOut(3, 0)
+
+	// Template changes are rolled out by replacing the VMs
+	oldVMs := setVMs()
+
+	_, err = devClient.VMSets().Patch(ctx, "agents", map[string]any{
+		"template": map[string]any{
+			"memory": 256,
+		},
+	})
+	require.NoError(t, err)
+
+	waitRolledOut(3, 1)
+
+	for _, vm := range setVMs() {
+		require.EqualValues(t, 256, vm.Memory)
+		require.EqualValues(t, 1, vm.VMSetGeneration)
+		require.False(t, lo.ContainsBy(oldVMs, func(oldVM v1.VM) bool {
+			return oldVM.UID == vm.UID
+		}))
+	}
+
+	// Scaling down deletes the VMs
+	_, err = devClient.VMSets().Scale(ctx, "agents", 1)
+	require.NoError(t, err)
+
+	waitRolledOut(1, 1)
+
+	// Deleting the set deletes its VMs
+	require.NoError(t, devClient.VMSets().Delete(ctx, "agents"))
+	require.Empty(t, setVMs())
+}
@@ -0,0 +1,112 @@
+package tests_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	"github.com/cirruslabs/orchard/pkg/client"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMTemplate(t *testing.T) {
+	ctx := t.Context()
+
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	// Templates cannot refer to other templates
+	err := devClient.VMTemplates().Create(ctx, &v1.VMTemplate{
+		Meta: v1.Meta{
+			Name: "invalid",
+		},
+		Spec: v1.VM{
+			Image:    "example.com/macos:latest",
+			Template: "macos",
+		},
+	})
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	require.NoError(t, devClient.VMTemplates().Create(ctx, &v1.VMTemplate{
+		Meta: v1.Meta{
+			Name: "macos",
+		},
+		Spec: v1.VM{
+			Meta: v1.Meta{
+				Labels: map[string]string{"team": "platform"},
+			},
+			Image:    "example.com/macos:sequoia",
+			CPU:      4,
+			Memory:   8 * 1024,
+			Headless: true,
+			Labels:   map[string]string{"rack": "a"},
+		},
+	}))
+
+	// Template's defaults are only provided once the VM is created
+	vmTemplate, err := devClient.VMTemplates().Get(ctx, "macos")
+	require.NoError(t, err)
+	require.Empty(t, vmTemplate.Spec.RestartPolicy)
+
+	// Dry run instantiates the template without creating the VM
+	simulatedVM, err := devClient.VMs().CreateFromTemplate(ctx, "first", "macos", map[string]any{
+		"cpu": 8,
+	}, true)
+	require.NoError(t, err)
+	require.Equal(t, "example.com/macos:sequoia", simulatedVM.Image)
+	require.EqualValues(t, 8, simulatedVM.CPU)
+
+	_, err = devClient.VMs().Get(ctx, "first")
+	requireStatusCode(t, http.StatusNotFound, err)
+
+	// The VM inherits the template's fields, except for the overridden ones
+	_, err = devClient.VMs().CreateFromTemplate(ctx, "first", "macos", map[string]any{
+		"cpu":            8,
+		"headless":       nil,
+		"metadataLabels": map[string]string{"job": "42"},
+	}, false)
+	require.NoError(t, err)
+
+	firstVM, err := devClient.VMs().Get(ctx, "first")
+	require.NoError(t, err)
+	require.Equal(t, "macos", firstVM.Template)
+	require.Equal(t, "example.com/macos:sequoia", firstVM.Image)
+	require.EqualValues(t, 8, firstVM.CPU)
+	require.EqualValues(t, 8*1024, firstVM.Memory)
+	require.False(t, firstVM.Headless)
+	require.Equal(t, v1.Labels{"rack": "a"}, firstVM.Labels)
+	require.Equal(t, map[string]string{"team": "platform", "job": "42"}, firstVM.Meta.Labels)
+	require.Equal(t, v1.RestartPolicyNever, firstVM.RestartPolicy)
+	require.Equal(t, v1.VMStatusPending, firstVM.Status)
+
+	// Creating a VM from a non-existent template fails
+	_, err = devClient.VMs().CreateFromTemplate(ctx, "second", "linux", nil, false)
+	requireStatusCode(t, http.StatusPreconditionFailed, err)
+
+	// Updating the template only affects the VMs created afterward
+	vmTemplate.Spec.Image = "example.com/macos:tahoe"
+
+	_, err = devClient.VMTemplates().Update(ctx, vmTemplate, client.WithResourceVersion(vmTemplate.Version))
+	require.NoError(t, err)
+
+	_, err = devClient.VMs().CreateFromTemplate(ctx, "second", "macos", nil, false)
+	require.NoError(t, err)
+
+	secondVM, err := devClient.VMs().Get(ctx, "second")
+	require.NoError(t, err)
+	require.Equal(t, "example.com/macos:tahoe", secondVM.Image)
+	require.EqualValues(t, 4, secondVM.CPU)
+
+	firstVM, err = devClient.VMs().Get(ctx, "first")
+	require.NoError(t, err)
+	require.Equal(t, "example.com/macos:sequoia", firstVM.Image)
+
+	// Deleting the template doesn't affect the VMs created from it
+	require.NoError(t, devClient.VMTemplates().Delete(ctx, "macos"))
+
+	_, err = devClient.VMs().Get(ctx, "second")
+	require.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cirruslabs/orchard/internal/tests/devcontroller"
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchVMs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// Create a development environment
+	devClient, _, _ := devcontroller.StartIntegrationTestEnvironmentWithAdditionalOpts(t,
+		false, nil,
+		true, nil,
+	)
+
+	createVM := func(name string) {
+		require.NoError(t, devClient.VMs().Create(ctx, &v1.VM{
+			Meta: v1.Meta{
+				Name: name,
+			},
+			Image:    "example.com/doesnt/matter:latest",
+			CPU:      4,
+			Memory:   8 * 1024,
+			Headless: true,
+		}))
+	}
+
+	receive := func(watchCh chan v1.WatchMessage[v1.VM], errCh chan error) v1.WatchMessage[v1.VM] {
+		select {
+		case watchMessage := <-watchCh:
+			return watchMessage
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for watch message")
+		}
+
+		return v1.WatchMessage[v1.VM]{}
+	}
+
+	createVM("test-vm-1")
+
+	// Watching without a resource version streams the existing VMs first
+	watchCtx, watchCancel := context.WithCancel(ctx)
+
+	watchCh, errCh, err := devClient.VMs().Watch(watchCtx, 0)
+	require.NoError(t, err)
+
+	watchMessage := receive(watchCh, errCh)
+	require.Equal(t, v1.WatchMessageTypeAdded, watchMessage.Type)
+	require.Equal(t, "test-vm-1", watchMessage.Object.Name)
+
+	resourceVersion := watchMessage.Object.Version
+
+	watchCancel()
+
+	// Resuming from a resource version streams
+	// the changes that were made in the meantime
+	createVM("test-vm-2")
+	require.NoError(t, devClient.VMs().Delete(ctx, "test-vm-1"))
+
+	watchCh, errCh, err = devClient.VMs().Watch(ctx, resourceVersion)
+	require.NoError(t, err)
+
+	// Skip the modifications made by the scheduler
+	var messages []v1.WatchMessage[v1.VM]
+
+	for len(messages) < 2 {
+		watchMessage := receive(watchCh, errCh)
+		if watchMessage.Type == v1.WatchMessageTypeModified {
+			continue
+		}
+
+		messages = append(messages, watchMessage)
+	}
+
+	require.Equal(t, v1.WatchMessageTypeAdded, messages[0].Type)
+	require.Equal(t, "test-vm-2", messages[0].Object.Name)
+	require.Equal(t, v1.WatchMessageTypeDeleted, messages[1].Type)
+	require.Equal(t, "test-vm-1", messages[1].Object.Name)
+}
@@ -2,6 +2,7 @@ package vmmanager
 
 import (
 	"context"
+	"strings"
 
 	"github.com/cirruslabs/orchard/internal/worker/ondiskname"
 	"github.com/cirruslabs/orchard/pkg/client"
@@ -33,6 +34,12 @@ type VMInfo struct {
 	Running bool
 }
 
+// Cached returns true if the VM info describes an image
+// that was pulled from an OCI registry and cached locally.
+func (vmInfo VMInfo) Cached() bool {
+	return strings.EqualFold(vmInfo.Source, "oci")
+}
+
 type VMManager struct {
 	vms *xsync.Map[ondiskname.OnDiskName, VM]
 }
@@ -225,7 +225,9 @@ func (worker *Worker) runNewSession(ctx context.Context) error {
 	updateFuncInner := worker.client.VMs().UpdateState
 
 	if !info.Capabilities.Has(v1.ControllerCapabilityVMStateEndpoint) {
-		updateFuncInner = worker.client.VMs().Update
+		updateFuncInner = func(ctx context.Context, vm v1.VM) (*v1.VM, error) {
+			return worker.client.VMs().Update(ctx, vm)
+		}
 	}
 
 	// Ignore HTTP 404, because the VM might no longer exist while we're still processing it
@@ -297,6 +299,7 @@ func (worker *Worker) registerWorker(ctx context.Context) error {
 		MachineID:     platformUUID,
 		DefaultCPU:    worker.defaultCPU,
 		DefaultMemory: worker.defaultMemory,
+		CachedImages:  worker.cachedImages(ctx),
 	})
 	if err != nil {
 		return err
@@ -316,6 +319,9 @@ func (worker *Worker) updateWorker(ctx context.Context) error {
 	worker.logger.Debugf("got worker from the API")
 
 	workerResource.LastSeen = time.Now()
+	if cachedImages := worker.cachedImages(ctx); cachedImages != nil {
+		workerResource.CachedImages = cachedImages
+	}
 
 	if _, err := worker.client.Workers().Update(ctx, *workerResource); err != nil {
 		return fmt.Errorf("%w: failed to update worker in the API: %v", ErrPollFailed, err)
@@ -326,6 +332,32 @@ func (worker *Worker) updateWorker(ctx context.Context) error {
 	return nil
 }
 
+// cachedImages returns the images cached by the runtime or nil if they
+// can't be determined, in which case the previously reported images
+// are left intact.
+func (worker *Worker) cachedImages(ctx context.Context) []string {
+	if worker.runtime.Synthetic() {
+		return nil
+	}
+
+	vmInfos, err := worker.runtime.ListVMs(ctx, worker.logger)
+	if err != nil {
+		worker.logger.Warnf("failed to list the cached images: %v", err)
+
+		return nil
+	}
+
+	cachedImages := []string{}
+
+	for _, vmInfo := range vmInfos {
+		if vmInfo.Cached() {
+			cachedImages = append(cachedImages, vmInfo.Name)
+		}
+	}
+
+	return cachedImages
+}
+
 //nolint:nestif,gocognit // nested "if" and cognitive complexity is tolerable for now
 func (worker *Worker) syncVMs(ctx context.Context, updateVM func(context.Context, v1.VM) error) error {
 	allKeys := mapset.NewSet[ondiskname.OnDiskName]()
@@ -440,7 +472,7 @@ func (worker *Worker) syncVMs(ctx context.Context, updateVM func(context.Context
 
 					if vmResource.PowerState == v1.PowerStateRunning {
 						// Start the VM
-						eventStreamer := worker.client.VMs().StreamEvents(vmResource.Name)
+						eventStreamer := worker.client.VMs().InNamespace(vmResource.Namespace).StreamEvents(vmResource.Name)
 						vm.Start(eventStreamer)
 					}
 				}
@@ -595,7 +627,7 @@ func (worker *Worker) deleteVM(vm vmmanager.VM) error {
 }
 
 func (worker *Worker) createVM(odn ondiskname.OnDiskName, vmResource v1.VM) {
-	eventStreamer := worker.client.VMs().StreamEvents(vmResource.Name)
+	eventStreamer := worker.client.VMs().InNamespace(vmResource.Namespace).StreamEvents(vmResource.Name)
 
 	vm := worker.runtime.NewVM(vmResource, eventStreamer, worker.vmPullTimeHistogram, worker.dialer, worker.logger)
 
@@ -56,6 +56,8 @@ type Client struct {
 	serviceAccountName  string
 	serviceAccountToken string
 
+	namespace string
+
 	dialer dialer.Dialer
 }
 
@@ -172,6 +174,10 @@ func (client *Client) configureFromDefaultContext() error {
 	client.serviceAccountName = defaultContext.ServiceAccountName
 	client.serviceAccountToken = defaultContext.ServiceAccountToken
 
+	if client.namespace == "" {
+		client.namespace = defaultContext.Namespace
+	}
+
 	if client.trustedCertificate == nil {
 		client.trustedCertificate, err = defaultContext.TrustedCertificate()
 		if err != nil {
@@ -182,6 +188,30 @@ func (client *Client) configureFromDefaultContext() error {
 	return nil
 }
 
+// withNamespace adds the client's namespace (if any) to the request parameters
+// of the namespaced resources, allocating the parameters if necessary.
+func (client *Client) withNamespace(params map[string]string) map[string]string {
+	if client.namespace == "" {
+		return params
+	}
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	params["namespace"] = client.namespace
+
+	return params
+}
+
+// listOptionsWithNamespace prepends the client's namespace to the list
+// options, so that it can be overridden by WithListAllNamespaces().
+func (client *Client) listOptionsWithNamespace(opts []ListOption) []ListOption {
+	return append([]ListOption{func(params map[string]string) {
+		client.withNamespace(params)
+	}}, opts...)
+}
+
 func (client *Client) requestWithHeaders(
 	ctx context.Context,
 	method string,
@@ -190,6 +220,56 @@ func (client *Client) requestWithHeaders(
 	out interface{},
 	params map[string]string,
 ) (http.Header, error) {
+	response, err := client.doRequest(ctx, client.httpClient, method, path, in, params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if out != nil {
+		bodyBytes, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w to read response body: %v", ErrAPI, err)
+		}
+
+		if err := json.Unmarshal(bodyBytes, out); err != nil {
+			return nil, fmt.Errorf("%w to unmarshal response body: %v", ErrAPI, err)
+		}
+	}
+
+	return response.Header, nil
+}
+
+// requestStream is similar to request(), but returns the response body
+// as is instead of unmarshalling it, and doesn't impose any timeout
+// on the request, so it's suitable for streaming large responses.
+func (client *Client) requestStream(
+	ctx context.Context,
+	method string,
+	path string,
+	params map[string]string,
+) (io.ReadCloser, error) {
+	httpClient := *client.httpClient
+	httpClient.Timeout = 0
+
+	response, err := client.doRequest(ctx, &httpClient, method, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (client *Client) doRequest(
+	ctx context.Context,
+	httpClient *http.Client,
+	method string,
+	path string,
+	in interface{},
+	params map[string]string,
+) (*http.Response, error) {
 	var body io.Reader
 
 	if in != nil {
@@ -216,15 +296,24 @@ func (client *Client) requestWithHeaders(
 
 	client.modifyHeader(request.Header)
 
-	response, err := client.httpClient.Do(request)
+	if in != nil {
+		if method == http.MethodPatch {
+			request.Header.Set("Content-Type", "application/merge-patch+json")
+		} else {
+			request.Header.Set("Content-Type", "application/json")
+		}
+	}
+
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("%w to make a request: %v", ErrFailed, err)
 	}
-	defer func() {
-		_ = response.Body.Close()
-	}()
 
 	if response.StatusCode != http.StatusOK {
+		defer func() {
+			_ = response.Body.Close()
+		}()
+
 		apiError := &APIError{
 			StatusCode: response.StatusCode,
 		}
@@ -234,18 +323,7 @@ func (client *Client) requestWithHeaders(
 			detailsFromErrorResponseBody(response.Body))
 	}
 
-	if out != nil {
-		bodyBytes, err := io.ReadAll(response.Body)
-		if err != nil {
-			return nil, fmt.Errorf("%w to read response body: %v", ErrAPI, err)
-		}
-
-		if err := json.Unmarshal(bodyBytes, out); err != nil {
-			return nil, fmt.Errorf("%w to unmarshal response body: %v", ErrAPI, err)
-		}
-	}
-
-	return response.Header, nil
+	return response, nil
 }
 
 func (client *Client) request(
@@ -374,6 +452,36 @@ func (client *Client) VMs() *VMsService {
 	}
 }
 
+func (client *Client) VMGroups() *VMGroupsService {
+	return &VMGroupsService{
+		client: client,
+	}
+}
+
+func (client *Client) VMPools() *VMPoolsService {
+	return &VMPoolsService{
+		client: client,
+	}
+}
+
+func (client *Client) VMSets() *VMSetsService {
+	return &VMSetsService{
+		client: client,
+	}
+}
+
+func (client *Client) VMTemplates() *VMTemplatesService {
+	return &VMTemplatesService{
+		client: client,
+	}
+}
+
+func (client *Client) Quotas() *QuotasService {
+	return &QuotasService{
+		client: client,
+	}
+}
+
 func (client *Client) ServiceAccounts() *ServiceAccountsService {
 	return &ServiceAccountsService{
 		client: client,
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"io"
 	"net/http"
 )
 
@@ -21,3 +22,11 @@ func (service *ControllerService) Info(ctx context.Context) (v1.ControllerInfo,
 
 	return controllerInfo, nil
 }
+
+// Backup returns a tar archive containing a consistent snapshot of the controller's
+// database and PKI material, which can be later restored with "orchard controller restore".
+//
+// It's the caller's responsibility to close the returned reader.
+func (service *ControllerService) Backup(ctx context.Context) (io.ReadCloser, error) {
+	return service.client.requestStream(ctx, http.MethodGet, "controller/backup", nil)
+}
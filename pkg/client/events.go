@@ -35,7 +35,8 @@ func (streamer *EventStreamer) stream() {
 
 	for {
 		events, finished := streamer.readAvailableEvents()
-		err := streamer.client.request(ctx, http.MethodPost, streamer.endpoint, events, nil, nil)
+		err := streamer.client.request(ctx, http.MethodPost, streamer.endpoint, events, nil,
+			streamer.client.withNamespace(nil))
 		if err != nil {
 			streamer.sendErr = multierror.Append(streamer.sendErr, err)
 		}
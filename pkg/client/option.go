@@ -3,6 +3,7 @@ package client
 import (
 	"crypto/x509"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cirruslabs/orchard/internal/dialer"
@@ -36,6 +37,18 @@ func WithDialer(dialer dialer.Dialer) Option {
 	}
 }
 
+// WithNamespace makes the client operate on the namespaced resources (such as VMs)
+// in the given namespace instead of the namespace configured in the default context.
+//
+// When no namespace is configured, the VMs are created and looked up in the default
+// namespace, while listing them returns the VMs from all namespaces that the service
+// account has access to.
+func WithNamespace(namespace string) Option {
+	return func(client *Client) {
+		client.namespace = namespace
+	}
+}
+
 type ListInput struct {
 	Filters []v1.Filter
 }
@@ -57,3 +70,71 @@ func WithListFilters(filters ...v1.Filter) ListOption {
 		params["filter"] = strings.Join(pairs, ",")
 	}
 }
+
+// WithListSelector only returns the resources matching the selector, see v1.Selector for the syntax.
+func WithListSelector(selector v1.Selector) ListOption {
+	return func(params map[string]string) {
+		if len(selector) == 0 {
+			return
+		}
+
+		params["selector"] = selector.String()
+	}
+}
+
+// WithListLimit limits the number of resources returned in a single page,
+// the cursor for the next page is returned by the ListPage() methods.
+func WithListLimit(limit int) ListOption {
+	return func(params map[string]string) {
+		if limit <= 0 {
+			return
+		}
+
+		params["limit"] = strconv.Itoa(limit)
+	}
+}
+
+// WithListCursor continues the listing from the cursor
+// returned by the previous ListPage() method call.
+func WithListCursor(cursor string) ListOption {
+	return func(params map[string]string) {
+		if cursor == "" {
+			return
+		}
+
+		params["cursor"] = cursor
+	}
+}
+
+// WithListAllNamespaces lists the namespaced resources (such as VMs) in all namespaces
+// that the service account has access to, ignoring the client's namespace.
+func WithListAllNamespaces() ListOption {
+	return func(params map[string]string) {
+		delete(params, "namespace")
+	}
+}
+
+type UpdateOption func(params map[string]string)
+
+// WithResourceVersion only performs the update if the resource's Version
+// still equals to the version, otherwise ErrConflict is returned.
+func WithResourceVersion(version uint64) UpdateOption {
+	return func(params map[string]string) {
+		if version == 0 {
+			return
+		}
+
+		params["resourceVersion"] = strconv.FormatUint(version, 10)
+	}
+}
+
+// WithGeneration only performs the VM update if the VM's specification Generation
+// still equals to the generation, otherwise ErrConflict is returned.
+//
+// Unlike WithResourceVersion, this precondition is not affected
+// by the VM status changes reported by the worker.
+func WithGeneration(generation uint64) UpdateOption {
+	return func(params map[string]string) {
+		params["generation"] = strconv.FormatUint(generation, 10)
+	}
+}
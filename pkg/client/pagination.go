@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+const defaultPageSize = 100
+
+func listPage[T any](
+	ctx context.Context,
+	client *Client,
+	path string,
+	opts []ListOption,
+) ([]T, string, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	var items []T
+
+	headers, err := client.requestWithHeaders(ctx, http.MethodGet, path, nil, &items, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, headers.Get("X-Next-Cursor"), nil
+}
+
+// iterate lists the resources page by page, retrieving
+// the next page only once the previous one is consumed.
+func iterate[T any](
+	ctx context.Context,
+	client *Client,
+	path string,
+	pageSize int,
+	opts []ListOption,
+) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var cursor string
+
+		for {
+			pageOpts := append(opts[:len(opts):len(opts)], WithListLimit(pageSize), WithListCursor(cursor))
+
+			items, nextCursor, err := listPage[T](ctx, client, path, pageOpts)
+			if err != nil {
+				var zero T
+
+				yield(zero, err)
+
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if nextCursor == "" {
+				return
+			}
+
+			cursor = nextCursor
+		}
+	}
+}
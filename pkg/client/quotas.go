@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"net/http"
+	"net/url"
+)
+
+type QuotasService struct {
+	client *Client
+}
+
+func (service *QuotasService) Create(ctx context.Context, quota *v1.Quota) error {
+	err := service.client.request(ctx, http.MethodPost, "quotas",
+		quota, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *QuotasService) List(ctx context.Context, opts ...ListOption) ([]v1.Quota, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	var quotas []v1.Quota
+
+	err := service.client.request(ctx, http.MethodGet, "quotas",
+		nil, &quotas, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return quotas, nil
+}
+
+// Get retrieves the quota along with its current usage.
+func (service *QuotasService) Get(ctx context.Context, name string) (*v1.Quota, error) {
+	var quota v1.Quota
+
+	err := service.client.request(ctx, http.MethodGet,
+		fmt.Sprintf("quotas/%s", url.PathEscape(name)),
+		nil, &quota, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quota, nil
+}
+
+func (service *QuotasService) Update(ctx context.Context, quota *v1.Quota, opts ...UpdateOption) error {
+	err := service.client.request(ctx, http.MethodPut,
+		fmt.Sprintf("quotas/%s", url.PathEscape(quota.Name)),
+		quota, nil, updateParams(opts))
+	if err != nil {
+		return mapConflictErr(err)
+	}
+
+	return nil
+}
+
+func (service *QuotasService) Delete(ctx context.Context, name string) error {
+	err := service.client.request(ctx, http.MethodDelete,
+		fmt.Sprintf("quotas/%s", url.PathEscape(name)),
+		nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -22,11 +22,18 @@ func (service *ServiceAccountsService) Create(ctx context.Context, serviceAccoun
 	return nil
 }
 
-func (service *ServiceAccountsService) List(ctx context.Context) ([]v1.ServiceAccount, error) {
+func (service *ServiceAccountsService) List(ctx context.Context, opts ...ListOption) ([]v1.ServiceAccount, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	var serviceAccounts []v1.ServiceAccount
 
 	err := service.client.request(ctx, http.MethodGet, "service-accounts",
-		nil, &serviceAccounts, nil)
+		nil, &serviceAccounts, params)
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +41,15 @@ func (service *ServiceAccountsService) List(ctx context.Context) ([]v1.ServiceAc
 	return serviceAccounts, nil
 }
 
+// Watch streams the changes to all service accounts that have
+// happened after the resourceVersion, see VMsService.Watch() for details.
+func (service *ServiceAccountsService) Watch(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan v1.WatchMessage[v1.ServiceAccount], chan error, error) {
+	return watch[v1.ServiceAccount](ctx, service.client, "service-accounts", resourceVersion, nil)
+}
+
 func (service *ServiceAccountsService) Get(ctx context.Context, name string) (*v1.ServiceAccount, error) {
 	var serviceAccount v1.ServiceAccount
 
@@ -47,17 +63,40 @@ func (service *ServiceAccountsService) Get(ctx context.Context, name string) (*v
 	return &serviceAccount, nil
 }
 
-func (service *ServiceAccountsService) Update(ctx context.Context, serviceAccount *v1.ServiceAccount) error {
+func (service *ServiceAccountsService) Update(
+	ctx context.Context,
+	serviceAccount *v1.ServiceAccount,
+	opts ...UpdateOption,
+) error {
 	err := service.client.request(ctx, http.MethodPut,
 		fmt.Sprintf("service-accounts/%s", url.PathEscape(serviceAccount.Name)),
-		serviceAccount, nil, nil)
+		serviceAccount, nil, updateParams(opts))
 	if err != nil {
-		return err
+		return mapConflictErr(err)
 	}
 
 	return nil
 }
 
+// Patch updates the service account using a JSON Merge Patch (RFC 7386), see VMsService.Patch() for details.
+func (service *ServiceAccountsService) Patch(
+	ctx context.Context,
+	name string,
+	patch any,
+	opts ...UpdateOption,
+) (*v1.ServiceAccount, error) {
+	var patchedServiceAccount v1.ServiceAccount
+
+	err := service.client.request(ctx, http.MethodPatch,
+		fmt.Sprintf("service-accounts/%s", url.PathEscape(name)),
+		patch, &patchedServiceAccount, updateParams(opts))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &patchedServiceAccount, nil
+}
+
 func (service *ServiceAccountsService) Delete(ctx context.Context, name string, force bool) error {
 	params := map[string]string{}
 
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrConflict is returned by the update and patch methods when
+// the precondition specified using WithResourceVersion or
+// WithGeneration is not satisfied.
+var ErrConflict = errors.New("resource was modified concurrently")
+
+func updateParams(opts []UpdateOption) map[string]string {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	return params
+}
+
+func mapConflictErr(err error) error {
+	var apiError *APIError
+
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	}
+
+	return err
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"net/http"
+	"net/url"
+)
+
+type VMGroupsService struct {
+	client *Client
+}
+
+func (service *VMGroupsService) Create(ctx context.Context, vmGroup *v1.VMGroup) error {
+	err := service.client.request(ctx, http.MethodPost, "vm-groups",
+		vmGroup, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *VMGroupsService) List(ctx context.Context, opts ...ListOption) ([]v1.VMGroup, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range service.client.listOptionsWithNamespace(opts) {
+		opt(params)
+	}
+
+	var vmGroups []v1.VMGroup
+
+	err := service.client.request(ctx, http.MethodGet, "vm-groups",
+		nil, &vmGroups, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmGroups, nil
+}
+
+func (service *VMGroupsService) Get(ctx context.Context, name string) (*v1.VMGroup, error) {
+	var vmGroup v1.VMGroup
+
+	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("vm-groups/%s", url.PathEscape(name)),
+		nil, &vmGroup, service.client.withNamespace(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmGroup, nil
+}
+
+func (service *VMGroupsService) Delete(ctx context.Context, name string) error {
+	err := service.client.request(ctx, http.MethodDelete, fmt.Sprintf("vm-groups/%s", url.PathEscape(name)),
+		nil, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"net/http"
+	"net/url"
+)
+
+type VMPoolsService struct {
+	client *Client
+}
+
+func (service *VMPoolsService) Create(ctx context.Context, vmPool *v1.VMPool) error {
+	err := service.client.request(ctx, http.MethodPost, "pools",
+		vmPool, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *VMPoolsService) List(ctx context.Context, opts ...ListOption) ([]v1.VMPool, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range service.client.listOptionsWithNamespace(opts) {
+		opt(params)
+	}
+
+	var vmPools []v1.VMPool
+
+	err := service.client.request(ctx, http.MethodGet, "pools",
+		nil, &vmPools, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmPools, nil
+}
+
+func (service *VMPoolsService) Get(ctx context.Context, name string) (*v1.VMPool, error) {
+	var vmPool v1.VMPool
+
+	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("pools/%s", url.PathEscape(name)),
+		nil, &vmPool, service.client.withNamespace(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmPool, nil
+}
+
+// Update updates the VM pool's replicas, template and metadata, the template
+// changes only affect the VMs that the pool creates afterward.
+func (service *VMPoolsService) Update(ctx context.Context, vmPool *v1.VMPool, opts ...UpdateOption) (*v1.VMPool, error) {
+	var updatedVMPool v1.VMPool
+
+	err := service.client.request(ctx, http.MethodPut, fmt.Sprintf("pools/%s", url.PathEscape(vmPool.Name)),
+		vmPool, &updatedVMPool, service.client.withNamespace(updateParams(opts)))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &updatedVMPool, nil
+}
+
+// Patch updates the VM pool using a JSON Merge Patch (RFC 7386), see VMsService.Patch() for details.
+func (service *VMPoolsService) Patch(ctx context.Context, name string, patch any, opts ...UpdateOption) (*v1.VMPool, error) {
+	var patchedVMPool v1.VMPool
+
+	err := service.client.request(ctx, http.MethodPatch, fmt.Sprintf("pools/%s", url.PathEscape(name)),
+		patch, &patchedVMPool, service.client.withNamespace(updateParams(opts)))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &patchedVMPool, nil
+}
+
+// Scale changes the number of warm VMs that the VM pool maintains.
+func (service *VMPoolsService) Scale(ctx context.Context, name string, replicas uint64) (*v1.VMPool, error) {
+	return service.Patch(ctx, name, map[string]any{
+		"replicas": replicas,
+	})
+}
+
+func (service *VMPoolsService) Delete(ctx context.Context, name string) error {
+	err := service.client.request(ctx, http.MethodDelete, fmt.Sprintf("pools/%s", url.PathEscape(name)),
+		nil, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Claim atomically takes one of the pool's ready VMs out of the pool, optionally
+// re-labeling it, and returns it. The pool replaces the claimed VM in the background.
+func (service *VMPoolsService) Claim(ctx context.Context, name string, claim *v1.VMPoolClaim) (*v1.VM, error) {
+	var vm v1.VM
+
+	err := service.client.request(ctx, http.MethodPost, fmt.Sprintf("pools/%s/claim", url.PathEscape(name)),
+		claim, &vm, service.client.withNamespace(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vm, nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"net/http"
+	"net/url"
+)
+
+type VMSetsService struct {
+	client *Client
+}
+
+func (service *VMSetsService) Create(ctx context.Context, vmSet *v1.VMSet) error {
+	err := service.client.request(ctx, http.MethodPost, "vm-sets",
+		vmSet, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *VMSetsService) List(ctx context.Context, opts ...ListOption) ([]v1.VMSet, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range service.client.listOptionsWithNamespace(opts) {
+		opt(params)
+	}
+
+	var vmSets []v1.VMSet
+
+	err := service.client.request(ctx, http.MethodGet, "vm-sets",
+		nil, &vmSets, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmSets, nil
+}
+
+func (service *VMSetsService) Get(ctx context.Context, name string) (*v1.VMSet, error) {
+	var vmSet v1.VMSet
+
+	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("vm-sets/%s", url.PathEscape(name)),
+		nil, &vmSet, service.client.withNamespace(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmSet, nil
+}
+
+// Update updates the VM set's replicas, template and metadata,
+// the template changes are gradually rolled out to the set's VMs.
+func (service *VMSetsService) Update(ctx context.Context, vmSet *v1.VMSet, opts ...UpdateOption) (*v1.VMSet, error) {
+	var updatedVMSet v1.VMSet
+
+	err := service.client.request(ctx, http.MethodPut, fmt.Sprintf("vm-sets/%s", url.PathEscape(vmSet.Name)),
+		vmSet, &updatedVMSet, service.client.withNamespace(updateParams(opts)))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &updatedVMSet, nil
+}
+
+// Patch updates the VM set using a JSON Merge Patch (RFC 7386), see VMsService.Patch() for details.
+func (service *VMSetsService) Patch(ctx context.Context, name string, patch any, opts ...UpdateOption) (*v1.VMSet, error) {
+	var patchedVMSet v1.VMSet
+
+	err := service.client.request(ctx, http.MethodPatch, fmt.Sprintf("vm-sets/%s", url.PathEscape(name)),
+		patch, &patchedVMSet, service.client.withNamespace(updateParams(opts)))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &patchedVMSet, nil
+}
+
+// Scale changes the number of VMs that the VM set maintains.
+func (service *VMSetsService) Scale(ctx context.Context, name string, replicas uint64) (*v1.VMSet, error) {
+	return service.Patch(ctx, name, map[string]any{
+		"replicas": replicas,
+	})
+}
+
+// Delete deletes the VM set along with its VMs.
+func (service *VMSetsService) Delete(ctx context.Context, name string) error {
+	err := service.client.request(ctx, http.MethodDelete, fmt.Sprintf("vm-sets/%s", url.PathEscape(name)),
+		nil, nil, service.client.withNamespace(nil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
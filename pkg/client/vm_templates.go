@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"net/http"
+	"net/url"
+)
+
+type VMTemplatesService struct {
+	client *Client
+}
+
+func (service *VMTemplatesService) Create(ctx context.Context, vmTemplate *v1.VMTemplate) error {
+	err := service.client.request(ctx, http.MethodPost, "vm-templates",
+		vmTemplate, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *VMTemplatesService) List(ctx context.Context, opts ...ListOption) ([]v1.VMTemplate, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	var vmTemplates []v1.VMTemplate
+
+	err := service.client.request(ctx, http.MethodGet, "vm-templates",
+		nil, &vmTemplates, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmTemplates, nil
+}
+
+func (service *VMTemplatesService) Get(ctx context.Context, name string) (*v1.VMTemplate, error) {
+	var vmTemplate v1.VMTemplate
+
+	err := service.client.request(ctx, http.MethodGet,
+		fmt.Sprintf("vm-templates/%s", url.PathEscape(name)),
+		nil, &vmTemplate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmTemplate, nil
+}
+
+// Update replaces the VM template's specification and metadata,
+// the VMs already created from the template are not affected.
+func (service *VMTemplatesService) Update(
+	ctx context.Context,
+	vmTemplate *v1.VMTemplate,
+	opts ...UpdateOption,
+) (*v1.VMTemplate, error) {
+	var updatedVMTemplate v1.VMTemplate
+
+	err := service.client.request(ctx, http.MethodPut,
+		fmt.Sprintf("vm-templates/%s", url.PathEscape(vmTemplate.Name)),
+		vmTemplate, &updatedVMTemplate, updateParams(opts))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &updatedVMTemplate, nil
+}
+
+func (service *VMTemplatesService) Delete(ctx context.Context, name string) error {
+	err := service.client.request(ctx, http.MethodDelete,
+		fmt.Sprintf("vm-templates/%s", url.PathEscape(name)),
+		nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"fmt"
+	"iter"
+	"maps"
 	"net"
 	"net/http"
 	"net/url"
@@ -17,6 +19,18 @@ type VMsService struct {
 	client *Client
 }
 
+// InNamespace returns a copy of the service that operates on the VMs
+// in the given namespace instead of the client's namespace, which is
+// useful when handling the VMs listed from all namespaces.
+func (service *VMsService) InNamespace(namespace string) *VMsService {
+	client := *service.client
+	client.namespace = namespace
+
+	return &VMsService{
+		client: &client,
+	}
+}
+
 type LogsOrder string
 
 const (
@@ -61,7 +75,7 @@ type ExecSessionOptions struct {
 
 func (service *VMsService) Create(ctx context.Context, vm *v1.VM) error {
 	err := service.client.request(ctx, http.MethodPost, "vms",
-		vm, nil, nil)
+		vm, nil, service.client.withNamespace(nil))
 	if err != nil {
 		return err
 	}
@@ -69,6 +83,62 @@ func (service *VMsService) Create(ctx context.Context, vm *v1.VM) error {
 	return nil
 }
 
+// CreateFromTemplate creates a VM from the VM template, with the fields specified in
+// overrides (e.g. map[string]any{"cpu": 8}) taking precedence over the template's
+// fields according to the JSON Merge Patch (RFC 7386) semantics, so a nil value
+// resets the template's field instead of overriding it.
+//
+// When dryRun is true, the VM is only validated and its placement is simulated,
+// see CreateDryRun() for details.
+func (service *VMsService) CreateFromTemplate(
+	ctx context.Context,
+	name string,
+	template string,
+	overrides map[string]any,
+	dryRun bool,
+) (*v1.VM, error) {
+	vm := maps.Clone(overrides)
+	if vm == nil {
+		vm = map[string]any{}
+	}
+	vm["name"] = name
+	vm["template"] = template
+
+	var params map[string]string
+	if dryRun {
+		params = map[string]string{
+			"dryRun": "true",
+		}
+	}
+
+	var result v1.VM
+
+	err := service.client.request(ctx, http.MethodPost, "vms",
+		vm, &result, service.client.withNamespace(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateDryRun validates the VM and simulates its placement without creating it,
+// returning the VM as it would've been created, with the worker it would've been
+// scheduled on or the status message explaining why it would've stayed pending.
+func (service *VMsService) CreateDryRun(ctx context.Context, vm *v1.VM) (*v1.VM, error) {
+	var result v1.VM
+
+	err := service.client.request(ctx, http.MethodPost, "vms",
+		vm, &result, service.client.withNamespace(map[string]string{
+			"dryRun": "true",
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (service *VMsService) FindForWorker(ctx context.Context, worker string) ([]v1.VM, error) {
 	allVms, err := service.List(ctx, WithListFilters(v1.Filter{
 		Path:  "worker",
@@ -97,7 +167,7 @@ func (service *VMsService) List(ctx context.Context, opts ...ListOption) ([]v1.V
 	params := map[string]string{}
 
 	// Apply options
-	for _, opt := range opts {
+	for _, opt := range service.client.listOptionsWithNamespace(opts) {
 		opt(params)
 	}
 
@@ -112,11 +182,41 @@ func (service *VMsService) List(ctx context.Context, opts ...ListOption) ([]v1.V
 	return vms, nil
 }
 
+// ListPage returns a single page of VMs along with the cursor for the next page,
+// which is empty when there are no more VMs left. Use WithListLimit() to set the
+// page size and WithListCursor() to retrieve the next page.
+func (service *VMsService) ListPage(ctx context.Context, opts ...ListOption) ([]v1.VM, string, error) {
+	return listPage[v1.VM](ctx, service.client, "vms", service.client.listOptionsWithNamespace(opts))
+}
+
+// All iterates over all of the VMs by retrieving them page by page,
+// which is more suitable than List() for the clusters with lots of VMs.
+//
+// When pageSize is zero, a default page size is used.
+func (service *VMsService) All(ctx context.Context, pageSize int, opts ...ListOption) iter.Seq2[v1.VM, error] {
+	return iterate[v1.VM](ctx, service.client, "vms", pageSize, service.client.listOptionsWithNamespace(opts))
+}
+
+// Watch streams the changes to all VMs that have happened after the resourceVersion.
+//
+// When resourceVersion is zero, all the existing VMs are streamed first as
+// v1.WatchMessageTypeAdded messages. To resume watching after a failure,
+// pass the Version of the last received VM as a resourceVersion.
+//
+// The watch is stopped when the ctx is canceled.
+func (service *VMsService) Watch(
+	ctx context.Context,
+	resourceVersion uint64,
+	opts ...ListOption,
+) (chan v1.WatchMessage[v1.VM], chan error, error) {
+	return watch[v1.VM](ctx, service.client, "vms", resourceVersion, service.client.listOptionsWithNamespace(opts))
+}
+
 func (service *VMsService) Get(ctx context.Context, name string) (*v1.VM, error) {
 	var vm v1.VM
 
 	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("vms/%s", url.PathEscape(name)),
-		nil, &vm, nil)
+		nil, &vm, service.client.withNamespace(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -124,21 +224,44 @@ func (service *VMsService) Get(ctx context.Context, name string) (*v1.VM, error)
 	return &vm, nil
 }
 
-func (service *VMsService) Update(ctx context.Context, vm v1.VM) (*v1.VM, error) {
+// Update updates the VM's specification and metadata. Use WithResourceVersion or
+// WithGeneration to avoid overwriting the concurrent changes to the VM.
+//
+// The VM is looked up in its own namespace, if specified.
+func (service *VMsService) Update(ctx context.Context, vm v1.VM, opts ...UpdateOption) (*v1.VM, error) {
 	var updatedVM v1.VM
 	err := service.client.request(ctx, http.MethodPut, fmt.Sprintf("vms/%s", url.PathEscape(vm.Name)),
-		vm, &updatedVM, nil)
+		vm, &updatedVM, service.clientFor(vm).withNamespace(updateParams(opts)))
 	if err != nil {
-		return &updatedVM, err
+		return &updatedVM, mapConflictErr(err)
 	}
 
 	return &updatedVM, nil
 }
 
+// Patch updates the VM's specification and metadata using a JSON Merge Patch (RFC 7386),
+// which is marshalled from the patch (e.g. map[string]any{"powerState": "stopped"}),
+// pass a json.RawMessage to send an already marshalled patch as is.
+//
+// Use WithResourceVersion or WithGeneration to only apply the
+// patch if the VM hasn't been modified concurrently.
+func (service *VMsService) Patch(ctx context.Context, name string, patch any, opts ...UpdateOption) (*v1.VM, error) {
+	var patchedVM v1.VM
+
+	err := service.client.request(ctx, http.MethodPatch, fmt.Sprintf("vms/%s", url.PathEscape(name)),
+		patch, &patchedVM, service.client.withNamespace(updateParams(opts)))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &patchedVM, nil
+}
+
+// UpdateState updates the VM's status. The VM is looked up in its own namespace, if specified.
 func (service *VMsService) UpdateState(ctx context.Context, vm v1.VM) (*v1.VM, error) {
 	var updatedVM v1.VM
 	err := service.client.request(ctx, http.MethodPut, fmt.Sprintf("vms/%s/state", url.PathEscape(vm.Name)),
-		vm, &updatedVM, nil)
+		vm, &updatedVM, service.clientFor(vm).withNamespace(nil))
 	if err != nil {
 		return &updatedVM, err
 	}
@@ -146,9 +269,19 @@ func (service *VMsService) UpdateState(ctx context.Context, vm v1.VM) (*v1.VM, e
 	return &updatedVM, nil
 }
 
+// clientFor returns the client to use for the requests addressing
+// the VM, preferring the VM's namespace over the client's namespace.
+func (service *VMsService) clientFor(vm v1.VM) *Client {
+	if vm.Namespace == "" {
+		return service.client
+	}
+
+	return service.InNamespace(vm.Namespace).client
+}
+
 func (service *VMsService) Delete(ctx context.Context, name string) error {
 	err := service.client.request(ctx, http.MethodDelete, fmt.Sprintf("vms/%s", url.PathEscape(name)),
-		nil, nil, nil)
+		nil, nil, service.client.withNamespace(nil))
 	if err != nil {
 		return err
 	}
@@ -163,10 +296,10 @@ func (service *VMsService) PortForward(
 	waitSeconds uint16,
 ) (net.Conn, error) {
 	return service.client.wsRequest(ctx, fmt.Sprintf("vms/%s/port-forward", url.PathEscape(name)),
-		map[string]string{
+		service.client.withNamespace(map[string]string{
 			"port": strconv.FormatUint(uint64(port), 10),
 			"wait": strconv.FormatUint(uint64(waitSeconds), 10),
-		})
+		}))
 }
 
 func (service *VMsService) Exec(
@@ -217,7 +350,7 @@ func (service *VMsService) ExecSession(
 	}
 
 	return service.client.wsRequestRaw(ctx, fmt.Sprintf("vms/%s/exec", url.PathEscape(name)),
-		params)
+		service.client.withNamespace(params))
 }
 
 func (service *VMsService) IP(ctx context.Context, name string, waitSeconds uint16) (string, error) {
@@ -226,9 +359,9 @@ func (service *VMsService) IP(ctx context.Context, name string, waitSeconds uint
 	}{}
 
 	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("vms/%s/ip", url.PathEscape(name)),
-		nil, &result, map[string]string{
+		nil, &result, service.client.withNamespace(map[string]string{
 			"wait": strconv.FormatUint(uint64(waitSeconds), 10),
-		})
+		}))
 	if err != nil {
 		return "", err
 	}
@@ -236,6 +369,19 @@ func (service *VMsService) IP(ctx context.Context, name string, waitSeconds uint
 	return result.IP, nil
 }
 
+// Scheduling explains why the VM is not scheduled yet.
+func (service *VMsService) Scheduling(ctx context.Context, name string) (*v1.SchedulingExplanation, error) {
+	var explanation v1.SchedulingExplanation
+
+	err := service.client.request(ctx, http.MethodGet, fmt.Sprintf("vms/%s/scheduling", url.PathEscape(name)),
+		nil, &explanation, service.client.withNamespace(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &explanation, nil
+}
+
 func (service *VMsService) StreamEvents(name string) *EventStreamer {
 	return NewEventStreamer(service.client, fmt.Sprintf("vms/%s/events", url.PathEscape(name)))
 }
@@ -257,7 +403,7 @@ func (service *VMsService) LogsWithOptions(ctx context.Context, name string, opt
 		params = nil
 	}
 	err = service.client.request(ctx, http.MethodGet, fmt.Sprintf("vms/%s/events", url.PathEscape(name)),
-		nil, &events, params)
+		nil, &events, service.client.withNamespace(params))
 	if err != nil {
 		return
 	}
@@ -289,7 +435,7 @@ func (service *VMsService) EventsPage(
 	}
 
 	headers, err := service.client.requestWithHeaders(ctx, http.MethodGet, fmt.Sprintf("vms/%s/events", url.PathEscape(name)),
-		nil, &events, params)
+		nil, &events, service.client.withNamespace(params))
 	if err != nil {
 		return nil, "", err
 	}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+)
+
+// ErrWatchExpired is returned when the changes after the requested resource
+// version are no longer available on the controller, in which case the caller
+// should re-list the resources and watch again with a zero resource version.
+var ErrWatchExpired = errors.New("watch expired")
+
+// ErrWatchClosed is sent to the error channel when the controller
+// closes the watch stream, the caller can resume watching by passing
+// the Version of the last received object as a resource version.
+var ErrWatchClosed = errors.New("watch stream was closed by the controller")
+
+func watch[T any](
+	ctx context.Context,
+	client *Client,
+	path string,
+	resourceVersion uint64,
+	opts []ListOption,
+) (chan v1.WatchMessage[T], chan error, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	params["watch"] = "true"
+
+	if resourceVersion != 0 {
+		params["resourceVersion"] = strconv.FormatUint(resourceVersion, 10)
+	}
+
+	body, err := client.requestStream(ctx, http.MethodGet, path, params)
+	if err != nil {
+		var apiError *APIError
+
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusGone {
+			return nil, nil, fmt.Errorf("%w: %v", ErrWatchExpired, err)
+		}
+
+		return nil, nil, err
+	}
+
+	watchCh := make(chan v1.WatchMessage[T], 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(watchCh)
+		defer func() {
+			_ = body.Close()
+		}()
+
+		decoder := json.NewDecoder(body)
+
+		for {
+			var watchMessage v1.WatchMessage[T]
+
+			if err := decoder.Decode(&watchMessage); err != nil {
+				// Cancellation by the caller is not an error
+				if ctx.Err() != nil {
+					return
+				}
+
+				if errors.Is(err, io.EOF) {
+					errCh <- ErrWatchClosed
+				} else {
+					errCh <- fmt.Errorf("%w to decode watch message: %v", ErrAPI, err)
+				}
+
+				return
+			}
+
+			select {
+			case watchCh <- watchMessage:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return watchCh, errCh, nil
+}
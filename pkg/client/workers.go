@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/cirruslabs/orchard/pkg/resource/v1"
+	"iter"
 	"net"
 	"net/http"
 	"net/url"
@@ -24,11 +25,18 @@ func (service *WorkersService) Create(ctx context.Context, worker v1.Worker) (*v
 	return &worker, nil
 }
 
-func (service *WorkersService) List(ctx context.Context) ([]v1.Worker, error) {
+func (service *WorkersService) List(ctx context.Context, opts ...ListOption) ([]v1.Worker, error) {
+	params := map[string]string{}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	var workers []v1.Worker
 
 	err := service.client.request(ctx, http.MethodGet, "workers",
-		nil, &workers, nil)
+		nil, &workers, params)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +44,30 @@ func (service *WorkersService) List(ctx context.Context) ([]v1.Worker, error) {
 	return workers, nil
 }
 
+// ListPage returns a single page of workers along with the cursor for the next page,
+// which is empty when there are no more workers left. Use WithListLimit() to set the
+// page size and WithListCursor() to retrieve the next page.
+func (service *WorkersService) ListPage(ctx context.Context, opts ...ListOption) ([]v1.Worker, string, error) {
+	return listPage[v1.Worker](ctx, service.client, "workers", opts)
+}
+
+// All iterates over all of the workers by retrieving them page by page,
+// which is more suitable than List() for the clusters with lots of workers.
+//
+// When pageSize is zero, a default page size is used.
+func (service *WorkersService) All(ctx context.Context, pageSize int, opts ...ListOption) iter.Seq2[v1.Worker, error] {
+	return iterate[v1.Worker](ctx, service.client, "workers", pageSize, opts)
+}
+
+// Watch streams the changes to all workers that have happened
+// after the resourceVersion, see VMsService.Watch() for details.
+func (service *WorkersService) Watch(
+	ctx context.Context,
+	resourceVersion uint64,
+) (chan v1.WatchMessage[v1.Worker], chan error, error) {
+	return watch[v1.Worker](ctx, service.client, "workers", resourceVersion, nil)
+}
+
 func (service *WorkersService) Get(ctx context.Context, name string) (*v1.Worker, error) {
 	var worker v1.Worker
 
@@ -48,16 +80,34 @@ func (service *WorkersService) Get(ctx context.Context, name string) (*v1.Worker
 	return &worker, nil
 }
 
-func (service *WorkersService) Update(ctx context.Context, worker v1.Worker) (*v1.Worker, error) {
+func (service *WorkersService) Update(ctx context.Context, worker v1.Worker, opts ...UpdateOption) (*v1.Worker, error) {
 	err := service.client.request(ctx, http.MethodPut, fmt.Sprintf("workers/%s", url.PathEscape(worker.Name)),
-		worker, &worker, nil)
+		worker, &worker, updateParams(opts))
 	if err != nil {
-		return nil, err
+		return nil, mapConflictErr(err)
 	}
 
 	return &worker, nil
 }
 
+// Patch updates the worker using a JSON Merge Patch (RFC 7386), see VMsService.Patch() for details.
+func (service *WorkersService) Patch(
+	ctx context.Context,
+	name string,
+	patch any,
+	opts ...UpdateOption,
+) (*v1.Worker, error) {
+	var patchedWorker v1.Worker
+
+	err := service.client.request(ctx, http.MethodPatch, fmt.Sprintf("workers/%s", url.PathEscape(name)),
+		patch, &patchedWorker, updateParams(opts))
+	if err != nil {
+		return nil, mapConflictErr(err)
+	}
+
+	return &patchedWorker, nil
+}
+
 func (service *WorkersService) Delete(ctx context.Context, name string) error {
 	err := service.client.request(ctx, http.MethodDelete, fmt.Sprintf("workers/%s", url.PathEscape(name)),
 		nil, nil, nil)
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidAffinity = errors.New("invalid affinity")
+
+// Affinity constrains the placement of a VM on the workers
+// in addition to the VM's Labels and Resources.
+//
+// All selectors use the Selector syntax: worker selectors are matched
+// against the workers (e.g. "labels.rack in (a,b)") and VM selectors
+// are matched against the other VMs in the same namespace
+// (e.g. "metadataLabels.shard=1").
+type Affinity struct {
+	// WorkerAffinity attracts the VM to the matching workers.
+	WorkerAffinity *WorkerAffinity `json:"workerAffinity,omitempty"`
+
+	// VMAntiAffinity keeps the VM away from the workers
+	// that already run the matching VMs.
+	VMAntiAffinity *VMAntiAffinity `json:"vmAntiAffinity,omitempty"`
+
+	// TopologySpread spreads the matching VMs evenly across
+	// the groups of workers sharing the same label value.
+	TopologySpread []TopologySpreadConstraint `json:"topologySpread,omitempty"`
+}
+
+type WorkerAffinity struct {
+	// Required is a worker selector that the worker must match for the VM to be scheduled on it.
+	Required string `json:"required,omitempty"`
+
+	// Preferred are the worker selectors that make the worker
+	// more preferable when matched, proportionally to their weights.
+	Preferred []WeightedSelector `json:"preferred,omitempty"`
+}
+
+type VMAntiAffinity struct {
+	// Required is a VM selector: the VM won't be scheduled in
+	// a topology domain that already runs any of the matching VMs.
+	Required string `json:"required,omitempty"`
+
+	// Preferred are the VM selectors that make the topology domain
+	// less preferable for each matching VM, proportionally to their weights.
+	Preferred []WeightedSelector `json:"preferred,omitempty"`
+
+	// TopologyKey is a worker label whose value defines a topology domain,
+	// by default each worker is considered to be a topology domain of its own.
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+type WeightedSelector struct {
+	Selector string `json:"selector,omitempty"`
+
+	// Weight is in the range of 1 to 100.
+	Weight int64 `json:"weight,omitempty"`
+}
+
+type TopologySpreadConstraint struct {
+	// TopologyKey is a worker label whose value defines a topology domain,
+	// the workers that don't have this label are not considered.
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxSkew is the maximum permitted difference between the number of matching
+	// VMs in a topology domain and the minimum number of matching VMs across
+	// all topology domains. Defaults to 1.
+	MaxSkew uint64 `json:"maxSkew,omitempty"`
+
+	// Selector selects the VMs that are counted in each topology domain,
+	// the VM being scheduled is expected to match it too.
+	Selector string `json:"selector,omitempty"`
+
+	WhenUnsatisfiable WhenUnsatisfiable `json:"whenUnsatisfiable,omitempty"`
+}
+
+// WhenUnsatisfiable determines what happens
+// when the topology spread constraint cannot be satisfied.
+type WhenUnsatisfiable string
+
+const (
+	// WhenUnsatisfiableDoNotSchedule keeps the VM pending until the constraint can be satisfied.
+	WhenUnsatisfiableDoNotSchedule WhenUnsatisfiable = "DoNotSchedule"
+
+	// WhenUnsatisfiableScheduleAnyway schedules the VM while preferring
+	// the workers that minimize the skew.
+	WhenUnsatisfiableScheduleAnyway WhenUnsatisfiable = "ScheduleAnyway"
+)
+
+// Validate checks that all the selectors are valid.
+func (affinity *Affinity) Validate() error {
+	if affinity == nil {
+		return nil
+	}
+
+	if workerAffinity := affinity.WorkerAffinity; workerAffinity != nil {
+		if err := validateAffinitySelector("workerAffinity.required", workerAffinity.Required); err != nil {
+			return err
+		}
+
+		if err := validateWeightedSelectors("workerAffinity.preferred", workerAffinity.Preferred); err != nil {
+			return err
+		}
+	}
+
+	if vmAntiAffinity := affinity.VMAntiAffinity; vmAntiAffinity != nil {
+		if err := validateAffinitySelector("vmAntiAffinity.required", vmAntiAffinity.Required); err != nil {
+			return err
+		}
+
+		if err := validateWeightedSelectors("vmAntiAffinity.preferred", vmAntiAffinity.Preferred); err != nil {
+			return err
+		}
+	}
+
+	for i, constraint := range affinity.TopologySpread {
+		field := fmt.Sprintf("topologySpread[%d]", i)
+
+		if constraint.TopologyKey == "" {
+			return fmt.Errorf("%w: %s.topologyKey cannot be empty", ErrInvalidAffinity, field)
+		}
+
+		if err := validateAffinitySelector(field+".selector", constraint.Selector); err != nil {
+			return err
+		}
+
+		switch constraint.WhenUnsatisfiable {
+		case "", WhenUnsatisfiableDoNotSchedule, WhenUnsatisfiableScheduleAnyway:
+		default:
+			return fmt.Errorf("%w: %s.whenUnsatisfiable should be either %q or %q, got %q",
+				ErrInvalidAffinity, field, WhenUnsatisfiableDoNotSchedule, WhenUnsatisfiableScheduleAnyway,
+				constraint.WhenUnsatisfiable)
+		}
+	}
+
+	return nil
+}
+
+func validateAffinitySelector(field string, selector string) error {
+	if _, err := ParseSelector(selector); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidAffinity, field, err)
+	}
+
+	return nil
+}
+
+func validateWeightedSelectors(field string, weightedSelectors []WeightedSelector) error {
+	for i, weightedSelector := range weightedSelectors {
+		field := fmt.Sprintf("%s[%d]", field, i)
+
+		if weightedSelector.Selector == "" {
+			return fmt.Errorf("%w: %s.selector cannot be empty", ErrInvalidAffinity, field)
+		}
+
+		if err := validateAffinitySelector(field+".selector", weightedSelector.Selector); err != nil {
+			return err
+		}
+
+		if weightedSelector.Weight < 1 || weightedSelector.Weight > 100 {
+			return fmt.Errorf("%w: %s.weight should be in the range of 1 to 100, got %d",
+				ErrInvalidAffinity, field, weightedSelector.Weight)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffinityValidate(t *testing.T) {
+	var nilAffinity *v1.Affinity
+	require.NoError(t, nilAffinity.Validate())
+
+	require.NoError(t, (&v1.Affinity{
+		WorkerAffinity: &v1.WorkerAffinity{
+			Required: "labels.rack in (a,b)",
+			Preferred: []v1.WeightedSelector{
+				{Selector: "labels.gpu", Weight: 50},
+			},
+		},
+		VMAntiAffinity: &v1.VMAntiAffinity{
+			Required: "metadataLabels.shard=1",
+		},
+		TopologySpread: []v1.TopologySpreadConstraint{
+			{TopologyKey: "rack", WhenUnsatisfiable: v1.WhenUnsatisfiableScheduleAnyway},
+		},
+	}).Validate())
+
+	require.ErrorIs(t, (&v1.Affinity{
+		WorkerAffinity: &v1.WorkerAffinity{
+			Required: "labels.rack in (a,b",
+		},
+	}).Validate(), v1.ErrInvalidAffinity)
+
+	require.ErrorIs(t, (&v1.Affinity{
+		VMAntiAffinity: &v1.VMAntiAffinity{
+			Preferred: []v1.WeightedSelector{
+				{Selector: "metadataLabels.shard=1", Weight: 101},
+			},
+		},
+	}).Validate(), v1.ErrInvalidAffinity)
+
+	require.ErrorIs(t, (&v1.Affinity{
+		TopologySpread: []v1.TopologySpreadConstraint{
+			{TopologyKey: ""},
+		},
+	}).Validate(), v1.ErrInvalidAffinity)
+
+	require.ErrorIs(t, (&v1.Affinity{
+		TopologySpread: []v1.TopologySpreadConstraint{
+			{TopologyKey: "rack", WhenUnsatisfiable: "Sometimes"},
+		},
+	}).Validate(), v1.ErrInvalidAffinity)
+}
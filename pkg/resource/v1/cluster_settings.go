@@ -12,6 +12,32 @@ const (
 type ClusterSettings struct {
 	HostDirPolicies  []HostDirPolicy  `json:"hostDirPolicies,omitempty"`
 	SchedulerProfile SchedulerProfile `json:"schedulerProfile,omitempty"`
+
+	// FairShare enables the weighted fair-share queueing of the
+	// unscheduled VMs when set, otherwise the VMs with the same
+	// priority are scheduled in the order of their creation.
+	FairShare *FairShare `json:"fairShare,omitempty"`
+
+	// SchedulerScoreWeights, when set, are used by the scheduler
+	// to rank the workers instead of the SchedulerProfile's preset.
+	SchedulerScoreWeights SchedulerScoreWeights `json:"schedulerScoreWeights,omitempty"`
+
+	// RestartBackoff configures the delay before restarting the failed VMs,
+	// the defaults are used when not set.
+	RestartBackoff *RestartBackoff `json:"restartBackoff,omitempty"`
+
+	// PriorityClasses are the named VM priorities that the
+	// VMs can refer to using their PriorityClassName.
+	PriorityClasses PriorityClasses `json:"priorityClasses,omitempty"`
+}
+
+// EffectiveSchedulerScoreWeights returns the score weights used by the scheduler.
+func (clusterSettings *ClusterSettings) EffectiveSchedulerScoreWeights() SchedulerScoreWeights {
+	if len(clusterSettings.SchedulerScoreWeights) != 0 {
+		return clusterSettings.SchedulerScoreWeights
+	}
+
+	return clusterSettings.SchedulerProfile.ScoreWeights()
 }
 
 func (clusterSettings *ClusterSettings) SetVersion(_ uint64) {}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidFairShare = errors.New("invalid fair-share settings")
+
+// FairShare configures the weighted fair-share queueing of the unscheduled VMs.
+//
+// The unscheduled VMs are grouped into queues by their owner, and instead of
+// being scheduled strictly in the order of creation, the VMs with the same
+// priority are interleaved across the queues, so that each queue gets a share
+// of the cluster proportional to its weight.
+//
+// A queue's usage is the amount of CPU, memory and Tart VMs assigned to its
+// scheduled VMs, and its share is the largest fraction of the cluster's capacity
+// of these resources that the queue uses (also known as the dominant resource
+// share), divided by the queue's weight. The VMs of the queue with the lowest
+// share are scheduled first.
+//
+// With UsageHalfLifeSeconds set, the usage is averaged over time instead, so the
+// queues that have recently used more than their share are deprioritized for a
+// while even after their VMs have finished. This average is kept in memory of the
+// Controller that runs the scheduler and starts over when the Controller restarts.
+type FairShare struct {
+	// Label is a VM metadata label whose value determines the VM's queue,
+	// when empty, the VMs are queued by the service account that created them.
+	Label string `json:"label,omitempty"`
+
+	// Weights of the queues, queues that are not listed here have a weight of 1.
+	Weights map[string]uint64 `json:"weights,omitempty"`
+
+	// UsageHalfLifeSeconds is the time after which the past usage of a queue
+	// only counts half as much as its current usage, when zero, only the
+	// resources assigned to the currently scheduled VMs are taken into account.
+	UsageHalfLifeSeconds uint64 `json:"usageHalfLifeSeconds,omitempty"`
+}
+
+func (fairShare *FairShare) Validate() error {
+	if fairShare == nil {
+		return nil
+	}
+
+	for queue, weight := range fairShare.Weights {
+		if weight == 0 {
+			return fmt.Errorf("%w: weight of the queue %q should be at least 1", ErrInvalidFairShare, queue)
+		}
+	}
+
+	return nil
+}
+
+// Queue returns the name of the VM's queue.
+func (fairShare *FairShare) Queue(vm VM) string {
+	if fairShare != nil && fairShare.Label != "" {
+		return vm.Meta.Labels[fairShare.Label]
+	}
+
+	return vm.CreatedBy
+}
+
+// UsageHalfLife returns the half-life of the queues' past usage.
+func (fairShare *FairShare) UsageHalfLife() time.Duration {
+	if fairShare == nil {
+		return 0
+	}
+
+	return time.Duration(fairShare.UsageHalfLifeSeconds) * time.Second
+}
+
+// Weight returns the weight of the queue.
+func (fairShare *FairShare) Weight(queue string) uint64 {
+	if fairShare != nil {
+		if weight, ok := fairShare.Weights[queue]; ok && weight != 0 {
+			return weight
+		}
+	}
+
+	return 1
+}
@@ -29,3 +29,14 @@ func NewFilter(s string) (Filter, error) {
 		Value: parts[1],
 	}, nil
 }
+
+// Selector returns a selector equivalent to this filter.
+func (filter Filter) Selector() Selector {
+	return Selector{
+		{
+			Key:      filter.Path,
+			Operator: SelectorOperatorEquals,
+			Values:   []string{filter.Value},
+		},
+	}
+}
@@ -0,0 +1,11 @@
+package v1
+
+// DefaultNamespace is the namespace of the namespaced resources
+// created without explicitly specifying a namespace.
+const DefaultNamespace = "default"
+
+// NamespacedName returns "<namespace>/<name>", which uniquely identifies
+// a namespaced resource among the resources of the same kind.
+func NamespacedName(namespace string, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPreemptionPolicy = errors.New("invalid preemption policy")
+
+// PreemptionPolicy controls whether a VM that cannot be scheduled due to
+// the lack of resources is allowed to evict the lower-priority VMs.
+type PreemptionPolicy string
+
+const (
+	PreemptionPolicyNever                PreemptionPolicy = "Never"
+	PreemptionPolicyPreemptLowerPriority PreemptionPolicy = "PreemptLowerPriority"
+)
+
+func NewPreemptionPolicyFromString(s string) (PreemptionPolicy, error) {
+	switch s {
+	case string(PreemptionPolicyNever):
+		return PreemptionPolicyNever, nil
+	case string(PreemptionPolicyPreemptLowerPriority):
+		return PreemptionPolicyPreemptLowerPriority, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrInvalidPreemptionPolicy, s)
+	}
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPriorityClasses = errors.New("invalid priority classes")
+
+// PriorityClass is a named VM priority defined by the cluster administrator.
+//
+// Only the administrators can assign a positive priority or the PreemptLowerPriority
+// preemption policy to the VMs directly, other service accounts need to refer to
+// a priority class that grants them.
+type PriorityClass struct {
+	Name string `json:"name"`
+
+	// Value is the priority assigned to the VMs of this class.
+	Value int64 `json:"value,omitempty"`
+
+	// PreemptionPolicy is assigned to the VMs of this class,
+	// defaults to PreemptionPolicyNever when not set.
+	PreemptionPolicy PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+}
+
+// EffectivePreemptionPolicy returns the preemption policy
+// assigned to the VMs of this class.
+func (priorityClass PriorityClass) EffectivePreemptionPolicy() PreemptionPolicy {
+	if priorityClass.PreemptionPolicy == "" {
+		return PreemptionPolicyNever
+	}
+
+	return priorityClass.PreemptionPolicy
+}
+
+type PriorityClasses []PriorityClass
+
+func (priorityClasses PriorityClasses) Validate() error {
+	names := map[string]struct{}{}
+
+	for _, priorityClass := range priorityClasses {
+		if priorityClass.Name == "" {
+			return fmt.Errorf("%w: priority class name cannot be empty", ErrInvalidPriorityClasses)
+		}
+
+		if _, ok := names[priorityClass.Name]; ok {
+			return fmt.Errorf("%w: priority class %q is defined more than once",
+				ErrInvalidPriorityClasses, priorityClass.Name)
+		}
+
+		names[priorityClass.Name] = struct{}{}
+
+		if priorityClass.PreemptionPolicy != "" {
+			if _, err := NewPreemptionPolicyFromString(string(priorityClass.PreemptionPolicy)); err != nil {
+				return fmt.Errorf("%w: priority class %q: %v", ErrInvalidPriorityClasses,
+					priorityClass.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Find returns the priority class with the given name, if any.
+func (priorityClasses PriorityClasses) Find(name string) (PriorityClass, bool) {
+	for _, priorityClass := range priorityClasses {
+		if priorityClass.Name == name {
+			return priorityClass, true
+		}
+	}
+
+	return PriorityClass{}, false
+}
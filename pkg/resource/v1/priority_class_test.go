@@ -0,0 +1,32 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityClassesValidate(t *testing.T) {
+	require.NoError(t, v1.PriorityClasses{
+		{Name: "batch", Value: -10},
+		{Name: "urgent", Value: 100, PreemptionPolicy: v1.PreemptionPolicyPreemptLowerPriority},
+	}.Validate())
+
+	require.ErrorIs(t, v1.PriorityClasses{{Value: 10}}.Validate(), v1.ErrInvalidPriorityClasses)
+	require.ErrorIs(t, v1.PriorityClasses{{Name: "a"}, {Name: "a"}}.Validate(), v1.ErrInvalidPriorityClasses)
+	require.ErrorIs(t, v1.PriorityClasses{{Name: "a", PreemptionPolicy: "Sometimes"}}.Validate(),
+		v1.ErrInvalidPriorityClasses)
+}
+
+func TestPriorityClassesFind(t *testing.T) {
+	priorityClasses := v1.PriorityClasses{{Name: "urgent", Value: 100}}
+
+	priorityClass, ok := priorityClasses.Find("urgent")
+	require.True(t, ok)
+	require.EqualValues(t, 100, priorityClass.Value)
+	require.Equal(t, v1.PreemptionPolicyNever, priorityClass.EffectivePreemptionPolicy())
+
+	_, ok = priorityClasses.Find("missing")
+	require.False(t, ok)
+}
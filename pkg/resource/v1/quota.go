@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidQuota  = errors.New("invalid quota")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+const (
+	// QuotaResourceVMs limits the number of VMs.
+	QuotaResourceVMs = "vms"
+
+	// QuotaResourceCPU limits the total number of CPUs assigned to the VMs.
+	QuotaResourceCPU = "cpu"
+
+	// QuotaResourceMemory limits the total amount of memory
+	// assigned to the VMs, in megabytes.
+	QuotaResourceMemory = "memory"
+)
+
+// Quota limits the total amount of resources used by the VMs
+// in a namespace or by the VMs created by a service account.
+//
+// Only the VMs that are not in a terminal state are accounted for.
+type Quota struct {
+	Scope QuotaScope `json:"scope"`
+
+	// Hard limits keyed by QuotaResourceVMs, QuotaResourceCPU, QuotaResourceMemory
+	// or by a custom resource name (e.g. "org.cirruslabs.tart-vms"), the resources
+	// that are not specified are not limited.
+	Hard Resources `json:"hard,omitempty"`
+
+	// Used is populated by the Controller when retrieving the quota.
+	Used Resources `json:"used,omitempty"`
+
+	Meta
+}
+
+// QuotaScope determines the VMs that the quota applies to,
+// exactly one of the fields needs to be set.
+type QuotaScope struct {
+	// Namespace limits the VMs in the namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// ServiceAccount limits the VMs created by the service account in all namespaces.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+func (scope QuotaScope) String() string {
+	if scope.ServiceAccount != "" {
+		return fmt.Sprintf("service account %s", scope.ServiceAccount)
+	}
+
+	return fmt.Sprintf("namespace %s", scope.Namespace)
+}
+
+func (quota *Quota) SetVersion(version uint64) {
+	quota.Version = version
+}
+
+func (quota *Quota) Match(filter Filter) bool {
+	return filter.Selector().Matches(quota)
+}
+
+func (quota *Quota) Validate() error {
+	if (quota.Scope.Namespace == "") == (quota.Scope.ServiceAccount == "") {
+		return fmt.Errorf("%w: exactly one of the \"scope.namespace\" and \"scope.serviceAccount\" "+
+			"fields should be set", ErrInvalidQuota)
+	}
+
+	if len(quota.Hard) == 0 {
+		return fmt.Errorf("%w: at least one hard limit should be specified", ErrInvalidQuota)
+	}
+
+	return nil
+}
+
+// AppliesTo returns true if the VM is accounted for in the quota.
+func (quota *Quota) AppliesTo(vm VM) bool {
+	if quota.Scope.ServiceAccount != "" {
+		return vm.CreatedBy == quota.Scope.ServiceAccount
+	}
+
+	return vm.Namespace == quota.Scope.Namespace
+}
+
+// Usage returns the quota-limited resources used by the VMs that the quota applies to.
+func (quota *Quota) Usage(vms []VM) Resources {
+	used := Resources{}
+
+	for key := range quota.Hard {
+		used[key] = 0
+	}
+
+	for _, vm := range vms {
+		if vm.TerminalState() || !quota.AppliesTo(vm) {
+			continue
+		}
+
+		used.Add(quota.limited(vm.QuotaUsage()))
+	}
+
+	return used
+}
+
+// Admit returns an error if adding the requested resources
+// to the used resources will exceed the hard limits.
+func (quota *Quota) Admit(used Resources, requested Resources) error {
+	var violations []string
+
+	for key, limit := range quota.Hard {
+		if requested[key] == 0 {
+			continue
+		}
+
+		if used[key]+requested[key] > limit {
+			violations = append(violations, fmt.Sprintf("%s: requested %d, used %d, limited to %d",
+				key, requested[key], used[key], limit))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	slices.Sort(violations)
+
+	return fmt.Errorf("%w: quota %s for %s: %s", ErrQuotaExceeded, quota.Name, quota.Scope,
+		strings.Join(violations, "; "))
+}
+
+func (quota *Quota) limited(resources Resources) Resources {
+	result := Resources{}
+
+	for key, value := range resources {
+		if _, ok := quota.Hard[key]; ok {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// QuotaUsage returns the resources that the VM uses from the quota's standpoint.
+//
+// The CPU and memory are accounted for using the AssignedCPU and AssignedMemory
+// once the VM is scheduled, and using the explicitly requested CPU and Memory before that.
+func (vm *VM) QuotaUsage() Resources {
+	result := vm.Resources.Copy()
+
+	result[QuotaResourceVMs] = 1
+
+	if vm.IsScheduled() {
+		result[QuotaResourceCPU] = vm.AssignedCPU
+		result[QuotaResourceMemory] = vm.AssignedMemory
+	} else {
+		result[QuotaResourceCPU] = vm.CPU
+		result[QuotaResourceMemory] = vm.Memory
+	}
+
+	return result
+}
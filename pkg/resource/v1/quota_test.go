@@ -0,0 +1,101 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaValidate(t *testing.T) {
+	require.NoError(t, (&v1.Quota{
+		Scope: v1.QuotaScope{Namespace: "team-a"},
+		Hard:  v1.Resources{v1.QuotaResourceVMs: 1},
+	}).Validate())
+
+	require.ErrorIs(t, (&v1.Quota{
+		Hard: v1.Resources{v1.QuotaResourceVMs: 1},
+	}).Validate(), v1.ErrInvalidQuota)
+
+	require.ErrorIs(t, (&v1.Quota{
+		Scope: v1.QuotaScope{Namespace: "team-a", ServiceAccount: "ci"},
+		Hard:  v1.Resources{v1.QuotaResourceVMs: 1},
+	}).Validate(), v1.ErrInvalidQuota)
+
+	require.ErrorIs(t, (&v1.Quota{
+		Scope: v1.QuotaScope{ServiceAccount: "ci"},
+	}).Validate(), v1.ErrInvalidQuota)
+}
+
+func TestQuotaUsageAndAdmit(t *testing.T) {
+	quota := v1.Quota{
+		Meta: v1.Meta{
+			Name: "team-a",
+		},
+		Scope: v1.QuotaScope{Namespace: "team-a"},
+		Hard: v1.Resources{
+			v1.QuotaResourceVMs: 3,
+			v1.QuotaResourceCPU: 8,
+			v1.ResourceTartVMs:  2,
+		},
+	}
+
+	vms := []v1.VM{
+		// Scheduled VMs use the assigned CPU
+		{
+			Meta:        v1.Meta{Namespace: "team-a"},
+			Worker:      "worker",
+			AssignedCPU: 4,
+			Resources:   v1.Resources{v1.ResourceTartVMs: 1},
+		},
+		// Pending VMs use the requested CPU
+		{
+			Meta: v1.Meta{Namespace: "team-a"},
+			VMSpec: v1.VMSpec{
+				OS: v1.OSLinux,
+			},
+			CPU: 2,
+		},
+		// VMs in a terminal state and in other namespaces are not accounted for
+		{
+			Meta:        v1.Meta{Namespace: "team-a"},
+			Worker:      "worker",
+			AssignedCPU: 4,
+			Status:      v1.VMStatusFailed,
+		},
+		{
+			Meta: v1.Meta{Namespace: "team-b"},
+			CPU:  4,
+		},
+	}
+
+	used := quota.Usage(vms)
+	require.Equal(t, v1.Resources{
+		v1.QuotaResourceVMs: 2,
+		v1.QuotaResourceCPU: 6,
+		v1.ResourceTartVMs:  1,
+	}, used)
+
+	require.NoError(t, quota.Admit(used, v1.Resources{
+		v1.QuotaResourceVMs: 1,
+		v1.QuotaResourceCPU: 2,
+		v1.ResourceTartVMs:  1,
+	}))
+
+	err := quota.Admit(used, v1.Resources{
+		v1.QuotaResourceVMs: 1,
+		v1.QuotaResourceCPU: 4,
+	})
+	require.ErrorIs(t, err, v1.ErrQuotaExceeded)
+	require.ErrorContains(t, err, "cpu: requested 4, used 6, limited to 8")
+}
+
+func TestQuotaAppliesToServiceAccount(t *testing.T) {
+	quota := v1.Quota{
+		Scope: v1.QuotaScope{ServiceAccount: "ci"},
+	}
+
+	require.True(t, quota.AppliesTo(v1.VM{CreatedBy: "ci", Meta: v1.Meta{Namespace: "team-a"}}))
+	require.True(t, quota.AppliesTo(v1.VM{CreatedBy: "ci", Meta: v1.Meta{Namespace: "team-b"}}))
+	require.False(t, quota.AppliesTo(v1.VM{CreatedBy: "admin", Meta: v1.Meta{Namespace: "team-a"}}))
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultRestartBackoffInitialDelay = 15 * time.Second
+	DefaultRestartBackoffMaxDelay     = 5 * time.Minute
+	DefaultRestartBackoffResetAfter   = 10 * time.Minute
+)
+
+var ErrInvalidRestartBackoff = errors.New("invalid restart backoff settings")
+
+// RestartBackoff configures the delay before the Controller restarts a failed VM.
+//
+// The delay starts at InitialDelaySeconds and doubles with each consecutive
+// failure of the VM, until it reaches MaxDelaySeconds. The VM's failures
+// are no longer considered consecutive once it runs for ResetAfterSeconds.
+type RestartBackoff struct {
+	// InitialDelaySeconds is the delay before the first restart,
+	// defaults to 15 seconds when zero.
+	InitialDelaySeconds uint64 `json:"initialDelaySeconds,omitempty"`
+
+	// MaxDelaySeconds is the upper bound of the delay,
+	// defaults to 5 minutes when zero.
+	MaxDelaySeconds uint64 `json:"maxDelaySeconds,omitempty"`
+
+	// ResetAfterSeconds is the time the VM needs to run for
+	// the delay to be reset, defaults to 10 minutes when zero.
+	ResetAfterSeconds uint64 `json:"resetAfterSeconds,omitempty"`
+}
+
+func (restartBackoff *RestartBackoff) Validate() error {
+	if restartBackoff.MaxDelay() < restartBackoff.InitialDelay() {
+		return fmt.Errorf("%w: maximum delay (%s) should not be less than the initial delay (%s)",
+			ErrInvalidRestartBackoff, restartBackoff.MaxDelay(), restartBackoff.InitialDelay())
+	}
+
+	return nil
+}
+
+// Delay returns the delay before restarting a VM that has already
+// been restarted consecutiveFailures times without running for
+// ResetAfterSeconds in between.
+func (restartBackoff *RestartBackoff) Delay(consecutiveFailures uint64) time.Duration {
+	delay := restartBackoff.InitialDelay()
+	maxDelay := restartBackoff.MaxDelay()
+
+	for range consecutiveFailures {
+		if delay >= maxDelay {
+			break
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+// InitialDelay returns the delay before the first restart.
+func (restartBackoff *RestartBackoff) InitialDelay() time.Duration {
+	if restartBackoff != nil && restartBackoff.InitialDelaySeconds != 0 {
+		return time.Duration(restartBackoff.InitialDelaySeconds) * time.Second
+	}
+
+	return DefaultRestartBackoffInitialDelay
+}
+
+// MaxDelay returns the upper bound of the delay.
+func (restartBackoff *RestartBackoff) MaxDelay() time.Duration {
+	if restartBackoff != nil && restartBackoff.MaxDelaySeconds != 0 {
+		return time.Duration(restartBackoff.MaxDelaySeconds) * time.Second
+	}
+
+	return DefaultRestartBackoffMaxDelay
+}
+
+// ResetAfter returns the time the VM needs to run for the delay to be reset.
+func (restartBackoff *RestartBackoff) ResetAfter() time.Duration {
+	if restartBackoff != nil && restartBackoff.ResetAfterSeconds != 0 {
+		return time.Duration(restartBackoff.ResetAfterSeconds) * time.Second
+	}
+
+	return DefaultRestartBackoffResetAfter
+}
@@ -0,0 +1,44 @@
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartBackoffDelay(t *testing.T) {
+	var defaultBackoff *v1.RestartBackoff
+	require.Equal(t, 15*time.Second, defaultBackoff.Delay(0))
+	require.Equal(t, 30*time.Second, defaultBackoff.Delay(1))
+	require.Equal(t, 4*time.Minute, defaultBackoff.Delay(4))
+	require.Equal(t, 5*time.Minute, defaultBackoff.Delay(5))
+	require.Equal(t, 5*time.Minute, defaultBackoff.Delay(1_000_000))
+
+	restartBackoff := &v1.RestartBackoff{
+		InitialDelaySeconds: 1,
+		MaxDelaySeconds:     10,
+	}
+	require.Equal(t, time.Second, restartBackoff.Delay(0))
+	require.Equal(t, 8*time.Second, restartBackoff.Delay(3))
+	require.Equal(t, 10*time.Second, restartBackoff.Delay(4))
+}
+
+func TestRestartBackoffValidate(t *testing.T) {
+	var defaultBackoff *v1.RestartBackoff
+	require.NoError(t, defaultBackoff.Validate())
+
+	require.NoError(t, (&v1.RestartBackoff{InitialDelaySeconds: 60}).Validate())
+	require.NoError(t, (&v1.RestartBackoff{InitialDelaySeconds: 60, MaxDelaySeconds: 60}).Validate())
+
+	require.ErrorIs(t, (&v1.RestartBackoff{InitialDelaySeconds: 600}).Validate(), v1.ErrInvalidRestartBackoff)
+	require.ErrorIs(t, (&v1.RestartBackoff{MaxDelaySeconds: 10}).Validate(), v1.ErrInvalidRestartBackoff)
+}
+
+func TestRestartBackoffResetAfter(t *testing.T) {
+	var defaultBackoff *v1.RestartBackoff
+	require.Equal(t, 10*time.Minute, defaultBackoff.ResetAfter())
+
+	require.Equal(t, time.Minute, (&v1.RestartBackoff{ResetAfterSeconds: 60}).ResetAfter())
+}
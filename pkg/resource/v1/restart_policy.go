@@ -10,7 +10,14 @@ var ErrInvalidRestartPolicy = errors.New("invalid restart policy")
 type RestartPolicy string
 
 const (
-	RestartPolicyNever     RestartPolicy = "Never"
+	// RestartPolicyNever never restarts the failed VM.
+	RestartPolicyNever RestartPolicy = "Never"
+
+	// RestartPolicyOnFailure restarts the failed VM until
+	// the VM's MaxRestarts limit is reached, if any.
+	//
+	// Note that a VM that exits without being asked to stop is considered
+	// failed, so there's no separate restart policy for such VMs.
 	RestartPolicyOnFailure RestartPolicy = "OnFailure"
 )
 
@@ -24,3 +31,17 @@ func NewRestartPolicyFromString(s string) (RestartPolicy, error) {
 		return "", fmt.Errorf("%w %q", ErrInvalidRestartPolicy, s)
 	}
 }
+
+// WillRestart returns true when the VM has failed and is going
+// to be restarted by the Controller according to its restart policy.
+func (vm *VM) WillRestart() bool {
+	if vm.Status != VMStatusFailed {
+		return false
+	}
+
+	if vm.RestartPolicy != RestartPolicyOnFailure {
+		return false
+	}
+
+	return vm.MaxRestarts == 0 || vm.RestartCount < vm.MaxRestarts
+}
@@ -23,4 +23,28 @@ func TestNewRestartPolicyFromString(t *testing.T) {
 	restartPolicy, err = v1.NewRestartPolicyFromString("OnFailure")
 	assert.NoError(t, err, "OnFailure policy should be parsed correctly")
 	assert.Equal(t, v1.RestartPolicyOnFailure, restartPolicy)
+
+	_, err = v1.NewRestartPolicyFromString("Always")
+	assert.Error(t, err, "Always policy is not supported, OnFailure without a limit should be used instead")
+}
+
+func TestVMWillRestart(t *testing.T) {
+	vm := v1.VM{Status: v1.VMStatusRunning, RestartPolicy: v1.RestartPolicyOnFailure}
+	assert.False(t, vm.WillRestart(), "VMs that haven't failed should not be restarted")
+
+	vm.Status = v1.VMStatusFailed
+	assert.True(t, vm.WillRestart())
+
+	vm.RestartPolicy = v1.RestartPolicyNever
+	assert.False(t, vm.WillRestart())
+
+	vm.RestartPolicy = v1.RestartPolicyOnFailure
+	vm.RestartCount = 10
+	assert.True(t, vm.WillRestart(), "OnFailure VMs without a limit should always be restarted")
+
+	vm.MaxRestarts = 11
+	assert.True(t, vm.WillRestart())
+
+	vm.RestartCount = 11
+	assert.False(t, vm.WillRestart(), "OnFailure VMs should not be restarted past their limit")
 }
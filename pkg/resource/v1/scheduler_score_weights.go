@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var ErrInvalidSchedulerScoreWeights = errors.New("invalid scheduler score weights")
+
+// SchedulerScorePlugin is a name of the scheduler's score plugin,
+// which expresses a preference of placing a VM on some workers
+// over the others.
+type SchedulerScorePlugin string
+
+const (
+	// SchedulerScorePluginBinPacking prefers the busiest workers.
+	SchedulerScorePluginBinPacking SchedulerScorePlugin = "bin-packing"
+
+	// SchedulerScorePluginSpread prefers the least occupied workers.
+	SchedulerScorePluginSpread SchedulerScorePlugin = "spread"
+
+	// SchedulerScorePluginAffinity prefers the workers that satisfy the VM's
+	// preferred affinity, anti-affinity and topology spread constraints, and
+	// avoids the workers with untolerated PreferNoSchedule taints.
+	SchedulerScorePluginAffinity SchedulerScorePlugin = "affinity"
+
+	// SchedulerScorePluginImageLocality prefers the workers
+	// that already have the VM's image cached locally.
+	SchedulerScorePluginImageLocality SchedulerScorePlugin = "image-locality"
+)
+
+var schedulerScorePlugins = []SchedulerScorePlugin{
+	SchedulerScorePluginBinPacking,
+	SchedulerScorePluginSpread,
+	SchedulerScorePluginAffinity,
+	SchedulerScorePluginImageLocality,
+}
+
+// SchedulerScoreWeights are the weights of the scheduler's score plugins.
+//
+// Each plugin's scores are normalized to the range from 0 to 100 across the
+// workers and the workers with the highest weighted sum of the scores are
+// preferred, the plugins that are not listed are not taken into account.
+type SchedulerScoreWeights map[SchedulerScorePlugin]uint64
+
+func (weights SchedulerScoreWeights) Validate() error {
+	for plugin := range weights {
+		if !slices.Contains(schedulerScorePlugins, plugin) {
+			return fmt.Errorf("%w: unsupported score plugin %q, supported plugins are: %s",
+				ErrInvalidSchedulerScoreWeights, plugin, strings.Join(lo.Map(schedulerScorePlugins,
+					func(plugin SchedulerScorePlugin, _ int) string {
+						return string(plugin)
+					}), ", "))
+		}
+	}
+
+	return nil
+}
+
+func (weights SchedulerScoreWeights) String() string {
+	plugins := lo.Keys(weights)
+	slices.Sort(plugins)
+
+	return strings.Join(lo.Map(plugins, func(plugin SchedulerScorePlugin, _ int) string {
+		return fmt.Sprintf("%s=%d", plugin, weights[plugin])
+	}), ",")
+}
+
+// ScoreWeights returns the score weights that the scheduler profile is a preset for.
+//
+// The affinity is weighted heavily, so that the VM's preferences take
+// precedence over the image locality, which in turn takes precedence
+// over the scheduler profile, since pulling an image takes a while.
+func (schedulerProfile SchedulerProfile) ScoreWeights() SchedulerScoreWeights {
+	switch schedulerProfile {
+	case SchedulerProfileDistributeLoad:
+		return SchedulerScoreWeights{
+			SchedulerScorePluginAffinity:      100,
+			SchedulerScorePluginImageLocality: 10,
+			SchedulerScorePluginSpread:        1,
+		}
+	case SchedulerProfileOptimizeUtilization:
+		fallthrough
+	default:
+		return SchedulerScoreWeights{
+			SchedulerScorePluginAffinity:      100,
+			SchedulerScorePluginImageLocality: 10,
+			SchedulerScorePluginBinPacking:    1,
+		}
+	}
+}
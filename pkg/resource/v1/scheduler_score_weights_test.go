@@ -0,0 +1,23 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerScoreWeights(t *testing.T) {
+	require.NoError(t, v1.SchedulerScoreWeights{
+		v1.SchedulerScorePluginAffinity: 100,
+		v1.SchedulerScorePluginSpread:   1,
+	}.Validate())
+	require.ErrorIs(t, v1.SchedulerScoreWeights{"unknown": 1}.Validate(), v1.ErrInvalidSchedulerScoreWeights)
+
+	// The explicit weights take precedence over the scheduler profile
+	clusterSettings := v1.ClusterSettings{SchedulerProfile: v1.SchedulerProfileDistributeLoad}
+	require.Equal(t, v1.SchedulerProfileDistributeLoad.ScoreWeights(), clusterSettings.EffectiveSchedulerScoreWeights())
+
+	clusterSettings.SchedulerScoreWeights = v1.SchedulerScoreWeights{v1.SchedulerScorePluginBinPacking: 1}
+	require.Equal(t, "bin-packing=1", clusterSettings.EffectiveSchedulerScoreWeights().String())
+}
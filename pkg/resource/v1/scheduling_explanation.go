@@ -0,0 +1,22 @@
+package v1
+
+import "time"
+
+// SchedulingExplanation explains why a VM is not scheduled yet,
+// as observed by the scheduler during its last scheduling loop iteration.
+type SchedulingExplanation struct {
+	// Message summarizes the explanation, the pending VM's StatusMessage
+	// only carries the most common reason why the workers were ruled out.
+	Message string `json:"message,omitempty"`
+
+	// Workers lists the reasons why each of the workers was ruled out.
+	Workers []WorkerRejection `json:"workers,omitempty"`
+
+	// EvaluatedAt is the time when the scheduler has last evaluated the VM.
+	EvaluatedAt time.Time `json:"evaluatedAt,omitempty"`
+}
+
+type WorkerRejection struct {
+	Worker string `json:"worker,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,330 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSelector = errors.New("invalid selector")
+
+type SelectorOperator string
+
+const (
+	SelectorOperatorEquals       SelectorOperator = "="
+	SelectorOperatorNotEquals    SelectorOperator = "!="
+	SelectorOperatorIn           SelectorOperator = "in"
+	SelectorOperatorNotIn        SelectorOperator = "notin"
+	SelectorOperatorExists       SelectorOperator = "exists"
+	SelectorOperatorDoesNotExist SelectorOperator = "!exists"
+)
+
+// Requirement is a single condition of a Selector.
+//
+// Key is a dot-separated path to the resource's field, using the field
+// names from the resource's JSON representation (e.g. "status", "powerState"
+// or "labels.<key>"). Keys of the map fields (such as labels) can contain
+// dots too, so "labels.example.com/role" refers to the "example.com/role" label.
+type Requirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// Selector is a set of requirements that all need to be satisfied
+// for a resource to match, similarly to Kubernetes label selectors:
+//
+//   - "key=value" or "key==value" — the field exists and is equal to the value
+//   - "key!=value" — the field doesn't exist or is not equal to the value
+//   - "key in (a,b)" — the field exists and is equal to one of the values
+//   - "key notin (a,b)" — the field doesn't exist or is not equal to any of the values
+//   - "key" — the field exists
+//   - "!key" — the field doesn't exist
+//
+// Multiple requirements are separated by commas.
+type Selector []Requirement
+
+var setRequirementRegex = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+func ParseSelector(s string) (Selector, error) {
+	var selector Selector
+
+	rawRequirements, err := splitRequirements(s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rawRequirement := range rawRequirements {
+		requirement, err := parseRequirement(rawRequirement)
+		if err != nil {
+			return nil, err
+		}
+
+		selector = append(selector, requirement)
+	}
+
+	return selector, nil
+}
+
+// splitRequirements splits the selector by commas,
+// except for the commas inside of the value sets.
+func splitRequirements(s string) ([]string, error) {
+	var result []string
+	var current strings.Builder
+	var inSet bool
+
+	for _, r := range s {
+		switch {
+		case r == '(' && !inSet:
+			inSet = true
+		case r == ')' && inSet:
+			inSet = false
+		case r == ',' && !inSet:
+			result = append(result, current.String())
+			current.Reset()
+
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if inSet {
+		return nil, fmt.Errorf("%w: unterminated value set in %q", ErrInvalidSelector, s)
+	}
+
+	result = append(result, current.String())
+
+	// Empty selector matches everything
+	if len(result) == 1 && strings.TrimSpace(result[0]) == "" {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+func parseRequirement(s string) (Requirement, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return Requirement{}, fmt.Errorf("%w: empty requirement", ErrInvalidSelector)
+	}
+
+	var requirement Requirement
+
+	if matches := setRequirementRegex.FindStringSubmatch(s); matches != nil {
+		requirement.Key = matches[1]
+		requirement.Operator = SelectorOperator(matches[2])
+
+		for _, value := range strings.Split(matches[3], ",") {
+			value = strings.TrimSpace(value)
+
+			if value == "" {
+				return Requirement{}, fmt.Errorf("%w: empty value in the value set of %q",
+					ErrInvalidSelector, s)
+			}
+
+			requirement.Values = append(requirement.Values, value)
+		}
+	} else if key, value, ok := strings.Cut(s, "!="); ok {
+		requirement.Key = strings.TrimSpace(key)
+		requirement.Operator = SelectorOperatorNotEquals
+		requirement.Values = []string{strings.TrimSpace(value)}
+	} else if key, value, ok := strings.Cut(s, "=="); ok {
+		requirement.Key = strings.TrimSpace(key)
+		requirement.Operator = SelectorOperatorEquals
+		requirement.Values = []string{strings.TrimSpace(value)}
+	} else if key, value, ok := strings.Cut(s, "="); ok {
+		requirement.Key = strings.TrimSpace(key)
+		requirement.Operator = SelectorOperatorEquals
+		requirement.Values = []string{strings.TrimSpace(value)}
+	} else if key, ok := strings.CutPrefix(s, "!"); ok {
+		requirement.Key = strings.TrimSpace(key)
+		requirement.Operator = SelectorOperatorDoesNotExist
+	} else {
+		requirement.Key = s
+		requirement.Operator = SelectorOperatorExists
+	}
+
+	if requirement.Key == "" {
+		return Requirement{}, fmt.Errorf("%w: key cannot be empty in %q", ErrInvalidSelector, s)
+	}
+	if strings.ContainsAny(requirement.Key, " \t()!=") {
+		return Requirement{}, fmt.Errorf("%w: invalid key %q", ErrInvalidSelector, requirement.Key)
+	}
+
+	return requirement, nil
+}
+
+func (selector Selector) String() string {
+	var requirements []string
+
+	for _, requirement := range selector {
+		requirements = append(requirements, requirement.String())
+	}
+
+	return strings.Join(requirements, ",")
+}
+
+// Matches returns true if the obj (a resource, such as VM)
+// satisfies all the selector's requirements.
+func (selector Selector) Matches(obj any) bool {
+	for _, requirement := range selector {
+		if !requirement.Matches(obj) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (requirement Requirement) String() string {
+	switch requirement.Operator {
+	case SelectorOperatorIn, SelectorOperatorNotIn:
+		return fmt.Sprintf("%s %s (%s)", requirement.Key, requirement.Operator,
+			strings.Join(requirement.Values, ","))
+	case SelectorOperatorExists:
+		return requirement.Key
+	case SelectorOperatorDoesNotExist:
+		return "!" + requirement.Key
+	default:
+		return fmt.Sprintf("%s%s%s", requirement.Key, requirement.Operator,
+			strings.Join(requirement.Values, ","))
+	}
+}
+
+func (requirement Requirement) Matches(obj any) bool {
+	value, exists := lookupField(reflect.ValueOf(obj), requirement.Key)
+
+	switch requirement.Operator {
+	case SelectorOperatorEquals, SelectorOperatorIn:
+		return exists && slices.Contains(requirement.Values, value)
+	case SelectorOperatorNotEquals, SelectorOperatorNotIn:
+		return !exists || !slices.Contains(requirement.Values, value)
+	case SelectorOperatorExists:
+		return exists
+	case SelectorOperatorDoesNotExist:
+		return !exists
+	default:
+		return false
+	}
+}
+
+// lookupField resolves a dot-separated path in the value using the JSON field names
+// and returns its string representation, along with whether the field exists.
+func lookupField(value reflect.Value, path string) (string, bool) {
+	for {
+		// Dereference pointers and interfaces
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return "", false
+			}
+
+			value = value.Elem()
+		}
+
+		if path == "" {
+			return formatField(value)
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			segment, rest, _ := strings.Cut(path, ".")
+
+			field, ok := lookupStructField(value, segment)
+			if !ok {
+				return "", false
+			}
+
+			value, path = field, rest
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return "", false
+			}
+
+			// Prefer the longest key, since map keys can contain dots
+			mapValue := value.MapIndex(reflect.ValueOf(path).Convert(value.Type().Key()))
+			if mapValue.IsValid() {
+				value, path = mapValue, ""
+
+				continue
+			}
+
+			segment, rest, _ := strings.Cut(path, ".")
+
+			mapValue = value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+			if !mapValue.IsValid() {
+				return "", false
+			}
+
+			value, path = mapValue, rest
+		default:
+			return "", false
+		}
+	}
+}
+
+func lookupStructField(value reflect.Value, name string) (reflect.Value, bool) {
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		structField := valueType.Field(i)
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		// Look into the embedded structures, such as Meta
+		if structField.Anonymous && jsonName == "" && structField.Type.Kind() == reflect.Struct {
+			if field, ok := lookupStructField(value.Field(i), name); ok {
+				return field, true
+			}
+
+			continue
+		}
+
+		if jsonName == name || (jsonName == "" && strings.EqualFold(structField.Name, name)) {
+			return value.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func formatField(value reflect.Value) (string, bool) {
+	if timeValue, ok := value.Interface().(time.Time); ok {
+		if timeValue.IsZero() {
+			return "", false
+		}
+
+		return timeValue.Format(time.RFC3339), true
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), true
+	case reflect.Slice, reflect.Map:
+		// Composite fields can only be checked for existence
+		return "", value.Len() != 0
+	default:
+		return "", true
+	}
+}
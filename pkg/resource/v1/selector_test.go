@@ -0,0 +1,165 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    string
+		Err      error
+		Expected v1.Selector
+	}{
+		{
+			Name:  "empty",
+			Input: "",
+		},
+		{
+			Name:  "equality and inequality",
+			Input: "status=running, image==ghcr.io/cirruslabs/macos:latest,runtime!=vetu",
+			Expected: v1.Selector{
+				{Key: "status", Operator: v1.SelectorOperatorEquals, Values: []string{"running"}},
+				{Key: "image", Operator: v1.SelectorOperatorEquals, Values: []string{"ghcr.io/cirruslabs/macos:latest"}},
+				{Key: "runtime", Operator: v1.SelectorOperatorNotEquals, Values: []string{"vetu"}},
+			},
+		},
+		{
+			Name:  "set membership",
+			Input: "labels.team in (ci, qa),powerState notin (stopped)",
+			Expected: v1.Selector{
+				{Key: "labels.team", Operator: v1.SelectorOperatorIn, Values: []string{"ci", "qa"}},
+				{Key: "powerState", Operator: v1.SelectorOperatorNotIn, Values: []string{"stopped"}},
+			},
+		},
+		{
+			Name:  "existence",
+			Input: "worker,!labels.team",
+			Expected: v1.Selector{
+				{Key: "worker", Operator: v1.SelectorOperatorExists},
+				{Key: "labels.team", Operator: v1.SelectorOperatorDoesNotExist},
+			},
+		},
+		{
+			Name:  "unterminated value set",
+			Input: "status in (running",
+			Err:   v1.ErrInvalidSelector,
+		},
+		{
+			Name:  "empty requirement",
+			Input: "status=running,",
+			Err:   v1.ErrInvalidSelector,
+		},
+		{
+			Name:  "empty key",
+			Input: "=running",
+			Err:   v1.ErrInvalidSelector,
+		},
+		{
+			Name:  "invalid key",
+			Input: "status in running",
+			Err:   v1.ErrInvalidSelector,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			selector, err := v1.ParseSelector(testCase.Input)
+			require.ErrorIs(t, err, testCase.Err)
+			require.Equal(t, testCase.Expected, selector)
+
+			if err == nil {
+				// Ensure that the selector survives the round-trip
+				roundTripSelector, err := v1.ParseSelector(selector.String())
+				require.NoError(t, err)
+				require.Equal(t, selector, roundTripSelector)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	vm := &v1.VM{
+		Meta: v1.Meta{
+			Name: "test",
+		},
+		Image:    "ghcr.io/cirruslabs/macos:latest",
+		CPU:      4,
+		Headless: true,
+		Status:   v1.VMStatusRunning,
+		Labels: v1.Labels{
+			"team":             "ci",
+			"example.com/role": "builder",
+		},
+		VMSpec: v1.VMSpec{
+			Runtime:    v1.RuntimeTart,
+			PowerState: v1.PowerStateRunning,
+		},
+	}
+
+	testCases := []struct {
+		Selector string
+		Matches  bool
+	}{
+		{"", true},
+		{"name=test", true},
+		{"status=running", true},
+		{"status!=running", false},
+		{"image=ghcr.io/cirruslabs/macos:latest", true},
+		{"cpu=4", true},
+		{"headless=true", true},
+		{"nested=false", true},
+		{"runtime=tart,powerState=running", true},
+		{"runtime=tart,powerState=stopped", false},
+		{"labels.team=ci", true},
+		{"labels.team in (qa,ci)", true},
+		{"labels.team notin (qa,ci)", false},
+		{"labels.example.com/role=builder", true},
+		{"labels.team", true},
+		{"!labels.team", false},
+		{"labels.owner", false},
+		{"!labels.owner", true},
+		{"labels.owner!=somebody", true},
+		{"labels.owner notin (somebody)", true},
+		{"startup_script", false},
+		{"non-existent=value", false},
+		{"hostDirs", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Selector, func(t *testing.T) {
+			selector, err := v1.ParseSelector(testCase.Selector)
+			require.NoError(t, err)
+			require.Equal(t, testCase.Matches, selector.Matches(vm))
+		})
+	}
+}
+
+func TestWorkerAndServiceAccountMatch(t *testing.T) {
+	worker := &v1.Worker{
+		Meta: v1.Meta{
+			Name: "worker-1",
+		},
+		Labels: v1.Labels{
+			"location": "rack-1",
+		},
+		SchedulingPaused: true,
+	}
+
+	require.True(t, worker.Match(v1.Filter{Path: "labels.location", Value: "rack-1"}))
+	require.True(t, worker.Match(v1.Filter{Path: "scheduling_paused", Value: "true"}))
+	require.False(t, worker.Match(v1.Filter{Path: "name", Value: "worker-2"}))
+
+	serviceAccount := &v1.ServiceAccount{
+		Meta: v1.Meta{
+			Name: "ci",
+		},
+		Roles: []v1.ServiceAccountRole{v1.ServiceAccountRoleComputeRead},
+	}
+
+	require.True(t, serviceAccount.Match(v1.Filter{Path: "name", Value: "ci"}))
+	require.False(t, serviceAccount.Match(v1.Filter{Path: "name", Value: "admin"}))
+}
@@ -1,14 +1,65 @@
 package v1
 
+import (
+	"fmt"
+	"strings"
+)
+
 type ServiceAccount struct {
-	Token string               `json:"token,omitempty"`
+	Token string `json:"token,omitempty"`
+
+	// Roles are granted to the service account cluster-wide,
+	// that is, for all namespaces and cluster-scoped resources.
 	Roles []ServiceAccountRole `json:"roles,omitempty"`
 
+	// RoleBindings grant additional roles that are only
+	// effective for the resources in a specific namespace.
+	RoleBindings []RoleBinding `json:"roleBindings,omitempty"`
+
 	Meta
 }
 
-func (serviceAccount *ServiceAccount) SetVersion(_ uint64) {}
+// RoleBinding grants the Roles to a service account in the Namespace.
+type RoleBinding struct {
+	Namespace string               `json:"namespace,omitempty"`
+	Roles     []ServiceAccountRole `json:"roles,omitempty"`
+}
+
+func (roleBinding RoleBinding) String() string {
+	roles := make([]string, 0, len(roleBinding.Roles))
+
+	for _, role := range roleBinding.Roles {
+		roles = append(roles, string(role))
+	}
+
+	return fmt.Sprintf("%s: %s", roleBinding.Namespace, strings.Join(roles, ", "))
+}
+
+func (serviceAccount *ServiceAccount) SetVersion(version uint64) {
+	serviceAccount.Version = version
+}
 
 func (serviceAccount *ServiceAccount) Match(filter Filter) bool {
-	return false
+	return filter.Selector().Matches(serviceAccount)
+}
+
+// RolesIn returns the roles that the service account has in the namespace,
+// which is a combination of the cluster-wide roles and the roles granted
+// by the namespace's role bindings.
+//
+// An empty namespace only returns the cluster-wide roles.
+func (serviceAccount *ServiceAccount) RolesIn(namespace string) []ServiceAccountRole {
+	roles := serviceAccount.Roles
+
+	if namespace == "" {
+		return roles
+	}
+
+	for _, roleBinding := range serviceAccount.RoleBindings {
+		if roleBinding.Namespace == namespace {
+			roles = append(roles[:len(roles):len(roles)], roleBinding.Roles...)
+		}
+	}
+
+	return roles
 }
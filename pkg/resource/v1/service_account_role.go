@@ -43,3 +43,16 @@ func AllServiceAccountRoles() []ServiceAccountRole {
 		ServiceAccountRoleAdminWrite,
 	}
 }
+
+// Namespaced returns true for the roles that can be granted
+// in a specific namespace using the service account's role bindings.
+//
+// Administrative roles only make sense cluster-wide.
+func (role ServiceAccountRole) Namespaced() bool {
+	switch role {
+	case ServiceAccountRoleComputeRead, ServiceAccountRoleComputeWrite, ServiceAccountRoleComputeConnect:
+		return true
+	default:
+		return false
+	}
+}
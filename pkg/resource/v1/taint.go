@@ -0,0 +1,230 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidTaint      = errors.New("invalid taint")
+	ErrInvalidToleration = errors.New("invalid toleration")
+)
+
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule prevents the VMs that don't tolerate
+	// the taint from being scheduled on the worker.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+
+	// TaintEffectPreferNoSchedule makes the scheduler avoid the worker for
+	// the VMs that don't tolerate the taint, unless there are no other options.
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+
+	// TaintEffectNoExecute is similar to TaintEffectNoSchedule, but also
+	// evicts the VMs that are already running on the worker and that don't
+	// tolerate the taint.
+	TaintEffectNoExecute TaintEffect = "NoExecute"
+)
+
+func NewTaintEffectFromString(s string) (TaintEffect, error) {
+	switch s {
+	case string(TaintEffectNoSchedule):
+		return TaintEffectNoSchedule, nil
+	case string(TaintEffectPreferNoSchedule):
+		return TaintEffectPreferNoSchedule, nil
+	case string(TaintEffectNoExecute):
+		return TaintEffectNoExecute, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported effect %q, expected %q, %q or %q", ErrInvalidTaint, s,
+			TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute)
+	}
+}
+
+// Taint repels the VMs that don't tolerate it from the worker.
+type Taint struct {
+	Key    string      `json:"key,omitempty"`
+	Value  string      `json:"value,omitempty"`
+	Effect TaintEffect `json:"effect,omitempty"`
+}
+
+// NewTaintFromString parses a taint in the "key=value:Effect" or "key:Effect" format.
+func NewTaintFromString(s string) (Taint, error) {
+	keyValue, effect, ok := strings.Cut(s, ":")
+	if !ok {
+		return Taint{}, fmt.Errorf("%w: %q should be in the \"key=value:Effect\" or \"key:Effect\" format",
+			ErrInvalidTaint, s)
+	}
+
+	key, value, _ := strings.Cut(keyValue, "=")
+
+	taint := Taint{
+		Key:    key,
+		Value:  value,
+		Effect: TaintEffect(effect),
+	}
+
+	if err := taint.Validate(); err != nil {
+		return Taint{}, err
+	}
+
+	return taint, nil
+}
+
+func (taint Taint) Validate() error {
+	if taint.Key == "" {
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidTaint)
+	}
+
+	if _, err := NewTaintEffectFromString(string(taint.Effect)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (taint Taint) String() string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+type TolerationOperator string
+
+const (
+	// TolerationOperatorEqual tolerates the taints with the same key and value.
+	TolerationOperatorEqual TolerationOperator = "Equal"
+
+	// TolerationOperatorExists tolerates the taints with the same key
+	// regardless of their value, or all taints when the key is empty.
+	TolerationOperatorExists TolerationOperator = "Exists"
+)
+
+// Toleration allows the VM to be scheduled on and to keep
+// running on the workers with the matching taints.
+type Toleration struct {
+	Key string `json:"key,omitempty"`
+
+	// Operator defaults to TolerationOperatorEqual.
+	Operator TolerationOperator `json:"operator,omitempty"`
+
+	Value string `json:"value,omitempty"`
+
+	// Effect to tolerate, an empty effect tolerates all effects.
+	Effect TaintEffect `json:"effect,omitempty"`
+}
+
+// NewTolerationFromString parses a toleration in the "key=value[:Effect]" format
+// for the TolerationOperatorEqual operator, or in the "key[:Effect]" format
+// for the TolerationOperatorExists operator.
+func NewTolerationFromString(s string) (Toleration, error) {
+	keyValue, effect, _ := strings.Cut(s, ":")
+
+	var toleration Toleration
+
+	if key, value, ok := strings.Cut(keyValue, "="); ok {
+		toleration = Toleration{
+			Key:      key,
+			Operator: TolerationOperatorEqual,
+			Value:    value,
+			Effect:   TaintEffect(effect),
+		}
+	} else {
+		toleration = Toleration{
+			Key:      keyValue,
+			Operator: TolerationOperatorExists,
+			Effect:   TaintEffect(effect),
+		}
+	}
+
+	if err := toleration.Validate(); err != nil {
+		return Toleration{}, err
+	}
+
+	return toleration, nil
+}
+
+func (toleration Toleration) Validate() error {
+	switch toleration.Operator {
+	case "", TolerationOperatorEqual:
+		if toleration.Key == "" {
+			return fmt.Errorf("%w: key can only be empty when using the %q operator",
+				ErrInvalidToleration, TolerationOperatorExists)
+		}
+	case TolerationOperatorExists:
+		if toleration.Value != "" {
+			return fmt.Errorf("%w: value should be empty when using the %q operator",
+				ErrInvalidToleration, TolerationOperatorExists)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported operator %q, expected %q or %q", ErrInvalidToleration,
+			toleration.Operator, TolerationOperatorEqual, TolerationOperatorExists)
+	}
+
+	if toleration.Effect != "" {
+		if _, err := NewTaintEffectFromString(string(toleration.Effect)); err != nil {
+			return fmt.Errorf("%w: unsupported effect %q", ErrInvalidToleration, toleration.Effect)
+		}
+	}
+
+	return nil
+}
+
+func (toleration Toleration) String() string {
+	var result string
+
+	if toleration.Operator == TolerationOperatorExists {
+		result = toleration.Key
+	} else {
+		result = fmt.Sprintf("%s=%s", toleration.Key, toleration.Value)
+	}
+
+	if toleration.Effect != "" {
+		result += ":" + string(toleration.Effect)
+	}
+
+	return result
+}
+
+func (toleration Toleration) Tolerates(taint Taint) bool {
+	if toleration.Effect != "" && toleration.Effect != taint.Effect {
+		return false
+	}
+
+	if toleration.Operator == TolerationOperatorExists {
+		return toleration.Key == "" || toleration.Key == taint.Key
+	}
+
+	return toleration.Key == taint.Key && toleration.Value == taint.Value
+}
+
+// UntoleratedTaints returns the taints with the specified
+// effect that aren't tolerated by any of the tolerations.
+func UntoleratedTaints(taints []Taint, tolerations []Toleration, effect TaintEffect) []Taint {
+	var result []Taint
+
+	for _, taint := range taints {
+		if taint.Effect != effect {
+			continue
+		}
+
+		tolerated := false
+
+		for _, toleration := range tolerations {
+			if toleration.Tolerates(taint) {
+				tolerated = true
+
+				break
+			}
+		}
+
+		if !tolerated {
+			result = append(result, taint)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,77 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaintFromString(t *testing.T) {
+	taint, err := v1.NewTaintFromString("dedicated=signing:NoSchedule")
+	require.NoError(t, err)
+	require.Equal(t, v1.Taint{Key: "dedicated", Value: "signing", Effect: v1.TaintEffectNoSchedule}, taint)
+	require.Equal(t, "dedicated=signing:NoSchedule", taint.String())
+
+	taint, err = v1.NewTaintFromString("flaky:PreferNoSchedule")
+	require.NoError(t, err)
+	require.Equal(t, v1.Taint{Key: "flaky", Effect: v1.TaintEffectPreferNoSchedule}, taint)
+	require.Equal(t, "flaky:PreferNoSchedule", taint.String())
+
+	_, err = v1.NewTaintFromString("flaky")
+	require.ErrorIs(t, err, v1.ErrInvalidTaint)
+
+	_, err = v1.NewTaintFromString("flaky:Sometimes")
+	require.ErrorIs(t, err, v1.ErrInvalidTaint)
+
+	_, err = v1.NewTaintFromString("=value:NoExecute")
+	require.ErrorIs(t, err, v1.ErrInvalidTaint)
+}
+
+func TestNewTolerationFromString(t *testing.T) {
+	toleration, err := v1.NewTolerationFromString("dedicated=signing:NoSchedule")
+	require.NoError(t, err)
+	require.Equal(t, v1.Toleration{
+		Key:      "dedicated",
+		Operator: v1.TolerationOperatorEqual,
+		Value:    "signing",
+		Effect:   v1.TaintEffectNoSchedule,
+	}, toleration)
+	require.Equal(t, "dedicated=signing:NoSchedule", toleration.String())
+
+	toleration, err = v1.NewTolerationFromString("flaky")
+	require.NoError(t, err)
+	require.Equal(t, v1.Toleration{Key: "flaky", Operator: v1.TolerationOperatorExists}, toleration)
+	require.Equal(t, "flaky", toleration.String())
+
+	_, err = v1.NewTolerationFromString("flaky:Sometimes")
+	require.ErrorIs(t, err, v1.ErrInvalidToleration)
+}
+
+func TestUntoleratedTaints(t *testing.T) {
+	signing := v1.Taint{Key: "dedicated", Value: "signing", Effect: v1.TaintEffectNoSchedule}
+	flaky := v1.Taint{Key: "flaky", Effect: v1.TaintEffectNoExecute}
+	taints := []v1.Taint{signing, flaky}
+
+	require.Equal(t, []v1.Taint{signing}, v1.UntoleratedTaints(taints, nil, v1.TaintEffectNoSchedule))
+	require.Equal(t, []v1.Taint{flaky}, v1.UntoleratedTaints(taints, nil, v1.TaintEffectNoExecute))
+	require.Empty(t, v1.UntoleratedTaints(taints, nil, v1.TaintEffectPreferNoSchedule))
+
+	// Equal operator requires the value to match
+	require.Empty(t, v1.UntoleratedTaints(taints, []v1.Toleration{
+		{Key: "dedicated", Value: "signing"},
+	}, v1.TaintEffectNoSchedule))
+	require.Equal(t, []v1.Taint{signing}, v1.UntoleratedTaints(taints, []v1.Toleration{
+		{Key: "dedicated", Value: "release"},
+	}, v1.TaintEffectNoSchedule))
+
+	// Effect should match, unless it's empty
+	require.Equal(t, []v1.Taint{flaky}, v1.UntoleratedTaints(taints, []v1.Toleration{
+		{Key: "flaky", Operator: v1.TolerationOperatorExists, Effect: v1.TaintEffectNoSchedule},
+	}, v1.TaintEffectNoExecute))
+
+	// Empty key with the Exists operator tolerates everything
+	require.Empty(t, v1.UntoleratedTaints(taints, []v1.Toleration{
+		{Operator: v1.TolerationOperatorExists},
+	}, v1.TaintEffectNoExecute))
+}
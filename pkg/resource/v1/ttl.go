@@ -0,0 +1,33 @@
+package v1
+
+import "time"
+
+// Finished returns true when the VM has either failed and won't be
+// restarted by the Controller or was stopped and stopped running.
+func (vm *VM) Finished() bool {
+	if vm.Status == VMStatusFailed && !vm.WillRestart() {
+		return true
+	}
+
+	return vm.PowerState == PowerStateStopped && ConditionIsFalse(vm.Conditions, ConditionTypeRunning)
+}
+
+// ExpiresAt returns the earliest point in time at which the VM
+// should be deleted according to its TTL fields, if any.
+func (vm *VM) ExpiresAt() (time.Time, bool) {
+	var expiresAt time.Time
+
+	if vm.TTLSecondsAfterCreation != nil {
+		expiresAt = vm.CreatedAt.Add(time.Duration(*vm.TTLSecondsAfterCreation) * time.Second)
+	}
+
+	if vm.TTLSecondsAfterFinished != nil && !vm.FinishedAt.IsZero() {
+		afterFinished := vm.FinishedAt.Add(time.Duration(*vm.TTLSecondsAfterFinished) * time.Second)
+
+		if expiresAt.IsZero() || afterFinished.Before(expiresAt) {
+			expiresAt = afterFinished
+		}
+	}
+
+	return expiresAt, !expiresAt.IsZero()
+}
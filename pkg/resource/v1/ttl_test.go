@@ -0,0 +1,78 @@
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMFinished(t *testing.T) {
+	vm := v1.VM{Status: v1.VMStatusRunning}
+	vm.PowerState = v1.PowerStateRunning
+	require.False(t, vm.Finished())
+
+	// Failed VMs are finished unless they're going to be restarted
+	vm.Status = v1.VMStatusFailed
+	require.True(t, vm.Finished())
+
+	vm.RestartPolicy = v1.RestartPolicyOnFailure
+	require.False(t, vm.Finished())
+
+	// ...or once they've exhausted their restarts
+	vm.MaxRestarts = 2
+	vm.RestartCount = 2
+	require.True(t, vm.Finished())
+
+	// Stopped VMs are only finished once they stop running
+	vm = v1.VM{Status: v1.VMStatusRunning}
+	vm.PowerState = v1.PowerStateStopped
+	vm.Conditions = []v1.Condition{{Type: v1.ConditionTypeRunning, State: v1.ConditionStateTrue}}
+	require.False(t, vm.Finished())
+
+	vm.Conditions = []v1.Condition{{Type: v1.ConditionTypeRunning, State: v1.ConditionStateFalse}}
+	require.True(t, vm.Finished())
+
+	// Suspended VMs are not finished
+	vm.PowerState = v1.PowerStateSuspended
+	require.False(t, vm.Finished())
+}
+
+func TestVMExpiresAt(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	vm := v1.VM{Meta: v1.Meta{CreatedAt: createdAt}}
+	_, ok := vm.ExpiresAt()
+	require.False(t, ok)
+
+	vm.TTLSecondsAfterCreation = lo.ToPtr[uint64](3600)
+	expiresAt, ok := vm.ExpiresAt()
+	require.True(t, ok)
+	require.Equal(t, createdAt.Add(time.Hour), expiresAt)
+
+	// TTL after finished is only in effect once the VM has finished
+	vm.TTLSecondsAfterFinished = lo.ToPtr[uint64](60)
+	expiresAt, ok = vm.ExpiresAt()
+	require.True(t, ok)
+	require.Equal(t, createdAt.Add(time.Hour), expiresAt)
+
+	// The earliest expiration wins
+	vm.FinishedAt = createdAt.Add(10 * time.Minute)
+	expiresAt, ok = vm.ExpiresAt()
+	require.True(t, ok)
+	require.Equal(t, createdAt.Add(11*time.Minute), expiresAt)
+
+	vm.FinishedAt = createdAt.Add(2 * time.Hour)
+	expiresAt, ok = vm.ExpiresAt()
+	require.True(t, ok)
+	require.Equal(t, createdAt.Add(time.Hour), expiresAt)
+
+	// Zero TTL means that the VM expires immediately
+	vm.TTLSecondsAfterCreation = nil
+	vm.TTLSecondsAfterFinished = lo.ToPtr[uint64](0)
+	expiresAt, ok = vm.ExpiresAt()
+	require.True(t, ok)
+	require.Equal(t, vm.FinishedAt, expiresAt)
+}
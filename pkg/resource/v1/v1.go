@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,14 +14,58 @@ type Meta struct {
 	// There can't be multiple resources with the same Name in the DB at any given time.
 	Name string `json:"name,omitempty"`
 
+	// Namespace isolates the resources of different tenants, only the service
+	// accounts that have role bindings in this namespace (or the corresponding
+	// cluster-wide roles) can access the resource.
+	//
+	// Namespace is only populated for the namespaced resources, such as VMs,
+	// and is empty for the cluster-scoped resources, such as workers.
+	Namespace string `json:"namespace,omitempty"`
+
 	// CreatedAt is a useful field for scheduler prioritization.
 	//
 	// It is populated by the Controller with the current time
 	// when receiving a POST request.
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 
-	// Version is a resource version used internally to implement WatchVM().
+	// Version is a resource version used to implement watches, it can be
+	// passed as a "resourceVersion" to resume watching from a given point.
 	Version uint64 `json:"version,omitempty"`
+
+	// Labels are arbitrary key/value pairs used to organize and select
+	// resources, for example, by a team or by a CI job that created them.
+	//
+	// Unlike VM's and Worker's "labels" field, these labels are purely
+	// informational and are not taken into account when scheduling.
+	Labels map[string]string `json:"metadataLabels,omitempty"`
+
+	// Annotations are arbitrary key/value pairs used to attach non-identifying
+	// information to resources, such as a link to a CI job that created them.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ValidateMetadata ensures that the metadata labels and annotations
+// have non-empty keys that can be referenced in a Selector.
+func (meta *Meta) ValidateMetadata() error {
+	if err := validateMetadataKeys("metadataLabels", meta.Labels); err != nil {
+		return err
+	}
+
+	return validateMetadataKeys("annotations", meta.Annotations)
+}
+
+func validateMetadataKeys(field string, values map[string]string) error {
+	for key := range values {
+		if key == "" {
+			return fmt.Errorf("%s cannot contain an empty key", field)
+		}
+		if strings.ContainsAny(key, " \t(),!=") {
+			return fmt.Errorf("%s key %q cannot contain whitespace, "+
+				"parentheses, commas, exclamation marks and equal signs", field, key)
+		}
+	}
+
+	return nil
 }
 
 type VM struct {
@@ -65,10 +110,30 @@ type VM struct {
 	RestartedAt   time.Time     `json:"restarted_at,omitempty"`
 	RestartCount  uint64        `json:"restart_count,omitempty"`
 
+	// MaxRestarts limits the number of times the VM with the OnFailure
+	// restart policy is restarted, after which the VM stays failed,
+	// zero means no limit.
+	MaxRestarts uint64 `json:"maxRestarts,omitempty"`
+
+	// NextRestartAt is set by the Controller to the point in time
+	// at which the failed VM is going to be restarted.
+	NextRestartAt time.Time `json:"nextRestartAt,omitempty"`
+
+	// ConsecutiveFailures is set by the Controller to the number of times
+	// the VM was restarted without running for the cluster's restart backoff
+	// reset period in between, the restart delay is based on it.
+	ConsecutiveFailures uint64 `json:"consecutiveFailures,omitempty"`
+
 	// RandomSerial controls whether the worker will run the
 	// "tart set --random-serial" when instantiating this VM.
 	RandomSerial bool `json:"randomSerial,omitempty"`
 
+	// CreatedBy is the name of the service account that created this VM.
+	//
+	// It is populated by the Controller when receiving a POST request
+	// and is empty when the Controller runs without authentication.
+	CreatedBy string `json:"createdBy,omitempty"`
+
 	// UID is a useful field for avoiding data races within a single Name.
 	//
 	// It is populated by the Controller when receiving a POST request.
@@ -80,6 +145,50 @@ type VM struct {
 	// Labels required by this VM.
 	Labels Labels `json:"labels,omitempty"`
 
+	// Group is the name of the VMGroup in the VM's namespace that
+	// this VM belongs to, which makes it gang-scheduled.
+	Group string `json:"group,omitempty"`
+
+	// Pool is the name of the VMPool in the VM's namespace that
+	// keeps this VM warm, it's cleared once the VM is claimed.
+	Pool string `json:"pool,omitempty"`
+
+	// VMSet is the name of the VMSet in the VM's namespace that maintains
+	// this VM, and VMSetGeneration is the set's generation that this VM
+	// was created from.
+	VMSet           string `json:"vmSet,omitempty"`
+	VMSetGeneration uint64 `json:"vmSetGeneration,omitempty"`
+
+	// Template is the name of the VMTemplate that this VM was created from,
+	// the fields specified explicitly when creating the VM override the
+	// template's fields.
+	Template string `json:"template,omitempty"`
+
+	// Tolerations allow this VM to be scheduled on the workers with the matching taints.
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+
+	// Affinity further constrains the workers on which this VM can be scheduled.
+	Affinity *Affinity `json:"affinity,omitempty"`
+
+	// Priority determines the order in which the pending VMs are scheduled,
+	// VMs with a higher priority are scheduled first.
+	//
+	// Only the administrators can set a positive priority directly,
+	// other service accounts need to use the PriorityClassName.
+	Priority int64 `json:"priority,omitempty"`
+
+	// PreemptionPolicy controls whether this VM is allowed to evict the
+	// lower-priority VMs in its namespace when no worker has enough resources
+	// to run it.
+	//
+	// Only the administrators can set the PreemptLowerPriority policy directly,
+	// other service accounts need to use the PriorityClassName.
+	PreemptionPolicy PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// PriorityClassName is the name of the priority class defined in the cluster
+	// settings, whose priority and preemption policy are assigned to this VM.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
 	// HostDir is a list of host directories to be mounted to the VM.
 	HostDirs []HostDir `json:"hostDirs,omitempty"`
 
@@ -90,6 +199,20 @@ type VM struct {
 	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
 	StartedAt   time.Time `json:"started_at,omitempty"`
 
+	// FinishedAt is set by the Controller once it observes that
+	// the VM has finished, see Finished() for more details.
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+
+	// TTLSecondsAfterCreation, when set, causes the Controller
+	// to delete the VM once this many seconds have passed
+	// since its creation, regardless of the VM's state.
+	TTLSecondsAfterCreation *uint64 `json:"ttlSecondsAfterCreation,omitempty"`
+
+	// TTLSecondsAfterFinished, when set, causes the Controller
+	// to delete the VM once this many seconds have passed
+	// since the VM has finished.
+	TTLSecondsAfterFinished *uint64 `json:"ttlSecondsAfterFinished,omitempty"`
+
 	// Generation is incremented by the controller each time
 	// the resource's specification is changed.
 	//
@@ -105,12 +228,7 @@ func (vm *VM) SetVersion(version uint64) {
 }
 
 func (vm *VM) Match(filter Filter) bool {
-	switch filter.Path {
-	case "worker":
-		return vm.Worker == filter.Value
-	default:
-		return false
-	}
+	return filter.Selector().Matches(vm)
 }
 
 func (vm *VM) SSHUsername() string {
@@ -342,6 +460,14 @@ type EventKind string
 
 const (
 	EventKindLogLine EventKind = "log_line"
+
+	// EventKindPreempted is emitted when the VM was
+	// evicted in favor of a higher-priority VM
+	EventKindPreempted EventKind = "preempted"
+
+	// EventKindEvicted is emitted when the VM was evicted from
+	// the worker due to a taint that the VM doesn't tolerate
+	EventKindEvicted EventKind = "evicted"
 )
 
 type VMScript struct {
@@ -396,4 +522,14 @@ type ControllerInfo struct {
 	Version      string                 `json:"version,omitempty"`
 	Commit       string                 `json:"commit,omitempty"`
 	Capabilities ControllerCapabilities `json:"capabilities,omitempty"`
+
+	// Identity of the controller replica that served the request.
+	Identity string `json:"identity,omitempty"`
+
+	// Leader is the identity of the controller replica
+	// that currently runs the scheduler.
+	Leader string `json:"leader,omitempty"`
+
+	// Term increases each time a new leader is elected.
+	Term uint64 `json:"term,omitempty"`
 }
@@ -0,0 +1,44 @@
+package v1
+
+import "time"
+
+type VMGroupStatus string
+
+const (
+	// VMGroupStatusPending means that less than MinMember
+	// of the group's VMs are currently scheduled.
+	VMGroupStatusPending VMGroupStatus = "pending"
+
+	// VMGroupStatusScheduled means that at least MinMember
+	// of the group's VMs are currently scheduled.
+	VMGroupStatusScheduled VMGroupStatus = "scheduled"
+)
+
+// VMGroup is a set of VMs that are scheduled together (gang-scheduled): the group's
+// VMs are only assigned to the workers once at least MinMember of them can be
+// assigned at the same time, which avoids partially scheduled groups that hold
+// the resources without being able to make any progress.
+//
+// VMs join the group by referring to it in their Group field.
+type VMGroup struct {
+	// MinMember is the minimum number of the group's VMs that need to be scheduled together.
+	MinMember uint64 `json:"minMember,omitempty"`
+
+	// Status is set by the Controller to reflect whether
+	// the group's VMs are currently scheduled.
+	Status        VMGroupStatus `json:"status,omitempty"`
+	StatusMessage string        `json:"status_message,omitempty"`
+
+	// ScheduledAt is the time when the group was last scheduled.
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
+
+	Meta
+}
+
+func (vmGroup *VMGroup) SetVersion(version uint64) {
+	vmGroup.Version = version
+}
+
+func (vmGroup *VMGroup) Match(filter Filter) bool {
+	return filter.Selector().Matches(vmGroup)
+}
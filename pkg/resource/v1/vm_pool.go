@@ -0,0 +1,61 @@
+package v1
+
+// VMPool keeps a number of pre-booted (warm) VMs created from the same
+// template, which can then be claimed to avoid waiting for the VM to be
+// cloned and booted.
+//
+// Pool's VMs refer to it in their Pool field. Once a VM is claimed, it stops
+// belonging to the pool and the Controller creates a replacement VM in the
+// background.
+type VMPool struct {
+	// Replicas is the number of warm VMs that the pool maintains.
+	Replicas uint64 `json:"replicas"`
+
+	// Template is the specification of the pool's VMs,
+	// its name and namespace are ignored.
+	Template VM `json:"template"`
+
+	// ReadyReplicas is set by the Controller to the number
+	// of pool's VMs that are running and can be claimed.
+	ReadyReplicas uint64 `json:"readyReplicas,omitempty"`
+
+	// StatusMessage is set by the Controller when
+	// the pool cannot be fully replenished.
+	StatusMessage string `json:"status_message,omitempty"`
+
+	// Hits and Misses are incremented by the Controller each time
+	// a claim succeeds or fails due to no ready VMs being available.
+	Hits   uint64 `json:"hits,omitempty"`
+	Misses uint64 `json:"misses,omitempty"`
+
+	Meta
+}
+
+func (vmPool *VMPool) SetVersion(version uint64) {
+	vmPool.Version = version
+}
+
+func (vmPool *VMPool) Match(filter Filter) bool {
+	return filter.Selector().Matches(vmPool)
+}
+
+// HitRate returns the share of the claims that were
+// satisfied with a warm VM, or zero if there were no claims.
+func (vmPool *VMPool) HitRate() float64 {
+	total := vmPool.Hits + vmPool.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(vmPool.Hits) / float64(total)
+}
+
+// VMPoolClaim is an optional body of the claim request
+// that is used to re-label the claimed VM.
+type VMPoolClaim struct {
+	// Labels are merged into the claimed VM's metadata labels.
+	Labels map[string]string `json:"metadataLabels,omitempty"`
+
+	// Annotations are merged into the claimed VM's annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
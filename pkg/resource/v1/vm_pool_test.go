@@ -0,0 +1,14 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMPoolHitRate(t *testing.T) {
+	require.Zero(t, (&v1.VMPool{}).HitRate())
+	require.InDelta(t, 0.75, (&v1.VMPool{Hits: 3, Misses: 1}).HitRate(), 0.001)
+	require.InDelta(t, 1.0, (&v1.VMPool{Hits: 5}).HitRate(), 0.001)
+}
@@ -0,0 +1,62 @@
+package v1
+
+// VMSet keeps a fixed number of identical VMs created from the same
+// template running, replacing the VMs that fail or get deleted.
+//
+// Set's VMs refer to it in their VMSet field, and to the set's generation
+// they were created from in their VMSetGeneration field. When the set's
+// template changes, the Controller gradually replaces the outdated VMs,
+// keeping at most MaxUnavailable VMs unavailable at any time.
+type VMSet struct {
+	// Replicas is the number of VMs that the set maintains.
+	Replicas uint64 `json:"replicas"`
+
+	// Template is the specification of the set's VMs,
+	// its name and namespace are ignored.
+	Template VM `json:"template"`
+
+	// MaxUnavailable is the maximum number of set's VMs that can be unavailable
+	// (that is, not running) while rolling out the template changes, defaults to 1.
+	MaxUnavailable uint64 `json:"maxUnavailable,omitempty"`
+
+	// Generation is incremented by the Controller
+	// each time the set's template is changed.
+	Generation uint64 `json:"generation"`
+
+	// ReadyReplicas is set by the Controller to the number of set's VMs that are running.
+	ReadyReplicas uint64 `json:"readyReplicas,omitempty"`
+
+	// UpdatedReplicas is set by the Controller to the number
+	// of set's VMs that were created from the current template.
+	UpdatedReplicas uint64 `json:"updatedReplicas,omitempty"`
+
+	// StatusMessage is set by the Controller when
+	// the set cannot be fully scaled up.
+	StatusMessage string `json:"status_message,omitempty"`
+
+	Meta
+}
+
+func (vmSet *VMSet) SetVersion(version uint64) {
+	vmSet.Version = version
+}
+
+func (vmSet *VMSet) Match(filter Filter) bool {
+	return filter.Selector().Matches(vmSet)
+}
+
+// EffectiveMaxUnavailable returns the MaxUnavailable,
+// taking the default value into account.
+func (vmSet *VMSet) EffectiveMaxUnavailable() uint64 {
+	if vmSet.MaxUnavailable == 0 {
+		return 1
+	}
+
+	return vmSet.MaxUnavailable
+}
+
+// RolledOut returns true when all of the set's VMs
+// were created from the current template and are running.
+func (vmSet *VMSet) RolledOut() bool {
+	return vmSet.UpdatedReplicas == vmSet.Replicas && vmSet.ReadyReplicas == vmSet.Replicas
+}
@@ -0,0 +1,20 @@
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/cirruslabs/orchard/pkg/resource/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMSetEffectiveMaxUnavailable(t *testing.T) {
+	require.EqualValues(t, 1, (&v1.VMSet{}).EffectiveMaxUnavailable())
+	require.EqualValues(t, 3, (&v1.VMSet{MaxUnavailable: 3}).EffectiveMaxUnavailable())
+}
+
+func TestVMSetRolledOut(t *testing.T) {
+	require.True(t, (&v1.VMSet{}).RolledOut())
+	require.True(t, (&v1.VMSet{Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2}).RolledOut())
+	require.False(t, (&v1.VMSet{Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 1}).RolledOut())
+	require.False(t, (&v1.VMSet{Replicas: 2, ReadyReplicas: 1, UpdatedReplicas: 2}).RolledOut())
+}
@@ -0,0 +1,22 @@
+package v1
+
+// VMTemplate is a reusable VM specification maintained centrally on the
+// Controller, which allows creating the VMs by only specifying the template's
+// name and the fields that need to be overridden.
+//
+// Changing the template doesn't affect the VMs that were already created from it.
+type VMTemplate struct {
+	// Spec is the specification that the VMs created from this template
+	// start with, its name and namespace are ignored.
+	Spec VM `json:"spec"`
+
+	Meta
+}
+
+func (vmTemplate *VMTemplate) SetVersion(version uint64) {
+	vmTemplate.Version = version
+}
+
+func (vmTemplate *VMTemplate) Match(filter Filter) bool {
+	return filter.Selector().Matches(vmTemplate)
+}
@@ -0,0 +1,20 @@
+package v1
+
+type WatchMessageType string
+
+const (
+	WatchMessageTypeAdded    WatchMessageType = "ADDED"
+	WatchMessageTypeModified WatchMessageType = "MODIFIED"
+	WatchMessageTypeDeleted  WatchMessageType = "DELETED"
+)
+
+// WatchMessage is a single change to a resource observed when watching.
+//
+// For WatchMessageTypeDeleted messages received when watching a collection
+// of resources, the object is the last known state of the deleted resource,
+// with the Version being the version of the deletion. When the last known
+// state is not available, only the Name and the Version fields are populated.
+type WatchMessage[T any] struct {
+	Type   WatchMessageType `json:"type,omitempty"`
+	Object T                `json:"object,omitempty"`
+}
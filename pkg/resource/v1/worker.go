@@ -17,6 +17,9 @@ type Worker struct {
 	// Labels that this Worker supports.
 	Labels Labels `json:"labels,omitempty"`
 
+	// Taints repel the VMs that don't tolerate them from this Worker.
+	Taints []Taint `json:"taints,omitempty"`
+
 	// DefaultCPU is the amount of CPUs to assign to a VM
 	// when it doesn't explicitly request a specific amount.
 	DefaultCPU uint64 `json:"defaultCPU,omitempty"`
@@ -30,6 +33,11 @@ type Worker struct {
 	// Runtime defines a runtime provided by this worker.
 	Runtime Runtime `json:"runtime,omitempty"`
 
+	// CachedImages are the images that this Worker has cached locally,
+	// which lets the scheduler prefer the Workers that won't need to pull
+	// the VM's image.
+	CachedImages []string `json:"cachedImages,omitempty"`
+
 	Meta
 }
 
@@ -37,8 +45,10 @@ func (worker Worker) Offline(workerOfflineTimeout time.Duration) bool {
 	return time.Since(worker.LastSeen) > workerOfflineTimeout
 }
 
-func (worker *Worker) SetVersion(_ uint64) {}
+func (worker *Worker) SetVersion(version uint64) {
+	worker.Version = version
+}
 
 func (worker *Worker) Match(filter Filter) bool {
-	return false
+	return filter.Selector().Matches(worker)
 }